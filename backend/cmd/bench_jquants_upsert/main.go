@@ -0,0 +1,150 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"stock-prediction/backend/db"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+
+	"gorm.io/gorm"
+)
+
+// 銘柄マスタ・日足の保存方法（1件ずつの CreateOrUpdate* と、まとめて保存する Upsert*）の速度を比較する
+// 実際のテーブルに "BENCH" で始まる銘柄コードのダミーデータを保存し、終了時に削除する
+//
+// 使用方法（backendディレクトリから実行）:
+//
+//	go run ./cmd/bench_jquants_upsert                           4,000銘柄 × 5営業日
+//	go run ./cmd/bench_jquants_upsert -companies 500 -days 20   件数を指定
+const benchCodePrefix = "BENCH"
+
+func main() {
+	companyCount := flag.Int("companies", 4000, "ダミー銘柄の数")
+	days := flag.Int("days", 5, "銘柄ごとの日足の日数")
+	flag.Parse()
+
+	dbConn := db.NewDB()
+	defer db.CloseDB(dbConn)
+
+	repository := repositories.NewJapaneseStockRepository(dbConn)
+	companies, dailyQuotes := generate(*companyCount, *days)
+
+	cleanup(dbConn)
+	defer cleanup(dbConn)
+
+	fmt.Printf("📊 銘柄マスタ %d件 / 日足 %d件\n", len(companies), len(dailyQuotes))
+	fmt.Println("==========================================")
+
+	rowByRow := []result{
+		measure("CreateOrUpdateCompany（新規）", len(companies), func() error { return saveCompaniesRowByRow(repository, companies) }),
+		measure("CreateOrUpdateCompany（更新）", len(companies), func() error { return saveCompaniesRowByRow(repository, companies) }),
+		measure("CreateOrUpdateDailyQuote（新規）", len(dailyQuotes), func() error { return saveDailyQuotesRowByRow(repository, dailyQuotes) }),
+		measure("CreateOrUpdateDailyQuote（更新）", len(dailyQuotes), func() error { return saveDailyQuotesRowByRow(repository, dailyQuotes) }),
+	}
+	cleanup(dbConn)
+
+	bulk := []result{
+		measure("UpsertCompanies（新規）", len(companies), func() error { return repository.UpsertCompanies(companies) }),
+		measure("UpsertCompanies（更新）", len(companies), func() error { return repository.UpsertCompanies(companies) }),
+		measure("UpsertDailyQuotes（新規）", len(dailyQuotes), func() error { return repository.UpsertDailyQuotes(dailyQuotes) }),
+		measure("UpsertDailyQuotes（更新）", len(dailyQuotes), func() error { return repository.UpsertDailyQuotes(dailyQuotes) }),
+	}
+
+	fmt.Println("==========================================")
+	for i := range rowByRow {
+		fmt.Printf("  %s → %s: %.1f倍\n", rowByRow[i].name, bulk[i].name, rowByRow[i].elapsed.Seconds()/bulk[i].elapsed.Seconds())
+	}
+	fmt.Println("✅ 完了")
+}
+
+type result struct {
+	name    string
+	elapsed time.Duration
+}
+
+func measure(name string, rows int, fn func() error) result {
+	start := time.Now()
+	if err := fn(); err != nil {
+		log.Fatalf("❌ %s: %v", name, err)
+	}
+	elapsed := time.Since(start)
+	fmt.Printf("  %-36s %8d件 %10s %10.0f件/秒\n", name, rows, elapsed.Round(time.Millisecond), float64(rows)/elapsed.Seconds())
+	return result{name: name, elapsed: elapsed}
+}
+
+// generate ダミーの銘柄マスタと日足を作成する（日付は実データと重ならないよう2099年の平日を使う）
+func generate(companyCount int, days int) ([]models.Company, []models.DailyQuote) {
+	var dates []string
+	for day := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC); len(dates) < days; day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			dates = append(dates, day.Format("2006-01-02"))
+		}
+	}
+
+	companies := make([]models.Company, 0, companyCount)
+	dailyQuotes := make([]models.DailyQuote, 0, companyCount*days)
+	for i := 0; i < companyCount; i++ {
+		code := fmt.Sprintf("%s%05d", benchCodePrefix, i)
+		companies = append(companies, models.Company{
+			Code:             code,
+			CompanyName:      "ベンチマーク" + code,
+			Sector33Code:     "9999",
+			Sector33CodeName: "ベンチマーク",
+			MarketCode:       "0111",
+			MarketCodeName:   "プライム",
+		})
+		for j, date := range dates {
+			price := float64(1000 + i%500 + j)
+			dailyQuotes = append(dailyQuotes, models.DailyQuote{
+				Code:             code,
+				Date:             date,
+				Open:             price,
+				High:             price + 10,
+				Low:              price - 10,
+				Close:            price + 5,
+				Volume:           100000,
+				TurnoverValue:    price * 100000,
+				AdjustmentFactor: 1,
+				AdjustmentOpen:   price,
+				AdjustmentHigh:   price + 10,
+				AdjustmentLow:    price - 10,
+				AdjustmentClose:  price + 5,
+				AdjustmentVolume: 100000,
+			})
+		}
+	}
+	return companies, dailyQuotes
+}
+
+func saveCompaniesRowByRow(repository repositories.IJapaneseStockRepository, companies []models.Company) error {
+	for _, company := range companies {
+		// CreateOrUpdateCompany は引数のIDを書き換えるのでコピーを渡す
+		if err := repository.CreateOrUpdateCompany(&company); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func saveDailyQuotesRowByRow(repository repositories.IJapaneseStockRepository, dailyQuotes []models.DailyQuote) error {
+	for _, dailyQuote := range dailyQuotes {
+		if err := repository.CreateOrUpdateDailyQuote(&dailyQuote); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanup ダミーデータを削除する（銘柄マスタは論理削除ではなく物理削除する）
+func cleanup(dbConn *gorm.DB) {
+	if err := dbConn.Where("code LIKE ?", benchCodePrefix+"%").Delete(&models.DailyQuote{}).Error; err != nil {
+		log.Printf("⚠️  日足のダミーデータの削除に失敗しました: %v", err)
+	}
+	if err := dbConn.Unscoped().Where("code LIKE ?", benchCodePrefix+"%").Delete(&models.Company{}).Error; err != nil {
+		log.Printf("⚠️  銘柄マスタのダミーデータの削除に失敗しました: %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock-prediction/backend/openapi"
+	"stock-prediction/backend/services/calendar"
+)
+
+// 起動中のAPIサーバーのレスポンスが /api/openapi.json の仕様と一致するかを確認する（契約テスト）
+//   - 仕様のすべての GET のオペレーションを、パスパラメータと必須のクエリパラメータを埋めて呼び出し、
+//     仕様の成功時のステータス（2xx）が返ることを確認する（APIキー未指定の管理系APIは401、{id} のAPIはIDが無い場合の404も可）
+//   - 入力エラー・存在しない銘柄・未認証などのエラーケースと、スクリーナー（POST）も呼び出す
+//   - ステータスコードが期待通りか、ボディがそのステータスのスキーマに合うか（仕様に無いプロパティを含む）を確認する
+//   - 500 は仕様に書かれていても失敗とする
+//
+// 管理系APIの POST（同期・投稿などのジョブ登録）は副作用があるため呼び出さない
+//
+// 使用方法（backendディレクトリから実行。先に go run . でサーバーを起動し、-ticker・-code・-sector のデータを同期しておく）:
+//
+//	go run ./cmd/check_openapi
+//	go run ./cmd/check_openapi -base http://localhost:8080 -ticker AAPL -code 67580 -api-key sk_...
+//
+// 不一致が1件でもあれば終了コード1で終了する
+func main() {
+	base := flag.String("base", "http://localhost:8080", "APIサーバーのURL")
+	ticker := flag.String("ticker", "NVDA", "米国株のAPIで使うティッカー")
+	code := flag.String("code", "72030", "日本株のAPIで使う銘柄コード")
+	sectorCode := flag.String("sector", "5250", "セクターのAPIで使う33業種コード")
+	date := flag.String("date", calendar.NYSE.LatestSessionDate(time.Now()), "日付が必須のAPIで使う日付")
+	id := flag.String("id", "1", "ジョブID・APIキーIDなどのパスパラメータ")
+	apiKey := flag.String("api-key", os.Getenv("ADMIN_API_KEY"), "管理系APIのAPIキー（省略時は ADMIN_API_KEY。未設定の場合は401になることを確認する）")
+	flag.Parse()
+
+	checker := &checker{base: strings.TrimSuffix(*base, "/"), apiKey: *apiKey, client: &http.Client{Timeout: 30 * time.Second}}
+	spec, err := checker.fetchSpec()
+	if err != nil {
+		log.Fatalf("❌ Failed to fetch the spec: %v", err)
+	}
+	checker.spec = spec
+
+	pathParams := map[string]string{
+		"ticker":     *ticker,
+		"code":       *code,
+		"sectorCode": *sectorCode,
+		"id":         *id,
+	}
+	requiredQuery := map[string]string{"date": *date}
+
+	fmt.Printf("📄 %s %s（%d paths）\n", spec.Info.Title, spec.Info.Version, len(spec.Paths))
+	fmt.Println("==========================================")
+
+	// 1. 仕様のすべての GET
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		operation := spec.Paths[path].Get
+		if operation == nil {
+			continue
+		}
+		tc := testCase{
+			name:          operation.OperationID,
+			method:        http.MethodGet,
+			specPath:      path,
+			url:           fillPath(path, pathParams) + requiredQueryString(operation, requiredQuery),
+			withKey:       len(operation.Security) > 0,
+			status:        successStatus(operation),
+			allowNotFound: strings.Contains(path, "{id}"),
+		}
+		if tc.withKey && checker.apiKey == "" {
+			tc.status = http.StatusUnauthorized
+			tc.allowNotFound = false
+		}
+		checker.check(tc)
+	}
+
+	// 2. エラーケースとPOST
+	extra := []testCase{
+		{name: "invalid category", method: http.MethodGet, specPath: "/api/stocks/latest", url: "/api/stocks/latest?category=invalid", status: http.StatusBadRequest},
+		{name: "maxRank out of range", method: http.MethodGet, specPath: "/api/stocks/latest", url: "/api/stocks/latest?maxRank=1000", status: http.StatusBadRequest},
+		{name: "unknown ticker", method: http.MethodGet, specPath: "/api/stocks/{ticker}", url: "/api/stocks/NO-SUCH-TICKER", status: http.StatusNotFound},
+		{name: "invalid cursor", method: http.MethodGet, specPath: "/api/stocks/{ticker}", url: "/api/stocks/" + url.PathEscape(*ticker) + "?cursor=%21%21", status: http.StatusBadRequest},
+		{name: "invalid date", method: http.MethodGet, specPath: "/api/jp/stocks/date", url: "/api/jp/stocks/date?date=2025-13-01", status: http.StatusBadRequest},
+		{name: "invalid market", method: http.MethodGet, specPath: "/api/jp/stocks/latest", url: "/api/jp/stocks/latest?market=tokyo", status: http.StatusBadRequest},
+		{name: "screen JP", method: http.MethodPost, specPath: "/api/screener", url: "/api/screener", body: `{"market":"JP","conditions":[{"field":"PER","op":"lte","value":15}],"sortBy":"MarketCap","sortOrder":"desc","perPage":5}`, status: http.StatusOK},
+		{name: "screen US", method: http.MethodPost, specPath: "/api/screener", url: "/api/screener", body: `{"market":"US","perPage":5}`, status: http.StatusOK},
+		{name: "screen invalid field", method: http.MethodPost, specPath: "/api/screener", url: "/api/screener", body: `{"market":"JP","conditions":[{"field":"NoSuchField","op":"eq","value":1}]}`, status: http.StatusBadRequest},
+		{name: "admin without api key", method: http.MethodGet, specPath: "/api/admin/jobs", url: "/api/admin/jobs", status: http.StatusUnauthorized},
+	}
+	for _, tc := range extra {
+		checker.check(tc)
+	}
+
+	// 3. 仕様に無いルートもエラーの形式で返すこと
+	checker.checkErrorResponse("unknown route", "/api/no-such-route", http.StatusNotFound)
+
+	fmt.Println("==========================================")
+	fmt.Printf("✅ %d passed / ❌ %d failed\n", checker.passed, checker.failed)
+	if checker.failed > 0 {
+		os.Exit(1)
+	}
+}
+
+type testCase struct {
+	name     string
+	method   string
+	specPath string // 仕様のパス（例: /api/stocks/{ticker}）
+	url      string // 実際に呼び出すパスとクエリ
+	body     string
+	withKey  bool
+	// status 期待するステータスコード（allowNotFound の場合は404も可。ジョブID・APIキーIDなど、-id のIDが無い場合）
+	status        int
+	allowNotFound bool
+}
+
+type checker struct {
+	base   string
+	apiKey string
+	client *http.Client
+	spec   *openapi.Document
+	passed int
+	failed int
+}
+
+func (c *checker) fetchSpec() (*openapi.Document, error) {
+	resp, err := c.client.Get(c.base + "/api/openapi.json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	var spec openapi.Document
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to decode spec: %w", err)
+	}
+	return &spec, nil
+}
+
+func (c *checker) check(tc testCase) {
+	label := fmt.Sprintf("%s %s (%s)", tc.method, tc.url, tc.name)
+
+	item, ok := c.spec.Paths[tc.specPath]
+	if !ok || item.Operation(tc.method) == nil {
+		c.fail(label, fmt.Sprintf("%s %s is not in the spec", tc.method, tc.specPath))
+		return
+	}
+	operation := item.Operation(tc.method)
+
+	status, contentType, body, err := c.do(tc.method, tc.url, tc.body, tc.withKey)
+	if err != nil {
+		c.fail(label, err.Error())
+		return
+	}
+	if status == http.StatusInternalServerError {
+		c.fail(label, fmt.Sprintf("server error: %s", truncate(body)))
+		return
+	}
+	if status != tc.status && !(tc.allowNotFound && status == http.StatusNotFound) {
+		c.fail(label, fmt.Sprintf("expected status %d, got %d: %s", tc.status, status, truncate(body)))
+		return
+	}
+	response, ok := operation.Responses[strconv.Itoa(status)]
+	if !ok {
+		c.fail(label, fmt.Sprintf("status %d is not documented: %s", status, truncate(body)))
+		return
+	}
+
+	c.validateBody(label, status, response, contentType, body)
+}
+
+// checkErrorResponse 仕様に無いルートが ErrorResponse の形式で返ることを確認する
+func (c *checker) checkErrorResponse(name string, path string, expectedStatus int) {
+	label := fmt.Sprintf("GET %s (%s)", path, name)
+	status, contentType, body, err := c.do(http.MethodGet, path, "", false)
+	if err != nil {
+		c.fail(label, err.Error())
+		return
+	}
+	if status != expectedStatus {
+		c.fail(label, fmt.Sprintf("expected status %d, got %d", expectedStatus, status))
+		return
+	}
+	response := &openapi.Response{Content: map[string]*openapi.MediaType{
+		"application/json": {Schema: &openapi.Schema{Ref: "#/components/schemas/ErrorResponse"}},
+	}}
+	c.validateBody(label, status, response, contentType, body)
+}
+
+func (c *checker) validateBody(label string, status int, response *openapi.Response, contentType string, body []byte) {
+	mediaType, ok := response.Content["application/json"]
+	if !ok {
+		// JSON以外（Swagger UIのHTMLなど）はContent-Typeのみ確認する
+		for expected := range response.Content {
+			if !strings.HasPrefix(contentType, expected) {
+				c.fail(label, fmt.Sprintf("expected Content-Type %s, got %s", expected, contentType))
+				return
+			}
+		}
+		c.pass(label, status)
+		return
+	}
+	if !strings.HasPrefix(contentType, "application/json") {
+		c.fail(label, fmt.Sprintf("expected JSON, got Content-Type %s", contentType))
+		return
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		c.fail(label, fmt.Sprintf("invalid JSON: %v", err))
+		return
+	}
+	if problems := c.spec.Validate(mediaType.Schema, value); len(problems) > 0 {
+		c.fail(label, strings.Join(problems, "\n      "))
+		return
+	}
+	c.pass(label, status)
+}
+
+func (c *checker) do(method string, path string, body string, withKey bool) (int, string, []byte, error) {
+	var reader io.Reader
+	if body != "" {
+		reader = bytes.NewBufferString(body)
+	}
+	req, err := http.NewRequest(method, c.base+path, reader)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if withKey && c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	return resp.StatusCode, resp.Header.Get("Content-Type"), respBody, nil
+}
+
+func (c *checker) pass(label string, status int) {
+	c.passed++
+	fmt.Printf("✅ %s → %d\n", label, status)
+}
+
+func (c *checker) fail(label string, reason string) {
+	c.failed++
+	fmt.Printf("❌ %s\n      %s\n", label, reason)
+}
+
+// successStatus 仕様に書かれた成功時（2xx）のステータスコード（無い場合は200）
+func successStatus(operation *openapi.Operation) int {
+	for code := range operation.Responses {
+		if status, err := strconv.Atoi(code); err == nil && status >= 200 && status < 300 {
+			return status
+		}
+	}
+	return http.StatusOK
+}
+
+// fillPath 仕様のパスの {param} を値で置き換える
+func fillPath(path string, params map[string]string) string {
+	for name, value := range params {
+		path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+	}
+	return path
+}
+
+// requiredQueryString 必須のクエリパラメータを values の値で埋める
+func requiredQueryString(operation *openapi.Operation, values map[string]string) string {
+	query := url.Values{}
+	for _, param := range operation.Parameters {
+		if param.In == "query" && param.Required {
+			query.Set(param.Name, values[param.Name])
+		}
+	}
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
+
+func truncate(body []byte) string {
+	const maxLength = 200
+	if len(body) > maxLength {
+		return string(body[:maxLength]) + "..."
+	}
+	return string(body)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"stock-prediction/backend/db"
+	"stock-prediction/backend/migrations"
+)
+
+// 使用方法（backendディレクトリから実行）:
+//
+//	go run ./cmd/migrate up        未適用のマイグレーションをすべて適用
+//	go run ./cmd/migrate down [n]  直近n件（省略時1件）のマイグレーションを取り消す
+//	go run ./cmd/migrate status    適用状況を表示
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("❌ エラー: コマンドが指定されていません。\n" +
+			"   使用方法: go run ./cmd/migrate <up|down [n]|status>")
+	}
+
+	dbConn := db.NewDB()
+	defer db.CloseDB(dbConn)
+
+	switch os.Args[1] {
+	case "up":
+		if err := migrations.Migrate(dbConn); err != nil {
+			log.Fatalf("❌ マイグレーションエラー: %v", err)
+		}
+		fmt.Println("✅ マイグレーション完了")
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			n, err := strconv.Atoi(os.Args[2])
+			if err != nil || n < 1 {
+				log.Fatalf("❌ 不正なステップ数です: %s", os.Args[2])
+			}
+			steps = n
+		}
+		if err := migrations.Rollback(dbConn, steps); err != nil {
+			log.Fatalf("❌ ロールバックエラー: %v", err)
+		}
+		fmt.Printf("✅ %d件のマイグレーションを取り消しました\n", steps)
+	case "status":
+		statuses, err := migrations.Status(dbConn)
+		if err != nil {
+			log.Fatalf("❌ エラー: %v", err)
+		}
+		fmt.Println("📋 マイグレーション状況:")
+		for _, s := range statuses {
+			if s.Applied {
+				fmt.Printf("  ✅ %04d_%s (%s)\n", s.Version, s.Name, s.AppliedAt.Format("2006-01-02 15:04:05"))
+			} else {
+				fmt.Printf("  ⏳ %04d_%s (未適用)\n", s.Version, s.Name)
+			}
+		}
+	default:
+		log.Fatalf("❌ 不明なコマンドです: %s (up | down [n] | status)", os.Args[1])
+	}
+}
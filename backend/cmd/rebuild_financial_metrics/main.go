@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"stock-prediction/backend/db"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	fundamentals "stock-prediction/backend/services/Japanese_Stock/fandamentals"
+	"stock-prediction/backend/services/valuation"
+)
+
+// 保存済みの財務諸表（RawJSON）から財務指標（financial_metrics）を作り直し、
+// 保存済みの日足と合わせて日次のバリュエーション（japanese_stock_valuations）も計算し直す
+// 財務指標・バリュエーションのテーブル追加前に同期した銘柄や、パーサーを変更した場合に使う
+//
+// 使用方法（backendディレクトリから実行）:
+//
+//	go run ./cmd/rebuild_financial_metrics            財務諸表のある全銘柄
+//	go run ./cmd/rebuild_financial_metrics 7203 6758  指定した銘柄のみ
+func main() {
+	dbConn := db.NewDB()
+	defer db.CloseDB(dbConn)
+
+	repository := repositories.NewJapaneseStockRepository(dbConn)
+
+	codes := os.Args[1:]
+	if len(codes) == 0 {
+		if err := dbConn.Model(&models.FinancialStatement{}).Distinct().Order("code").Pluck("code", &codes).Error; err != nil {
+			log.Fatalf("❌ エラー: %v", err)
+		}
+	}
+
+	fmt.Printf("📊 %d銘柄の財務指標を作り直します\n", len(codes))
+	failed := 0
+	for _, code := range codes {
+		count, err := fundamentals.RebuildFinancialMetrics(code, repository)
+		if err != nil {
+			log.Printf("❌ %s: %v", code, err)
+			failed++
+			continue
+		}
+		valuations, err := valuation.SyncJapaneseValuations(code, repository)
+		if err != nil {
+			log.Printf("❌ %s: %v", code, err)
+			failed++
+			continue
+		}
+		fmt.Printf("  ✅ %s: 財務指標 %d件 / バリュエーション %d件\n", code, count, valuations)
+	}
+
+	if failed > 0 {
+		log.Fatalf("❌ %d銘柄で失敗しました", failed)
+	}
+	fmt.Println("✅ 完了")
+}
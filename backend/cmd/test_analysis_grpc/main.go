@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"stock-prediction/backend/db"
+	"stock-prediction/backend/proto/analysispb"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/Japanese_Stock/analysis"
+	"stock-prediction/backend/services/Japanese_Stock/analysis/analysistest"
+)
+
+// Python分析サービスの代わりにインプロセスのfake gRPCサーバーを起動して、Go側のクライアントとジョブを確認する
+//
+//	go run ./cmd/test_analysis_grpc          RPCの疎通のみ確認（DB不要）
+//	go run ./cmd/test_analysis_grpc 86970    DBのデータからリクエストを組み立て、結果をanalysis_resultsに保存
+//	go run ./cmd/test_analysis_grpc sector 5250 [YYYY-MM-DD]
+//	                                         分析済みの結果からセクター比較を行い、sector_analysis_resultsに保存
+func main() {
+	fakeServer := &analysistest.FakeServer{Sentiment: analysispb.Sentiment_SENTIMENT_BUY}
+	conn, stop, err := analysistest.Start(fakeServer)
+	if err != nil {
+		log.Fatalf("❌ fakeサーバーの起動に失敗しました: %v", err)
+	}
+	defer stop()
+
+	client := analysis.NewClientWithConn(conn)
+	ctx := context.Background()
+
+	if len(os.Args) < 2 {
+		fmt.Println("🔌 fake gRPCサーバーとの疎通を確認中...")
+		fmt.Println("==========================================")
+
+		strategy, err := client.AnalyzeStrategy(ctx, &analysispb.StrategyRequest{
+			Ticker:          "86970",
+			CompanyInfo:     &analysispb.CompanyInfo{Code: "86970", CompanyName: "日本取引所グループ"},
+			QualitativeInfo: []string{"サンプルニュース"},
+		})
+		if err != nil {
+			log.Fatalf("❌ Phase 1エラー: %v", err)
+		}
+		fmt.Printf("✅ Phase 1: BusinessModel=%s / KPI=%s\n", strategy.GetBusinessModel(), strategy.GetKpi())
+
+		execution, err := client.AnalyzeExecution(ctx, &analysispb.ExecutionRequest{
+			Ticker:        "86970",
+			Kpi:           strategy.GetKpi(),
+			BusinessModel: strategy.GetBusinessModel(),
+			StockPrices:   []*analysispb.StockPrice{{Date: "2025-01-06", Close: 1800}},
+		})
+		if err != nil {
+			log.Fatalf("❌ Phase 2エラー: %v", err)
+		}
+		fmt.Printf("✅ Phase 2: Sentiment=%s / %s\n", analysis.SentimentToString(execution.GetSentiment()), execution.GetStockSummary())
+
+		comparison, err := client.CompareSector(ctx, &analysispb.SectorComparisonRequest{
+			SectorCode:   "5250",
+			SectorName:   "情報・通信業",
+			AnalysisDate: analysis.TodayInTokyo(),
+			Analyses: []*analysispb.StockAnalysisSummary{
+				{Ticker: "86970", Sentiment: execution.GetSentiment()},
+				{Ticker: "47550", Sentiment: analysispb.Sentiment_SENTIMENT_HOLD},
+			},
+		})
+		if err != nil {
+			log.Fatalf("❌ Phase 3エラー: %v", err)
+		}
+		for _, pick := range comparison.GetTopPicks() {
+			fmt.Printf("✅ Phase 3: %d位 %s (%s)\n", pick.GetRank(), pick.GetTicker(), pick.GetReasoning())
+		}
+		return
+	}
+
+	if os.Args[1] == "sector" {
+		if len(os.Args) < 3 {
+			log.Fatal("❌ 使用方法: go run ./cmd/test_analysis_grpc sector <33業種コード> [YYYY-MM-DD]")
+		}
+		analysisDate := analysis.TodayInTokyo()
+		if len(os.Args) > 3 {
+			analysisDate = os.Args[3]
+		}
+
+		dbConn := db.NewDB()
+		defer db.CloseDB(dbConn)
+
+		repo := repositories.NewJapaneseStockRepository(dbConn)
+		job := analysis.NewSectorComparisonJob(repo, client)
+		sectorResult, err := job.CompareSector(ctx, os.Args[2], analysisDate)
+		if err != nil {
+			log.Fatalf("❌ エラー: %v", err)
+		}
+
+		fmt.Println("✅ セクター比較結果を保存しました")
+		jsonData, _ := json.MarshalIndent(sectorResult, "", "  ")
+		fmt.Println(string(jsonData))
+		return
+	}
+
+	code := os.Args[1]
+	fmt.Printf("🔍 銘柄コード: %s の分析ジョブを実行中（fakeサーバー使用）...\n", code)
+	fmt.Println("==========================================")
+
+	dbConn := db.NewDB()
+	defer db.CloseDB(dbConn)
+
+	repo := repositories.NewJapaneseStockRepository(dbConn)
+	job := analysis.NewAnalysisJob(repo, client)
+
+	result, err := job.AnalyzeCompany(ctx, code, time.Now())
+	if err != nil {
+		log.Fatalf("❌ エラー: %v", err)
+	}
+
+	fmt.Println("✅ 分析結果を保存しました")
+	jsonData, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(jsonData))
+
+	// fakeサーバーが受け取ったリクエストの概要
+	if len(fakeServer.ExecutionRequests) > 0 {
+		req := fakeServer.ExecutionRequests[0]
+		fmt.Printf("\n📨 送信内容: 株価 %d件 / 財務JSON %dバイト / ニュース %d件\n",
+			len(req.GetStockPrices()), len(req.GetFinancialStatementsJson()), len(req.GetQualitativeInfo()))
+		if indicators := req.GetTechnicalIndicators(); indicators != nil {
+			fmt.Printf("📈 テクニカル指標: 基準日 %s / 日足 %d本 / RSI(14) %.1f\n",
+				indicators.GetDate(), indicators.GetBars(), indicators.GetRsi14())
+		}
+	}
+}
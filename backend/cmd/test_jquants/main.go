@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/joho/godotenv"
+	japanese_Stock "stock-prediction/backend/services/Japanese_Stock/companies"
+	"stock-prediction/backend/services/Japanese_Stock/jquants"
+)
+
+func main() {
+	// プロジェクトルートの.envファイルを読み込む
+	// backend/cmd/test_jquantsディレクトリから実行するので ../../../.env
+	envPath := filepath.Join("../../../", ".env")
+	if err := godotenv.Load(envPath); err != nil {
+		log.Printf("⚠️  警告: .envファイルの読み込みに失敗しました: %v", err)
+		log.Println("環境変数から直接読み取りを試みます...")
+	} else {
+		log.Println("✅ .envファイルを読み込みました")
+	}
+
+	// JQUANTS_REFRESH_TOKEN または JQUANTS_MAIL_ADDRESS / JQUANTS_PASSWORD を使用
+	client := jquants.NewClientFromEnv()
+
+	fmt.Println("🔑 J-Quants IDトークンを取得中...")
+	fmt.Println("==========================================")
+
+	idToken, err := client.IDToken()
+	if err != nil {
+		log.Fatalf("❌ エラー: %v", err)
+	}
+	fmt.Printf("✅ IDトークン取得成功 (長さ: %d)\n", len(idToken))
+
+	// 2回目はキャッシュが使われることを確認
+	cachedToken, err := client.IDToken()
+	if err != nil {
+		log.Fatalf("❌ エラー: %v", err)
+	}
+	fmt.Printf("♻️  キャッシュ利用: %v\n", cachedToken == idToken)
+
+	fmt.Println("\n🔍 銘柄マスタを取得中...")
+	companies, err := japanese_Stock.FetchJQuantsCompanies(client)
+	if err != nil {
+		log.Fatalf("❌ エラー: %v", err)
+	}
+
+	fmt.Printf("✅ 取得成功: %d銘柄\n", len(companies.Info))
+	if len(companies.Info) > 0 {
+		first := companies.Info[0]
+		fmt.Printf("  例: %s %s (%s / %s)\n", first.Code, first.CompanyName, first.Sector33CodeName, first.MarketCodeName)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"stock-prediction/backend/models"
+	AI "stock-prediction/backend/services/AI"
+	"stock-prediction/backend/services/AI/llm"
+
+	"github.com/joho/godotenv"
+)
+
+// AI分析のプロンプトを、環境変数で選択したLLM（LLM_PROVIDER / LLM_MODEL）に送って結果とトークン使用量を表示する
+//
+// 使用方法（backend/cmd/test_llm ディレクトリから実行）:
+//
+//	go run main.go NVDA 12.5
+//	LLM_PROVIDER=gemini go run main.go NVDA -8.3 losers
+//	LLM_PROVIDER=fake go run main.go NVDA 12.5   # APIを呼ばずに動作確認する
+func main() {
+	if len(os.Args) < 3 {
+		log.Fatal("❌ エラー: tickerと変化率が指定されていません。\n" +
+			"   使用方法: go run main.go <TICKER> <CHANGE_RATE> [gainers|losers|active]\n" +
+			"   例: go run main.go NVDA 12.5")
+	}
+	ticker := os.Args[1]
+	changeRate, err := strconv.ParseFloat(os.Args[2], 64)
+	if err != nil {
+		log.Fatalf("❌ エラー: 変化率が数値ではありません: %s", os.Args[2])
+	}
+	categoryParam := ""
+	if len(os.Args) > 3 {
+		categoryParam = os.Args[3]
+	}
+	category, err := models.ParseRankingCategory(categoryParam)
+	if err != nil {
+		log.Fatalf("❌ エラー: %v", err)
+	}
+
+	// プロジェクトルートの.envファイルを読み込む
+	// backend/cmd/test_llmディレクトリから実行するので ../../../.env
+	envPath := filepath.Join("../../../", ".env")
+	if err := godotenv.Load(envPath); err != nil {
+		log.Printf("⚠️  警告: .envファイルの読み込みに失敗しました: %v", err)
+		log.Println("環境変数から直接読み取りを試みます...")
+	} else {
+		log.Println("✅ .envファイルを読み込みました")
+	}
+
+	client, err := llm.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ エラー: %v", err)
+	}
+
+	fmt.Printf("🤖 %s (%s) で %s（%s, %+.2f%%）を分析中...\n", client.Name(), client.Model(), ticker, category, changeRate)
+	fmt.Println("==========================================")
+
+	// ニュースなしで分析する（ニュース付きの分析は同期処理で確認する）
+	resp, err := AI.AnalyzeStockMoveWithSummary(context.Background(), client, ticker, category, changeRate, AI.FormatNewsSummary(nil), "")
+	if err != nil {
+		log.Fatalf("❌ エラー: %v", err)
+	}
+
+	fmt.Println("✅ 分析成功！")
+	fmt.Println("==========================================")
+	fmt.Println(resp.Content)
+	fmt.Println("==========================================")
+	fmt.Printf("📊 モデル: %s\n", resp.Model)
+	fmt.Printf("📊 トークン: %s\n", resp.Usage)
+}
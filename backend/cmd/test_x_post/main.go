@@ -88,7 +88,7 @@ func testRankingPost(date string, repo repositories.IStockRepository) {
 	fmt.Println("----------------------------------------")
 
 	// ランキングデータを取得
-	rankings, err := repo.FindDailyRanking(date)
+	rankings, err := repo.FindDailyRanking(date, models.CategoryTopGainers, repositories.DefaultRankingMaxRank)
 	if err != nil {
 		log.Fatalf("❌ ランキングデータの取得に失敗: %v", err)
 	}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"net/http"
+	"stock-prediction/backend/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IAnalysisController interface {
+	FindStockAnalysis(c echo.Context) error
+	FindLatestSectorTopPicks(c echo.Context) error
+	FindIndicators(c echo.Context) error
+	FindFinancialMetrics(c echo.Context) error
+	FindValuation(c echo.Context) error
+}
+
+type analysisController struct {
+	service services.IAnalysisService
+}
+
+func NewAnalysisController(service services.IAnalysisService) IAnalysisController {
+	return &analysisController{service: service}
+}
+
+func (ac *analysisController) FindStockAnalysis(c echo.Context) error {
+	code := c.Param("code")
+	stockAnalysis, err := ac.service.FindStockAnalysis(code)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, stockAnalysis)
+}
+
+func (ac *analysisController) FindLatestSectorTopPicks(c echo.Context) error {
+	sectorCode := c.Param("sectorCode")
+	topPicks, err := ac.service.FindLatestSectorTopPicks(sectorCode)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, topPicks)
+}
+
+// FindIndicators 例: GET /api/jp/indicators/7203?date=2025-11-28
+// dateは省略時に東証の直近の取引日。本数が足りず計算できない指標は null
+func (ac *analysisController) FindIndicators(c echo.Context) error {
+	snapshot, err := ac.service.FindIndicators(c.Param("code"), c.QueryParam("date"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, snapshot)
+}
+
+// FindFinancialMetrics 例: GET /api/jp/financials/7203
+// 開示ごとの売上高・利益・EPS・純資産と、前年同期比・前四半期比・利益率を開示日の新しい順に返す
+func (ac *analysisController) FindFinancialMetrics(c echo.Context) error {
+	metrics, err := ac.service.FindFinancialMetrics(c.Param("code"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, metrics)
+}
+
+// FindValuation 例: GET /api/jp/valuation/7203?from=2025-01-01&to=2025-11-28
+// 最新のPER・PBR・配当利回り・ROEと期間内の推移を返す。toは省略時に東証の直近の取引日、fromは省略時にtoの1年前
+func (ac *analysisController) FindValuation(c echo.Context) error {
+	valuation, err := ac.service.FindValuation(c.Param("code"), c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, valuation)
+}
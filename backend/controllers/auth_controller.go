@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"net/http"
+	"stock-prediction/backend/dto"
+	"stock-prediction/backend/middlewares"
+	"stock-prediction/backend/services"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultAuditLogsLimit = 100
+	maxAuditLogsLimit     = 1000
+)
+
+type IAuthController interface {
+	FindAPIKeys(c echo.Context) error
+	IssueAPIKey(c echo.Context) error
+	RevokeAPIKey(c echo.Context) error
+	FindAuditLogs(c echo.Context) error
+}
+
+type authController struct {
+	service services.IAuthService
+}
+
+func NewAuthController(service services.IAuthService) IAuthController {
+	return &authController{service: service}
+}
+
+// FindAPIKeys 発行済みのAPIキー一覧（キー本体・ハッシュは含まない）
+// 例: GET /api/admin/keys
+func (ac *authController) FindAPIKeys(c echo.Context) error {
+	keys, err := ac.service.FindAPIKeys()
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, keys)
+}
+
+// IssueAPIKey APIキーを発行する。キー本体はこのレスポンスでしか返さない
+// 例: POST /api/admin/keys {"Name": "github-actions", "Role": "operator"}
+func (ac *authController) IssueAPIKey(c echo.Context) error {
+	var req dto.IssueAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return badRequest(c, "invalid request body")
+	}
+	if req.Name == "" {
+		return badRequest(c, "Name is required")
+	}
+
+	createdBy := ""
+	if key := middlewares.CurrentAPIKey(c); key != nil {
+		createdBy = key.Name
+	}
+
+	issued, err := ac.service.IssueAPIKey(req.Name, req.Role, createdBy)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusCreated, issued)
+}
+
+// RevokeAPIKey APIキーを失効させる
+// 例: DELETE /api/admin/keys/3
+func (ac *authController) RevokeAPIKey(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return badRequest(c, "invalid id")
+	}
+
+	key, err := ac.service.RevokeAPIKey(uint(id))
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, key)
+}
+
+// FindAuditLogs 監査ログを新しい順に返す
+// 例: GET /api/admin/audit-logs?actor=github-actions&limit=50
+func (ac *authController) FindAuditLogs(c echo.Context) error {
+	limit := defaultAuditLogsLimit
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxAuditLogsLimit {
+			return invalidParam(c, "limit", "limit must be between 1 and 1000")
+		}
+		limit = parsed
+	}
+
+	logs, err := ac.service.FindAuditLogs(c.QueryParam("actor"), limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, logs)
+}
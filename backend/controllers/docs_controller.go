@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"stock-prediction/backend/openapi"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IDocsController interface {
+	FindOpenAPI(c echo.Context) error
+	FindDocs(c echo.Context) error
+}
+
+type docsController struct {
+	spec []byte
+}
+
+// NewDocsController 起動時にOpenAPI仕様を生成してJSONにしておく
+func NewDocsController() (IDocsController, error) {
+	spec, err := json.MarshalIndent(NewOpenAPIDocument(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal openapi document: %w", err)
+	}
+	return &docsController{spec: spec}, nil
+}
+
+// FindOpenAPI 例: GET /api/openapi.json
+func (dc *docsController) FindOpenAPI(c echo.Context) error {
+	return c.JSONBlob(http.StatusOK, dc.spec)
+}
+
+// FindDocs 例: GET /api/docs（Swagger UI）
+func (dc *docsController) FindDocs(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, openapi.DocsHTML)
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"stock-prediction/backend/dto"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services"
+	japanesestock "stock-prediction/backend/services/Japanese_Stock"
+	"stock-prediction/backend/services/calendar"
+	"stock-prediction/backend/services/jobqueue"
+
+	"github.com/labstack/echo/v4"
+)
+
+// badRequestErrors 400 Bad Request にするエラー（入力の誤り）
+var badRequestErrors = []error{
+	calendar.ErrInvalidDate,
+	services.ErrInvalidDateRange,
+	services.ErrInvalidCursor,
+	services.ErrInvalidScreenerQuery,
+	services.ErrInvalidRole,
+	models.ErrInvalidCategory,
+	models.ErrInvalidMarketSegment,
+}
+
+// notFoundErrors 404 Not Found にするエラー
+var notFoundErrors = []error{
+	repositories.ErrNotFound,
+	services.ErrRankingNotFound,
+	japanesestock.ErrRankingNotFound,
+	services.ErrValuationNotFound,
+	services.ErrNoDailyBars,
+	services.ErrAnalysisNotFound,
+	services.ErrAPIKeyNotFound,
+	jobqueue.ErrJobNotFound,
+}
+
+// unauthorizedErrors 401 Unauthorized にするエラー
+var unauthorizedErrors = []error{
+	services.ErrUnauthorized,
+	services.ErrAPIKeyRevoked,
+}
+
+// paramError クエリパラメータの誤り（respondError で400にして、details にパラメータ名を入れる）
+type paramError struct {
+	param string
+	err   error
+}
+
+func (e *paramError) Error() string { return e.err.Error() }
+func (e *paramError) Unwrap() error { return e.err }
+
+// respondError サービスが返したエラーを種類に応じたステータスコードの dto.ErrorResponse で返す
+// 想定外のエラーは内部の情報を返さないよう、ログに出して500の定型メッセージにする
+func respondError(c echo.Context, err error) error {
+	var invalid *paramError
+	if errors.As(err, &invalid) {
+		return invalidParam(c, invalid.param, err.Error())
+	}
+
+	switch {
+	case isAny(err, badRequestErrors):
+		return errorJSON(c, http.StatusBadRequest, dto.ErrorCodeBadRequest, err.Error(), nil)
+	case isAny(err, notFoundErrors):
+		return errorJSON(c, http.StatusNotFound, dto.ErrorCodeNotFound, err.Error(), nil)
+	case isAny(err, unauthorizedErrors):
+		return errorJSON(c, http.StatusUnauthorized, dto.ErrorCodeUnauthorized, err.Error(), nil)
+	}
+
+	log.Printf("Error: %s %s: %v", c.Request().Method, c.Request().URL.Path, err)
+	return errorJSON(c, http.StatusInternalServerError, dto.ErrorCodeInternal, http.StatusText(http.StatusInternalServerError), nil)
+}
+
+// badRequest リクエストの形式の誤りを400で返す
+func badRequest(c echo.Context, message string) error {
+	return errorJSON(c, http.StatusBadRequest, dto.ErrorCodeBadRequest, message, nil)
+}
+
+// invalidParam パラメータの誤りを400で返す（details にパラメータ名を入れる）
+func invalidParam(c echo.Context, param string, message string) error {
+	return errorJSON(c, http.StatusBadRequest, dto.ErrorCodeBadRequest, message, map[string]string{"parameter": param})
+}
+
+func errorJSON(c echo.Context, status int, code string, message string, details any) error {
+	return c.JSON(status, dto.ErrorResponse{Code: code, Message: message, Details: details})
+}
+
+// HTTPErrorHandler Echoのエラーハンドラー。存在しないルート・メソッド違い・パニックなども dto.ErrorResponse で返す
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status := http.StatusInternalServerError
+	message := http.StatusText(status)
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		status = httpErr.Code
+		if msg, ok := httpErr.Message.(string); ok {
+			message = msg
+		} else {
+			message = http.StatusText(status)
+		}
+	} else {
+		log.Printf("Error: %s %s: %v", c.Request().Method, c.Request().URL.Path, err)
+	}
+
+	code := dto.ErrorCodeInternal
+	switch status {
+	case http.StatusBadRequest:
+		code = dto.ErrorCodeBadRequest
+	case http.StatusUnauthorized:
+		code = dto.ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		code = dto.ErrorCodeForbidden
+	case http.StatusNotFound:
+		code = dto.ErrorCodeNotFound
+	case http.StatusMethodNotAllowed:
+		code = dto.ErrorCodeMethodNotAllowed
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = errorJSON(c, status, code, message, nil)
+	}
+	if err != nil {
+		log.Printf("Error: failed to write error response: %v", err)
+	}
+}
+
+func isAny(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
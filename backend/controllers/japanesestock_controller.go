@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"net/http"
+	"stock-prediction/backend/models"
+	japanesestock "stock-prediction/backend/services/Japanese_Stock"
+	"stock-prediction/backend/services/calendar"
+	"stock-prediction/backend/services/jobqueue"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultJapaneseRankingLimit = 5
+	maxJapaneseRankingLimit     = 20
+)
+
+type IJapaneseStockController interface {
+	SyncSector(c echo.Context) error
+	AnalyzeSector(c echo.Context) error
+	CompareSector(c echo.Context) error
+	SyncMovers(c echo.Context) error
+	FindLatestRanking(c echo.Context) error
+	FindDailyRanking(c echo.Context) error
+}
+
+type japaneseStockController struct {
+	service japanesestock.IJapaneseStockService
+	queue   jobqueue.IJobQueue
+}
+
+func NewJapaneseStockController(service japanesestock.IJapaneseStockService, queue jobqueue.IJobQueue) IJapaneseStockController {
+	return &japaneseStockController{service: service, queue: queue}
+}
+
+// SyncSector 指定セクターの日本株データ（銘柄マスタ・株価・財務・ニュース）の同期をジョブとして登録する
+// 例: POST /api/admin/jp/sync?sector33=情報・通信業
+func (jc *japaneseStockController) SyncSector(c echo.Context) error {
+	filter := japanesestock.SectorFilter{
+		Sector33: c.QueryParam("sector33"),
+		Sector17: c.QueryParam("sector17"),
+	}
+	if filter.Sector33 == "" && filter.Sector17 == "" {
+		return invalidParam(c, "sector33", "sector33 or sector17 query parameter is required")
+	}
+
+	return enqueueJob(c, jc.queue, jobqueue.TypeJPSync, filter)
+}
+
+// AnalyzeSector 指定セクターの銘柄をPython分析サービス（gRPC）で分析し、結果を保存するジョブを登録する
+// 例: POST /api/admin/jp/analyze?sector33=情報・通信業
+func (jc *japaneseStockController) AnalyzeSector(c echo.Context) error {
+	filter := japanesestock.SectorFilter{
+		Sector33: c.QueryParam("sector33"),
+		Sector17: c.QueryParam("sector17"),
+	}
+	if filter.Sector33 == "" && filter.Sector17 == "" {
+		return invalidParam(c, "sector33", "sector33 or sector17 query parameter is required")
+	}
+
+	return enqueueJob(c, jc.queue, jobqueue.TypeJPAnalyze, filter)
+}
+
+// CompareSector 分析済みのセクター内銘柄を比較してTop3を選出・保存するジョブを登録する
+// 例: POST /api/admin/jp/compare?sector33=情報・通信業&date=2025-12-01（dateは省略時に日本時間の今日）
+func (jc *japaneseStockController) CompareSector(c echo.Context) error {
+	sector33 := c.QueryParam("sector33")
+	if sector33 == "" {
+		return invalidParam(c, "sector33", "sector33 query parameter is required")
+	}
+
+	date := c.QueryParam("date")
+	if date != "" {
+		if _, err := calendar.TSE.ParseDate(date); err != nil {
+			return invalidParam(c, "date", err.Error())
+		}
+	}
+
+	return enqueueJob(c, jc.queue, jobqueue.TypeJPCompare, jobqueue.JPComparePayload{Sector33: sector33, Date: date})
+}
+
+// SyncMovers 全銘柄の日足を同期し、市場区分ごとの値上がり率・値下がり率・売買代金ランキングを計算するジョブを登録する
+// 例: POST /api/admin/jp/movers?date=2025-12-01&minTurnover=500000000
+// dateは省略時に東証の直近の取引日、minTurnover（売買代金の下限・円）は省略時に JP_MOVERS_MIN_TURNOVER または1億円
+func (jc *japaneseStockController) SyncMovers(c echo.Context) error {
+	filter := japanesestock.MoversFilter{Date: c.QueryParam("date")}
+	if filter.Date != "" {
+		if _, err := calendar.TSE.ParseDate(filter.Date); err != nil {
+			return invalidParam(c, "date", err.Error())
+		}
+	}
+	if value := c.QueryParam("minTurnover"); value != "" {
+		minTurnover, err := strconv.ParseFloat(value, 64)
+		if err != nil || minTurnover < 0 {
+			return invalidParam(c, "minTurnover", "minTurnover must be a non-negative number")
+		}
+		filter.MinTurnover = &minTurnover
+	}
+
+	return enqueueJob(c, jc.queue, jobqueue.TypeJPMovers, filter)
+}
+
+// FindLatestRanking 例: GET /api/jp/stocks/latest?category=gainers&market=prime&limit=10
+// categoryは gainers / losers / active（省略時は gainers）、marketは all / prime / standard / growth（省略時は all）、limitは1〜20（省略時は5）
+func (jc *japaneseStockController) FindLatestRanking(c echo.Context) error {
+	category, segment, limit, err := parseJapaneseRankingParams(c)
+	if err != nil {
+		return respondError(c, err)
+	}
+	rankings, err := jc.service.FindLatestRanking(category, segment, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, rankings)
+}
+
+// FindDailyRanking 例: GET /api/jp/stocks/date?date=2025-11-28&category=active&market=growth
+// 休場日（週末・祝日）を指定した場合は直前の取引日のランキングを返す
+func (jc *japaneseStockController) FindDailyRanking(c echo.Context) error {
+	date := c.QueryParam("date")
+	if date == "" {
+		return invalidParam(c, "date", "date query parameter is required")
+	}
+	category, segment, limit, err := parseJapaneseRankingParams(c)
+	if err != nil {
+		return respondError(c, err)
+	}
+	rankings, err := jc.service.FindDailyRanking(date, category, segment, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, rankings)
+}
+
+// parseJapaneseRankingParams ランキングAPIの category / market / limit パラメータを検証する（不正な場合は paramError を返す）
+func parseJapaneseRankingParams(c echo.Context) (string, string, int, error) {
+	category, err := models.ParseRankingCategory(c.QueryParam("category"))
+	if err != nil {
+		return "", "", 0, &paramError{param: "category", err: err}
+	}
+	segment, err := models.ParseMarketSegment(c.QueryParam("market"))
+	if err != nil {
+		return "", "", 0, &paramError{param: "market", err: err}
+	}
+	limit, err := intQueryParam(c, "limit", defaultJapaneseRankingLimit, 1, maxJapaneseRankingLimit)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return category, segment, limit, nil
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"net/http"
+	"stock-prediction/backend/middlewares"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/services/jobqueue"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultJobsLimit = 50
+	maxJobsLimit     = 500
+)
+
+type IJobController interface {
+	FindJob(c echo.Context) error
+	FindJobs(c echo.Context) error
+}
+
+type jobController struct {
+	queue jobqueue.IJobQueue
+}
+
+func NewJobController(queue jobqueue.IJobQueue) IJobController {
+	return &jobController{queue: queue}
+}
+
+// FindJob ジョブの状態・銘柄ごとの進捗・エラーを返す
+// 例: GET /api/admin/jobs/12
+func (jbc *jobController) FindJob(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return badRequest(c, "invalid id")
+	}
+
+	job, err := jbc.queue.FindJob(uint(id))
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, job)
+}
+
+// FindJobs ジョブを新しい順に返す（進捗の詳細は含まない）
+// 例: GET /api/admin/jobs?status=failed&limit=20（statusは queued / running / succeeded / failed、省略時は全件）
+func (jbc *jobController) FindJobs(c echo.Context) error {
+	status := c.QueryParam("status")
+	switch status {
+	case "", models.BackgroundJobStatusQueued, models.BackgroundJobStatusRunning,
+		models.BackgroundJobStatusSucceeded, models.BackgroundJobStatusFailed:
+	default:
+		return invalidParam(c, "status", "invalid status. use 'queued', 'running', 'succeeded', or 'failed'")
+	}
+
+	limit := defaultJobsLimit
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxJobsLimit {
+			return invalidParam(c, "limit", "limit must be between 1 and 500")
+		}
+		limit = parsed
+	}
+
+	jobs, err := jbc.queue.FindJobs(status, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, jobs)
+}
+
+// enqueueJob ジョブを登録して 202 Accepted とジョブの状態を返す
+// 進捗は GET /api/admin/jobs/:id で確認する
+func enqueueJob(c echo.Context, queue jobqueue.IJobQueue, jobType string, payload any) error {
+	createdBy := ""
+	if key := middlewares.CurrentAPIKey(c); key != nil {
+		createdBy = key.Name
+	}
+
+	job, err := queue.Enqueue(jobType, payload, createdBy)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, job)
+}
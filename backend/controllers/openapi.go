@@ -0,0 +1,329 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"stock-prediction/backend/dto"
+	"stock-prediction/backend/middlewares"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/openapi"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services"
+	"stock-prediction/backend/services/indicators"
+	"stock-prediction/backend/services/jobqueue"
+	"stock-prediction/backend/services/scheduler"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OpenAPIのタグ
+const (
+	tagUSStocks = "US stocks"
+	tagJPStocks = "JP stocks"
+	tagScreener = "Screener"
+	tagAdmin    = "Admin"
+	tagDocs     = "Docs"
+)
+
+// セキュリティスキーム名（管理系APIはどちらかのヘッダーでAPIキーを渡す）
+const (
+	securityBearer = "bearerAuth"
+	securityAPIKey = "apiKeyAuth"
+)
+
+// CheckOpenAPIRoutes ルーターに登録したルートと NewOpenAPIDocument の仕様を比べ、片方にしか無いルートがあればエラーを返す
+func CheckOpenAPIRoutes(routes []*echo.Route) error {
+	document := NewOpenAPIDocument()
+
+	var problems []string
+	routed := make(map[string]bool)
+	for _, route := range routes {
+		// ミドルウェアを指定したグループには、存在しないルート用のルートが自動で登録される
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		path, _ := openapi.ConvertPath(route.Path)
+		routed[route.Method+" "+path] = true
+		if item, ok := document.Paths[path]; !ok || item.Operation(route.Method) == nil {
+			problems = append(problems, fmt.Sprintf("%s %s is not in the openapi document", route.Method, path))
+		}
+	}
+	for path, item := range document.Paths {
+		for _, method := range item.Methods() {
+			if !routed[method+" "+path] {
+				problems = append(problems, fmt.Sprintf("%s %s is not routed", method, path))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("routes do not match the openapi document: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// NewOpenAPIDocument /api のOpenAPI仕様を生成する
+// router.NewRouter にルートを追加・変更した場合は、ここにも同じルートを追加すること（起動時に CheckOpenAPIRoutes で確認する）
+func NewOpenAPIDocument() *openapi.Document {
+	b := openapi.NewBuilder(
+		openapi.Info{
+			Title:       "Stock Prediction API",
+			Description: "米国株・日本株のランキング、AI分析、バリュエーション、スクリーナーのAPI。エラーはすべて ErrorResponse の形式で返す。",
+			Version:     "1.0.0",
+		},
+		openapi.Tag{Name: tagUSStocks, Description: "米国株のランキング・日足・指標・バリュエーション"},
+		openapi.Tag{Name: tagJPStocks, Description: "日本株のAI分析・ランキング・財務指標・バリュエーション"},
+		openapi.Tag{Name: tagScreener, Description: "日本株・米国株のスクリーナー"},
+		openapi.Tag{Name: tagAdmin, Description: "管理系API（APIキーが必要）"},
+		openapi.Tag{Name: tagDocs, Description: "このAPIの仕様"},
+	)
+	b.SetErrorResponse(dto.ErrorResponse{})
+	b.AddSecurityScheme(securityBearer, &openapi.SecurityScheme{Type: "http", Scheme: "bearer", Description: "Authorization: Bearer <APIキー>"})
+	b.AddSecurityScheme(securityAPIKey, &openapi.SecurityScheme{Type: "apiKey", Name: middlewares.HeaderAPIKey, In: "header"})
+
+	category := openapi.QueryParam("category", "ランキングのカテゴリ（省略時は gainers）", openapi.String("gainers", "losers", "active"))
+	maxRank := openapi.QueryParam("maxRank", "この順位以内を返す", openapi.Integer(repositories.DefaultRankingMaxRank, 1, maxRankingMaxRank))
+	requiredDate := openapi.RequiredQueryParam("date", "取引日（休場日の場合は直前の取引日）", openapi.Date())
+	from := openapi.QueryParam("from", "開始日（含む）", openapi.Date())
+	to := openapi.QueryParam("to", "終了日（含む、省略時は直近の取引日）", openapi.Date())
+	ticker := map[string]string{"ticker": "米国株のティッカー（例: NVDA）"}
+	code := map[string]string{"code": "日本株の銘柄コード（例: 72030）"}
+	sector := []openapi.Parameter{
+		openapi.QueryParam("sector33", "33業種名（sector17 とどちらかが必須）", openapi.String()),
+		openapi.QueryParam("sector17", "17業種名", openapi.String()),
+	}
+	jpRanking := []openapi.Parameter{
+		category,
+		openapi.QueryParam("market", "市場区分（省略時は all）", openapi.String(models.MarketSegmentAll, models.MarketSegmentPrime, models.MarketSegmentStandard, models.MarketSegmentGrowth)),
+		openapi.QueryParam("limit", "件数", openapi.Integer(defaultJapaneseRankingLimit, 1, maxJapaneseRankingLimit)),
+	}
+	apiKey := []string{securityBearer, securityAPIKey}
+
+	// US stocks
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/stocks/latest", OperationID: "findLatestRanking", Tag: tagUSStocks,
+		Summary:  "最新のランキング",
+		Query:    []openapi.Parameter{category, maxRank},
+		Response: []dto.DailyRanking{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/stocks/date", OperationID: "findDailyRanking", Tag: tagUSStocks,
+		Summary:  "日付を指定したランキング",
+		Query:    []openapi.Parameter{requiredDate, category, maxRank},
+		Response: []dto.DailyRanking{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/stocks/:ticker", OperationID: "findStockHistory", Tag: tagUSStocks,
+		Summary:     "銘柄のランキング履歴",
+		Description: "日付の新しい順に返す。NextCursor を cursor に渡すと続きを取得できる。",
+		PathParams:  ticker,
+		Query: []openapi.Parameter{
+			from, openapi.QueryParam("to", "終了日（含む）", openapi.Date()),
+			openapi.QueryParam("category", "ランキングのカテゴリ（省略時は全カテゴリ）", openapi.String("gainers", "losers", "active")),
+			openapi.QueryParam("maxRank", "この順位以内のみ（省略時は制限なし）", openapi.Integer(0, 1, maxRankingMaxRank)),
+			openapi.QueryParam("limit", "1ページの件数", openapi.Integer(defaultStockHistoryLimit, 1, maxStockHistoryLimit)),
+			openapi.QueryParam("cursor", "前のページの NextCursor", openapi.String()),
+		},
+		Response: dto.Page[dto.DailyRanking]{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/stocks/:ticker/bars", OperationID: "findDailyBars", Tag: tagUSStocks,
+		Summary:    "日足（fromの省略時はtoの180日前）",
+		PathParams: ticker,
+		Query:      []openapi.Parameter{from, to},
+		Response:   []dto.StockDailyBar{}, Errors: []int{http.StatusBadRequest},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/stocks/:ticker/indicators", OperationID: "findIndicators", Tag: tagUSStocks,
+		Summary:    "テクニカル指標（本数が足りず計算できない指標は null）",
+		PathParams: ticker,
+		Query:      []openapi.Parameter{openapi.QueryParam("date", "基準日（省略時は直近の取引日）", openapi.Date())},
+		Response:   indicators.Snapshot{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/stocks/:ticker/valuation", OperationID: "findValuation", Tag: tagUSStocks,
+		Summary:    "バリュエーションの推移（fromの省略時はtoの1年前）",
+		PathParams: ticker,
+		Query:      []openapi.Parameter{from, to},
+		Response:   services.StockValuationHistory{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+
+	// JP stocks
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/jp/analysis/:code", OperationID: "findJapaneseStockAnalysis", Tag: tagJPStocks,
+		Summary:    "銘柄のAI分析結果（最新と履歴）",
+		PathParams: code,
+		Response:   services.StockAnalysis{}, Errors: []int{http.StatusNotFound},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/jp/sectors/:sectorCode/latest", OperationID: "findLatestSectorTopPicks", Tag: tagJPStocks,
+		Summary:    "セクターの最新の比較分析（Top3）",
+		PathParams: map[string]string{"sectorCode": "33業種コード"},
+		Response:   services.SectorTopPicks{}, Errors: []int{http.StatusNotFound},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/jp/indicators/:code", OperationID: "findJapaneseIndicators", Tag: tagJPStocks,
+		Summary:    "テクニカル指標（本数が足りず計算できない指標は null）",
+		PathParams: code,
+		Query:      []openapi.Parameter{openapi.QueryParam("date", "基準日（省略時は直近の取引日）", openapi.Date())},
+		Response:   indicators.Snapshot{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/jp/financials/:code", OperationID: "findFinancialMetrics", Tag: tagJPStocks,
+		Summary:    "開示ごとの財務指標（開示日の新しい順）",
+		PathParams: code,
+		Response:   []models.FinancialMetric{},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/jp/valuation/:code", OperationID: "findJapaneseValuation", Tag: tagJPStocks,
+		Summary:    "バリュエーションの推移（fromの省略時はtoの1年前）",
+		PathParams: code,
+		Query:      []openapi.Parameter{from, to},
+		Response:   services.JapaneseStockValuationHistory{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/jp/stocks/latest", OperationID: "findLatestJapaneseRanking", Tag: tagJPStocks,
+		Summary:  "最新のランキング（市場区分ごと）",
+		Query:    jpRanking,
+		Response: []dto.JapaneseDailyRanking{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/jp/stocks/date", OperationID: "findDailyJapaneseRanking", Tag: tagJPStocks,
+		Summary:  "日付を指定したランキング（市場区分ごと）",
+		Query:    append([]openapi.Parameter{requiredDate}, jpRanking...),
+		Response: []dto.JapaneseDailyRanking{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+
+	// Screener
+	b.Add(openapi.Route{
+		Method: http.MethodPost, Path: "/api/screener", OperationID: "screen", Tag: tagScreener,
+		Summary:     "条件に合う銘柄を絞り込む",
+		Description: "条件はすべてAND。op は eq / ne / gt / gte / lt / lte / between / in。",
+		Body:        services.ScreenerRequest{},
+		Response:    services.ScreenerResult{}, Errors: []int{http.StatusBadRequest},
+	})
+
+	// Docs
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/openapi.json", OperationID: "getOpenAPI", Tag: tagDocs,
+		Summary:        "このAPIのOpenAPI仕様",
+		ResponseSchema: &openapi.Schema{Type: "object"},
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/docs", OperationID: "getDocs", Tag: tagDocs,
+		Summary:        "仕様を表示するSwagger UI",
+		ResponseSchema: openapi.String(), ContentType: "text/html",
+	})
+
+	// Admin
+	b.Add(openapi.Route{
+		Method: http.MethodPost, Path: "/api/admin/sync", OperationID: "syncData", Tag: tagAdmin,
+		Summary: "米国株の同期をジョブとして登録する（operator）",
+		Status:  http.StatusAccepted, Response: jobqueue.JobStatus{}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/admin/sync-runs", OperationID: "findSyncRuns", Tag: tagAdmin,
+		Summary:  "取引日ごとの同期の記録（viewer）",
+		Query:    []openapi.Parameter{openapi.QueryParam("limit", "件数", openapi.Integer(defaultSyncRunsLimit, 1, maxSyncRunsLimit))},
+		Response: []dto.SyncRun{}, Errors: []int{http.StatusBadRequest}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodPost, Path: "/api/admin/xpost", OperationID: "postToX", Tag: tagAdmin,
+		Summary: "Xへの投稿をジョブとして登録する（operator）",
+		Query: []openapi.Parameter{
+			openapi.RequiredQueryParam("posttype", "投稿の種類", openapi.String("ranking", "analysis", "all")),
+			openapi.QueryParam("date", "取引日（省略時は直近の取引日）", openapi.Date()),
+		},
+		Status: http.StatusAccepted, Response: jobqueue.JobStatus{}, Errors: []int{http.StatusBadRequest}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodPost, Path: "/api/admin/jp/sync", OperationID: "syncJapaneseSector", Tag: tagAdmin,
+		Summary: "日本株セクターの同期をジョブとして登録する（operator）",
+		Query:   sector,
+		Status:  http.StatusAccepted, Response: jobqueue.JobStatus{}, Errors: []int{http.StatusBadRequest}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodPost, Path: "/api/admin/jp/analyze", OperationID: "analyzeJapaneseSector", Tag: tagAdmin,
+		Summary: "日本株セクターの分析をジョブとして登録する（operator）",
+		Query:   sector,
+		Status:  http.StatusAccepted, Response: jobqueue.JobStatus{}, Errors: []int{http.StatusBadRequest}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodPost, Path: "/api/admin/jp/compare", OperationID: "compareJapaneseSector", Tag: tagAdmin,
+		Summary: "日本株セクター内の比較をジョブとして登録する（operator）",
+		Query: []openapi.Parameter{
+			openapi.RequiredQueryParam("sector33", "33業種名", openapi.String()),
+			openapi.QueryParam("date", "日付（省略時は日本時間の今日）", openapi.Date()),
+		},
+		Status: http.StatusAccepted, Response: jobqueue.JobStatus{}, Errors: []int{http.StatusBadRequest}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodPost, Path: "/api/admin/jp/movers", OperationID: "syncJapaneseMovers", Tag: tagAdmin,
+		Summary: "日本株の全銘柄のランキングの計算をジョブとして登録する（operator）",
+		Query: []openapi.Parameter{
+			openapi.QueryParam("date", "取引日（省略時は直近の取引日）", openapi.Date()),
+			openapi.QueryParam("minTurnover", "売買代金の下限（円）", openapi.Number(0)),
+		},
+		Status: http.StatusAccepted, Response: jobqueue.JobStatus{}, Errors: []int{http.StatusBadRequest}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/admin/jobs", OperationID: "findJobs", Tag: tagAdmin,
+		Summary: "ジョブの一覧（viewer）",
+		Query: []openapi.Parameter{
+			openapi.QueryParam("status", "ジョブの状態（省略時は全件）", openapi.String(models.BackgroundJobStatusQueued, models.BackgroundJobStatusRunning, models.BackgroundJobStatusSucceeded, models.BackgroundJobStatusFailed)),
+			openapi.QueryParam("limit", "件数", openapi.Integer(defaultJobsLimit, 1, maxJobsLimit)),
+		},
+		Response: []jobqueue.JobStatus{}, Errors: []int{http.StatusBadRequest}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/admin/jobs/:id", OperationID: "findJob", Tag: tagAdmin,
+		Summary:    "ジョブの状態と銘柄ごとの進捗（viewer）",
+		PathParams: map[string]string{"id": "ジョブID"},
+		Response:   jobqueue.JobStatus{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/admin/scheduler/jobs", OperationID: "findSchedulerJobs", Tag: tagAdmin,
+		Summary:  "定期実行のジョブと次回の実行予定（viewer）",
+		Response: []scheduler.JobEntry{}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/admin/scheduler/runs", OperationID: "findSchedulerRuns", Tag: tagAdmin,
+		Summary: "定期実行の履歴（viewer）",
+		Query: []openapi.Parameter{
+			openapi.QueryParam("job", "ジョブ名（省略時は全ジョブ）", openapi.String()),
+			openapi.QueryParam("limit", "件数", openapi.Integer(defaultJobRunsLimit, 1, maxJobRunsLimit)),
+		},
+		Response: []dto.JobRun{}, Errors: []int{http.StatusBadRequest}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/admin/keys", OperationID: "findAPIKeys", Tag: tagAdmin,
+		Summary:  "APIキーの一覧（admin）",
+		Response: []dto.APIKey{}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodPost, Path: "/api/admin/keys", OperationID: "issueAPIKey", Tag: tagAdmin,
+		Summary: "APIキーを発行する。キー本体はこのレスポンスでのみ返す（admin）",
+		Body:    dto.IssueAPIKeyRequest{},
+		Status:  http.StatusCreated, Response: services.IssuedAPIKey{}, Errors: []int{http.StatusBadRequest}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodDelete, Path: "/api/admin/keys/:id", OperationID: "revokeAPIKey", Tag: tagAdmin,
+		Summary:    "APIキーを失効させる（admin）",
+		PathParams: map[string]string{"id": "APIキーのID"},
+		Response:   dto.APIKey{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}, Security: apiKey,
+	})
+	b.Add(openapi.Route{
+		Method: http.MethodGet, Path: "/api/admin/audit-logs", OperationID: "findAuditLogs", Tag: tagAdmin,
+		Summary: "監査ログ（admin）",
+		Query: []openapi.Parameter{
+			openapi.QueryParam("actor", "APIキーのName", openapi.String()),
+			openapi.QueryParam("limit", "件数", openapi.Integer(defaultAuditLogsLimit, 1, maxAuditLogsLimit)),
+		},
+		Response: []dto.AuditLog{}, Errors: []int{http.StatusBadRequest}, Security: apiKey,
+	})
+
+	return b.Document()
+}
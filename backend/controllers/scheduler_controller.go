@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"net/http"
+	"stock-prediction/backend/services/scheduler"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultJobRunsLimit = 50
+	maxJobRunsLimit     = 500
+)
+
+type ISchedulerController interface {
+	FindJobs(c echo.Context) error
+	FindJobRuns(c echo.Context) error
+}
+
+type schedulerController struct {
+	scheduler scheduler.IScheduler
+}
+
+func NewSchedulerController(scheduler scheduler.IScheduler) ISchedulerController {
+	return &schedulerController{scheduler: scheduler}
+}
+
+// FindJobs 登録済みのジョブと次回の実行予定を返す
+// 例: GET /api/admin/scheduler/jobs
+func (sc *schedulerController) FindJobs(c echo.Context) error {
+	return c.JSON(http.StatusOK, sc.scheduler.Jobs())
+}
+
+// FindJobRuns ジョブの実行履歴を新しい順に返す
+// 例: GET /api/admin/scheduler/runs?job=us_sync&limit=20（jobは省略時に全ジョブ）
+func (sc *schedulerController) FindJobRuns(c echo.Context) error {
+	limit := defaultJobRunsLimit
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxJobRunsLimit {
+			return invalidParam(c, "limit", "limit must be between 1 and 500")
+		}
+		limit = parsed
+	}
+
+	runs, err := sc.scheduler.FindJobRuns(c.QueryParam("job"), limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, runs)
+}
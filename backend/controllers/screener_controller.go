@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"net/http"
+	"stock-prediction/backend/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IScreenerController interface {
+	Screen(c echo.Context) error
+}
+
+type screenerController struct {
+	service services.IScreenerService
+}
+
+func NewScreenerController(service services.IScreenerService) IScreenerController {
+	return &screenerController{service: service}
+}
+
+// Screen 条件に合う銘柄を返す（条件の形式は services.ScreenerRequest を参照）
+// 例: POST /api/screener {"market":"JP","conditions":[{"field":"PER","op":"lte","value":15},{"field":"RevenueGrowth","op":"gte","value":10}],"sortBy":"MarketCap","sortOrder":"desc","page":1,"perPage":50}
+func (scc *screenerController) Screen(c echo.Context) error {
+	var request services.ScreenerRequest
+	if err := c.Bind(&request); err != nil {
+		return badRequest(c, "invalid request body")
+	}
+
+	result, err := scc.service.Screen(request)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -3,9 +3,12 @@ package controllers
 import (
 	"fmt"
 	"net/http"
+	"stock-prediction/backend/models"
 	"stock-prediction/backend/repositories"
 	"stock-prediction/backend/services"
-	xpost "stock-prediction/backend/services/x_post"
+	"stock-prediction/backend/services/calendar"
+	"stock-prediction/backend/services/jobqueue"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 )
@@ -14,119 +17,201 @@ type IStockController interface {
 	FindLatestRanking(c echo.Context) error
 	FindDailyRanking(c echo.Context) error
 	FindStock(c echo.Context) error
+	FindDailyBars(c echo.Context) error
+	FindIndicators(c echo.Context) error
+	FindValuation(c echo.Context) error
 	SyncData(c echo.Context) error
 	XAutomaticallyPost(c echo.Context) error
+	FindSyncRuns(c echo.Context) error
 }
 
+const (
+	defaultSyncRunsLimit = 30
+	maxSyncRunsLimit     = 365
+
+	// maxRank（ランキングAPIで返す順位の上限）の上限
+	maxRankingMaxRank = 100
+
+	// 銘柄のランキング履歴の1ページの件数
+	defaultStockHistoryLimit = 50
+	maxStockHistoryLimit     = 200
+)
+
 type stockController struct {
-	service      services.IStockService
-	xPostService xpost.IXPostService
+	service services.IStockService
+	queue   jobqueue.IJobQueue
 }
 
-func NewStockController(service services.IStockService, repo repositories.IStockRepository) IStockController {
-	xPostService := xpost.NewXPostService(repo)
-	return &stockController{service: service, xPostService: xPostService}
+func NewStockController(service services.IStockService, queue jobqueue.IJobQueue) IStockController {
+	return &stockController{service: service, queue: queue}
 }
 
+// FindLatestRanking 例: GET /api/stocks/latest?category=losers&maxRank=10
+// categoryは gainers / losers / active（省略時は gainers）、maxRankは1〜100（省略時は5）
 func (sc *stockController) FindLatestRanking(c echo.Context) error {
-	latestRanking, err := sc.service.FindLatestRanking()
+	category, err := models.ParseRankingCategory(c.QueryParam("category"))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return invalidParam(c, "category", err.Error())
+	}
+	maxRank, err := intQueryParam(c, "maxRank", repositories.DefaultRankingMaxRank, 1, maxRankingMaxRank)
+	if err != nil {
+		return respondError(c, err)
+	}
+	latestRanking, err := sc.service.FindLatestRanking(category, maxRank)
+	if err != nil {
+		return respondError(c, err)
 	}
 	return c.JSON(http.StatusOK, latestRanking)
 }
 
+// FindDailyRanking 例: GET /api/stocks/date?date=2025-11-28&category=active&maxRank=10（maxRankは省略時に5）
+// 休場日（週末・祝日）を指定した場合は直前の取引日のランキングを返す
 func (sc *stockController) FindDailyRanking(c echo.Context) error {
 	date := c.QueryParam("date")
 	if date == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "date query parameter is required"})
+		return invalidParam(c, "date", "date query parameter is required")
+	}
+	category, err := models.ParseRankingCategory(c.QueryParam("category"))
+	if err != nil {
+		return invalidParam(c, "category", err.Error())
 	}
-	dailyRanking, err := sc.service.FindDailyRanking(date)
+	maxRank, err := intQueryParam(c, "maxRank", repositories.DefaultRankingMaxRank, 1, maxRankingMaxRank)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return respondError(c, err)
+	}
+	dailyRanking, err := sc.service.FindDailyRanking(date, category, maxRank)
+	if err != nil {
+		return respondError(c, err)
 	}
 	return c.JSON(http.StatusOK, dailyRanking)
 }
 
+// FindStock 銘柄のランキング履歴を日付の新しい順に返す（カーソルページング）
+// 例: GET /api/stocks/NVDA?from=2025-01-01&to=2025-11-28&category=gainers&maxRank=5&limit=20
+//   - from / to: 日付の範囲（両端を含む、省略時は制限なし）
+//   - category: gainers / losers / active（省略時は全カテゴリ）
+//   - maxRank: この順位以内のみ（省略時は制限なし）
+//   - limit: 1ページの件数（1〜200、省略時は50）
+//   - cursor: 前のページの NextCursor（省略時は先頭から）
 func (sc *stockController) FindStock(c echo.Context) error {
-	ticker := c.Param("ticker")
-	stock, err := sc.service.FindStock(ticker)
+	query := services.StockHistoryQuery{
+		From:   c.QueryParam("from"),
+		To:     c.QueryParam("to"),
+		Cursor: c.QueryParam("cursor"),
+	}
+	if value := c.QueryParam("category"); value != "" {
+		category, err := models.ParseRankingCategory(value)
+		if err != nil {
+			return invalidParam(c, "category", err.Error())
+		}
+		query.Category = category
+	}
+	var err error
+	if query.MaxRank, err = intQueryParam(c, "maxRank", 0, 1, maxRankingMaxRank); err != nil {
+		return respondError(c, err)
+	}
+	if query.Limit, err = intQueryParam(c, "limit", defaultStockHistoryLimit, 1, maxStockHistoryLimit); err != nil {
+		return respondError(c, err)
+	}
+
+	page, err := sc.service.FindStock(c.Param("ticker"), query)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return respondError(c, err)
 	}
-	return c.JSON(http.StatusOK, stock)
+	return c.JSON(http.StatusOK, page)
 }
 
-func (sc *stockController) SyncData(c echo.Context) error {
-	err := sc.service.SyncData()
+// FindDailyBars 例: GET /api/stocks/NVDA/bars?from=2025-06-01&to=2025-11-28
+// toは省略時にNYSEの直近の取引日、fromは省略時にtoの180日前
+func (sc *stockController) FindDailyBars(c echo.Context) error {
+	bars, err := sc.service.FindDailyBars(c.Param("ticker"), c.QueryParam("from"), c.QueryParam("to"))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return respondError(c, err)
 	}
-	return c.JSON(http.StatusOK, "Data synchronized successfully")
+	return c.JSON(http.StatusOK, bars)
 }
 
-func (sc *stockController) XAutomaticallyPost(c echo.Context) error {
-	// ===== 一時的なデバッグコード（削除予定） =====
-	fmt.Printf("🔍 [DEBUG] XAutomaticallyPost 開始\n")
-	// ===== デバッグコード終了 =====
+// FindIndicators 例: GET /api/stocks/NVDA/indicators?date=2025-11-28
+// dateは省略時にNYSEの直近の取引日。本数が足りず計算できない指標は null
+func (sc *stockController) FindIndicators(c echo.Context) error {
+	snapshot, err := sc.service.FindIndicators(c.Param("ticker"), c.QueryParam("date"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, snapshot)
+}
 
+// FindValuation 例: GET /api/stocks/NVDA/valuation?from=2025-01-01&to=2025-11-28
+// 最新のPER・PBR・配当利回り・ROEと期間内の推移を返す。toは省略時にNYSEの直近の取引日、fromは省略時にtoの1年前
+func (sc *stockController) FindValuation(c echo.Context) error {
+	valuation, err := sc.service.FindValuation(c.Param("ticker"), c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(http.StatusOK, valuation)
+}
+
+// SyncData ランキング・企業情報・日足・バリュエーション・ニュース・AI分析の同期をジョブとして登録する
+// 同じ取引日の同期が途中で失敗していた場合は、失敗したステージから再開する
+// プロバイダーがレート制限などでデータを返さなかった場合は、ジョブの Result にプロバイダーごとの理由が入る
+func (sc *stockController) SyncData(c echo.Context) error {
+	return enqueueJob(c, sc.queue, jobqueue.TypeUSSync, nil)
+}
+
+// XAutomaticallyPost Xへの投稿をジョブとして登録する
+// 例: POST /api/admin/xpost?posttype=all&date=2025-11-28（posttypeは ranking / analysis / all）
+func (sc *stockController) XAutomaticallyPost(c echo.Context) error {
 	posttype := c.QueryParam("posttype")
 	date := c.QueryParam("date")
 
-	fmt.Printf("🔍 [DEBUG] パラメータ: posttype=%s, date=%s\n", posttype, date)
-
 	if posttype == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "type query parameter is required",
-		})
+		return invalidParam(c, "posttype", "posttype query parameter is required")
 	}
 
-	var err error
-	var message string
-
 	switch posttype {
-	case "ranking":
-		//ランキング投稿（AiAnalysis無し）
-		fmt.Printf("🔍 [DEBUG] ランキング投稿を開始\n")
-		err = sc.xPostService.PostRanking(date)
-		message = "Ranking posted to X successfully"
-	case "analysis":
-		//個別分析投稿（5件まとめて）
-		fmt.Printf("🔍 [DEBUG] 分析投稿を開始\n")
-		err = sc.xPostService.PostAnalysis(date)
-		message = "Analysis posted to X successfully"
-	case "all":
-		//ランキングと個別分析をまとめて投稿
-		fmt.Printf("🔍 [DEBUG] ランキング+分析投稿を開始\n")
-		err = sc.xPostService.PostRanking(date)
-		if err != nil {
-			fmt.Printf("🔍 [DEBUG] ランキング投稿エラー: %v\n", err)
-			return c.JSON(http.StatusInternalServerError, map[string]string{
-				"error": "failed to post ranking to x:" + err.Error(),
-			})
+	case "ranking", "analysis", "all":
+	default:
+		return invalidParam(c, "posttype", "invalid posttype. use 'ranking', 'analysis', or 'all'")
+	}
+	// ジョブの実行時に失敗してリトライし続けないよう、日付の形式はここで確認する
+	if date != "" {
+		if _, err := calendar.NYSE.ParseDate(date); err != nil {
+			return invalidParam(c, "date", err.Error())
 		}
-		err = sc.xPostService.PostAnalysis(date)
-		if err != nil {
-			fmt.Printf("🔍 [DEBUG] 分析投稿エラー: %v\n", err)
-			return c.JSON(http.StatusInternalServerError, map[string]string{
-				"error": "failed to post analysis to x:" + err.Error(),
-			})
+	}
+
+	return enqueueJob(c, sc.queue, jobqueue.TypeXPost, jobqueue.XPostPayload{PostType: posttype, Date: date})
+}
+
+// FindSyncRuns 取引日ごとの同期の記録（ステージごとの状態）を新しい順に返す
+// 例: GET /api/admin/sync-runs?limit=10
+func (sc *stockController) FindSyncRuns(c echo.Context) error {
+	limit := defaultSyncRunsLimit
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxSyncRunsLimit {
+			return invalidParam(c, "limit", "limit must be between 1 and 365")
 		}
-		message = "Ranking and analysis posted to X successfully"
-	default:
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid type. use 'ranking', 'analysis', or 'all'",
-		})
+		limit = parsed
 	}
+
+	runs, err := sc.service.FindSyncRuns(limit)
 	if err != nil {
-		fmt.Printf("🔍 [DEBUG] 投稿エラー: %v\n", err)
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "failed to post to x:" + err.Error(),
-		})
+		return respondError(c, err)
 	}
+	return c.JSON(http.StatusOK, runs)
+}
 
-	fmt.Printf("✅ [DEBUG] XAutomaticallyPost 成功\n")
-	return c.JSON(http.StatusOK, map[string]string{
-		"message": message,
-	})
+// intQueryParam 整数のクエリパラメータを minValue〜maxValue の範囲で読み取る（省略時は defaultValue）
+// 範囲外・整数でない場合は respondError で400になる paramError を返す
+func intQueryParam(c echo.Context, name string, defaultValue int, minValue int, maxValue int) (int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < minValue || parsed > maxValue {
+		return 0, &paramError{param: name, err: fmt.Errorf("%s must be between %d and %d", name, minValue, maxValue)}
+	}
+	return parsed, nil
 }
@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"stock-prediction/backend/models"
+	"time"
+)
+
+// AnalysisResult 日本株のAI分析結果（思考ログなどのデバッグ用の項目は含まない）
+type AnalysisResult struct {
+	ID               uint        `json:"ID"`
+	Code             string      `json:"Code"`
+	AnalyzedAt       time.Time   `json:"AnalyzedAt"`
+	StockSummary     string      `json:"StockSummary"`
+	FinancialSummary string      `json:"FinancialSummary"`
+	Sentiment        string      `json:"Sentiment"`
+	SummaryReasoning string      `json:"SummaryReasoning"`
+	BusinessModel    string      `json:"BusinessModel"`
+	KPI              string      `json:"KPI"`
+	PriceDataFrom    string      `json:"PriceDataFrom"`
+	PriceDataTo      string      `json:"PriceDataTo"`
+	NewsSearch       *NewsSearch `json:"NewsSearch,omitempty"` // 分析に使ったニュース検索（読み込んだ場合のみ）
+}
+
+// NewsSearch 分析に使ったニュース検索
+type NewsSearch struct {
+	ID         uint       `json:"ID"`
+	SearchedAt time.Time  `json:"SearchedAt"`
+	Items      []NewsItem `json:"Items"`
+}
+
+// NewsItem ニュース検索の個別の結果
+type NewsItem struct {
+	SearchQuery string  `json:"SearchQuery"`
+	Title       string  `json:"Title"`
+	URL         string  `json:"URL"`
+	Content     string  `json:"Content"`
+	Score       float64 `json:"Score"`
+}
+
+func NewAnalysisResult(result models.AnalysisResult) AnalysisResult {
+	analysisResult := AnalysisResult{
+		ID:               result.ID,
+		Code:             result.Code,
+		AnalyzedAt:       result.AnalyzedAt,
+		StockSummary:     result.StockSummary,
+		FinancialSummary: result.FinancialSummary,
+		Sentiment:        result.Sentiment,
+		SummaryReasoning: result.SummaryReasoning,
+		BusinessModel:    result.BusinessModel,
+		KPI:              result.KPI,
+		PriceDataFrom:    result.PriceDataFrom,
+		PriceDataTo:      result.PriceDataTo,
+	}
+	if result.NewsSearch != nil {
+		newsSearch := NewNewsSearch(*result.NewsSearch)
+		analysisResult.NewsSearch = &newsSearch
+	}
+	return analysisResult
+}
+
+// NewAnalysisResults 空の場合も null ではなく [] を返す
+func NewAnalysisResults(results []models.AnalysisResult) []AnalysisResult {
+	analysisResults := make([]AnalysisResult, 0, len(results))
+	for _, result := range results {
+		analysisResults = append(analysisResults, NewAnalysisResult(result))
+	}
+	return analysisResults
+}
+
+func NewNewsSearch(search models.NewsSearch) NewsSearch {
+	newsSearch := NewsSearch{
+		ID:         search.ID,
+		SearchedAt: search.SearchedAt,
+		Items:      make([]NewsItem, 0, len(search.Items)),
+	}
+	for _, item := range search.Items {
+		newsSearch.Items = append(newsSearch.Items, NewsItem{
+			SearchQuery: item.SearchQuery,
+			Title:       item.Title,
+			URL:         item.URL,
+			Content:     item.Content,
+			Score:       item.Score,
+		})
+	}
+	return newsSearch
+}
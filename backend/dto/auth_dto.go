@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"stock-prediction/backend/models"
+	"time"
+)
+
+// IssueAPIKeyRequest APIキーの発行のリクエスト
+type IssueAPIKeyRequest struct {
+	Name string `json:"Name"`
+	Role string `json:"Role"` // admin / operator / viewer
+}
+
+// APIKey 発行済みのAPIキー（キー本体・ハッシュは含まない）
+type APIKey struct {
+	ID         uint       `json:"ID"`
+	Name       string     `json:"Name"`
+	Prefix     string     `json:"Prefix"` // キー先頭の数文字（一覧で識別するため）
+	Role       string     `json:"Role"`
+	CreatedBy  string     `json:"CreatedBy"`
+	CreatedAt  time.Time  `json:"CreatedAt"` // 発行日時
+	LastUsedAt *time.Time `json:"LastUsedAt"`
+	RevokedAt  *time.Time `json:"RevokedAt"` // 失効日時（nullなら有効）
+}
+
+// AuditLog 管理系APIの操作履歴の1件
+type AuditLog struct {
+	ID         uint      `json:"ID"`
+	CreatedAt  time.Time `json:"CreatedAt"` // 操作日時
+	APIKeyID   *uint     `json:"APIKeyID"`
+	Actor      string    `json:"Actor"`
+	Role       string    `json:"Role"`
+	Method     string    `json:"Method"`
+	Path       string    `json:"Path"`
+	Query      string    `json:"Query"`
+	Status     int       `json:"Status"`
+	RemoteIP   string    `json:"RemoteIP"`
+	DurationMs int64     `json:"DurationMs"`
+}
+
+func NewAPIKey(key models.APIKey) APIKey {
+	return APIKey{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Role:       key.Role,
+		CreatedBy:  key.CreatedBy,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
+
+// NewAPIKeys 空の場合も null ではなく [] を返す
+func NewAPIKeys(keys []models.APIKey) []APIKey {
+	result := make([]APIKey, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, NewAPIKey(key))
+	}
+	return result
+}
+
+func NewAuditLog(entry models.AuditLog) AuditLog {
+	return AuditLog{
+		ID:         entry.ID,
+		CreatedAt:  entry.CreatedAt,
+		APIKeyID:   entry.APIKeyID,
+		Actor:      entry.Actor,
+		Role:       entry.Role,
+		Method:     entry.Method,
+		Path:       entry.Path,
+		Query:      entry.Query,
+		Status:     entry.Status,
+		RemoteIP:   entry.RemoteIP,
+		DurationMs: entry.DurationMs,
+	}
+}
+
+// NewAuditLogs 空の場合も null ではなく [] を返す
+func NewAuditLogs(entries []models.AuditLog) []AuditLog {
+	result := make([]AuditLog, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, NewAuditLog(entry))
+	}
+	return result
+}
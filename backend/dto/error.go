@@ -0,0 +1,19 @@
+package dto
+
+// APIのエラーコード（ErrorResponse.Code）
+const (
+	ErrorCodeBadRequest       = "bad_request"
+	ErrorCodeUnauthorized     = "unauthorized"
+	ErrorCodeForbidden        = "forbidden"
+	ErrorCodeNotFound         = "not_found"
+	ErrorCodeMethodNotAllowed = "method_not_allowed"
+	ErrorCodeInternal         = "internal_error"
+)
+
+// ErrorResponse すべてのAPIで共通のエラーレスポンス
+// 例: {"code": "not_found", "message": "stock not found"}
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"` // 入力エラーの項目など、エラーごとの補足情報
+}
@@ -0,0 +1,53 @@
+package dto
+
+import "stock-prediction/backend/models"
+
+// JapaneseDailyRanking 日本株の日次ランキングの1行
+type JapaneseDailyRanking struct {
+	ID               uint    `json:"ID"`
+	Date             string  `json:"Date"`
+	Segment          string  `json:"Segment"`
+	Category         string  `json:"Category"`
+	Rank             int     `json:"Rank"`
+	Code             string  `json:"Code"`
+	CompanyName      string  `json:"CompanyName"`
+	MarketCode       string  `json:"MarketCode"`
+	MarketCodeName   string  `json:"MarketCodeName"`
+	Sector33CodeName string  `json:"Sector33CodeName"`
+	Price            float64 `json:"Price"`
+	PreviousClose    float64 `json:"PreviousClose"`
+	ChangeAmount     float64 `json:"ChangeAmount"`
+	ChangeRate       float64 `json:"ChangeRate"`
+	Volume           float64 `json:"Volume"`
+	TurnoverValue    float64 `json:"TurnoverValue"`
+}
+
+func NewJapaneseDailyRanking(ranking models.JapaneseDailyRanking) JapaneseDailyRanking {
+	return JapaneseDailyRanking{
+		ID:               ranking.ID,
+		Date:             ranking.Date,
+		Segment:          ranking.Segment,
+		Category:         ranking.Category,
+		Rank:             ranking.Rank,
+		Code:             ranking.Code,
+		CompanyName:      ranking.CompanyName,
+		MarketCode:       ranking.MarketCode,
+		MarketCodeName:   ranking.MarketCodeName,
+		Sector33CodeName: ranking.Sector33CodeName,
+		Price:            ranking.Price,
+		PreviousClose:    ranking.PreviousClose,
+		ChangeAmount:     ranking.ChangeAmount,
+		ChangeRate:       ranking.ChangeRate,
+		Volume:           ranking.Volume,
+		TurnoverValue:    ranking.TurnoverValue,
+	}
+}
+
+// NewJapaneseDailyRankings 空の場合も null ではなく [] を返す
+func NewJapaneseDailyRankings(rankings []models.JapaneseDailyRanking) []JapaneseDailyRanking {
+	result := make([]JapaneseDailyRanking, 0, len(rankings))
+	for _, ranking := range rankings {
+		result = append(result, NewJapaneseDailyRanking(ranking))
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"stock-prediction/backend/models"
+	"time"
+)
+
+// SyncRun 取引日ごとの米国株の同期の記録
+type SyncRun struct {
+	ID          uint           `json:"ID"`
+	TradingDate string         `json:"TradingDate"`
+	Status      string         `json:"Status"` // running / succeeded / failed
+	Provider    string         `json:"Provider"`
+	Attempts    int            `json:"Attempts"`
+	StartedAt   *time.Time     `json:"StartedAt"`
+	FinishedAt  *time.Time     `json:"FinishedAt"`
+	Stages      []SyncRunStage `json:"Stages,omitempty"` // 読み込んだ場合のみ
+}
+
+// SyncRunStage 同期のステージごとの状態
+type SyncRunStage struct {
+	Stage      string     `json:"Stage"`  // movers / profiles / bars / valuation / news / ai
+	Status     string     `json:"Status"` // pending / running / succeeded / failed
+	Attempts   int        `json:"Attempts"`
+	StartedAt  *time.Time `json:"StartedAt"`
+	FinishedAt *time.Time `json:"FinishedAt"`
+	Message    string     `json:"Message"`
+}
+
+// JobRun スケジューラーが実行したジョブ1回分の履歴
+type JobRun struct {
+	ID          uint       `json:"ID"`
+	JobName     string     `json:"JobName"`
+	TradingDate string     `json:"TradingDate"`
+	Status      string     `json:"Status"` // running / succeeded / failed / skipped
+	StartedAt   time.Time  `json:"StartedAt"`
+	FinishedAt  *time.Time `json:"FinishedAt"`
+	Message     string     `json:"Message"`
+}
+
+// JobStep ジョブ内の銘柄ごとの進捗
+type JobStep struct {
+	Item    string `json:"Item"`   // ticker・銘柄コードなど
+	Status  string `json:"Status"` // running / succeeded / failed / skipped
+	Message string `json:"Message"`
+}
+
+func NewSyncRun(run models.SyncRun) SyncRun {
+	return SyncRun{
+		ID:          run.ID,
+		TradingDate: run.TradingDate,
+		Status:      run.Status,
+		Provider:    run.Provider,
+		Attempts:    run.Attempts,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+		Stages:      mapSlice(run.Stages, NewSyncRunStage),
+	}
+}
+
+// NewSyncRuns 空の場合も null ではなく [] を返す
+func NewSyncRuns(runs []models.SyncRun) []SyncRun {
+	result := make([]SyncRun, 0, len(runs))
+	for _, run := range runs {
+		result = append(result, NewSyncRun(run))
+	}
+	return result
+}
+
+func NewSyncRunStage(stage models.SyncRunStage) SyncRunStage {
+	return SyncRunStage{
+		Stage:      stage.Stage,
+		Status:     stage.Status,
+		Attempts:   stage.Attempts,
+		StartedAt:  stage.StartedAt,
+		FinishedAt: stage.FinishedAt,
+		Message:    stage.Message,
+	}
+}
+
+func NewJobRun(run models.JobRun) JobRun {
+	return JobRun{
+		ID:          run.ID,
+		JobName:     run.JobName,
+		TradingDate: run.TradingDate,
+		Status:      run.Status,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+		Message:     run.Message,
+	}
+}
+
+// NewJobRuns 空の場合も null ではなく [] を返す
+func NewJobRuns(runs []models.JobRun) []JobRun {
+	result := make([]JobRun, 0, len(runs))
+	for _, run := range runs {
+		result = append(result, NewJobRun(run))
+	}
+	return result
+}
+
+func NewJobStep(step models.BackgroundJobStep) JobStep {
+	return JobStep{
+		Item:    step.Item,
+		Status:  step.Status,
+		Message: step.Message,
+	}
+}
@@ -0,0 +1,10 @@
+package dto
+
+// Page カーソルページングのレスポンス
+// NextCursor を次のリクエストの cursor パラメータに渡すと続きを取得できる（最後のページでは null）
+type Page[T any] struct {
+	Items      []T     `json:"Items"`
+	NextCursor *string `json:"NextCursor"`
+	HasMore    bool    `json:"HasMore"`
+	Limit      int     `json:"Limit"`
+}
@@ -1,5 +1,159 @@
 package dto
 
-// TODO: DTO（Data Transfer Object）を実装予定
+import "stock-prediction/backend/models"
 
+// Stock 米国株の銘柄情報
+type Stock struct {
+	ID                uint          `json:"ID"`
+	Ticker            string        `json:"Ticker"`
+	Name              string        `json:"Name"`
+	Sector            string        `json:"Sector"`
+	Industry          string        `json:"Industry"`
+	Description       string        `json:"Description"`
+	Website           string        `json:"Website"`
+	Country           string        `json:"Country"`
+	FullTimeEmployees int           `json:"FullTimeEmployees"`
+	Image             string        `json:"Image"`
+	IpoDate           string        `json:"IpoDate"`
+	CEO               string        `json:"CEO"`
+	Metrics           []StockMetric `json:"Metrics,omitempty"` // 読み込んだ場合のみ
+}
 
+// StockMetric 米国株の日次の動的情報（時価総額・出来高など）
+type StockMetric struct {
+	StockID       uint    `json:"StockID"`
+	Date          string  `json:"Date"`
+	MarketCap     float64 `json:"MarketCap"`
+	Volume        int64   `json:"Volume"`
+	AverageVolume int64   `json:"AverageVolume"`
+	Beta          float64 `json:"Beta"`
+	LastDividend  float64 `json:"LastDividend"`
+}
+
+// DailyRanking 米国株の日次ランキングの1行
+type DailyRanking struct {
+	ID           uint    `json:"ID"`
+	StockID      uint    `json:"StockID"`
+	Date         string  `json:"Date"`
+	Rank         int     `json:"Rank"`
+	Category     string  `json:"Category"`
+	ChangeAmount float64 `json:"ChangeAmount"`
+	ChangeRate   float64 `json:"ChangeRate"`
+	Price        float64 `json:"Price"`
+	NewsSummary  string  `json:"NewsSummary"`
+	AiAnalysis   string  `json:"AiAnalysis"`
+	Stock        Stock   `json:"Stock"`
+}
+
+func NewStock(stock models.Stock) Stock {
+	return Stock{
+		ID:                stock.ID,
+		Ticker:            stock.Ticker,
+		Name:              stock.Name,
+		Sector:            stock.Sector,
+		Industry:          stock.Industry,
+		Description:       stock.Description,
+		Website:           stock.Website,
+		Country:           stock.Country,
+		FullTimeEmployees: stock.FullTimeEmployees,
+		Image:             stock.Image,
+		IpoDate:           stock.IpoDate,
+		CEO:               stock.CEO,
+		Metrics:           mapSlice(stock.Metrics, NewStockMetric),
+	}
+}
+
+func NewStockMetric(metric models.StockMetric) StockMetric {
+	return StockMetric{
+		StockID:       metric.StockID,
+		Date:          metric.Date,
+		MarketCap:     metric.MarketCap,
+		Volume:        metric.Volume,
+		AverageVolume: metric.AverageVolume,
+		Beta:          metric.Beta,
+		LastDividend:  metric.LastDividend,
+	}
+}
+
+func NewDailyRanking(ranking models.DailyRanking) DailyRanking {
+	return DailyRanking{
+		ID:           ranking.ID,
+		StockID:      ranking.StockID,
+		Date:         ranking.Date,
+		Rank:         ranking.Rank,
+		Category:     ranking.Category,
+		ChangeAmount: ranking.ChangeAmount,
+		ChangeRate:   ranking.ChangeRate,
+		Price:        ranking.Price,
+		NewsSummary:  ranking.NewsSummary,
+		AiAnalysis:   ranking.AiAnalysis,
+		Stock:        NewStock(ranking.Stock),
+	}
+}
+
+// NewDailyRankings 空の場合も null ではなく [] を返す
+func NewDailyRankings(rankings []models.DailyRanking) []DailyRanking {
+	result := make([]DailyRanking, 0, len(rankings))
+	for _, ranking := range rankings {
+		result = append(result, NewDailyRanking(ranking))
+	}
+	return result
+}
+
+// StockDailyBar 米国株の日足（OHLCV）
+type StockDailyBar struct {
+	ID               uint    `json:"ID"`
+	StockID          uint    `json:"StockID"`
+	Date             string  `json:"Date"`
+	Open             float64 `json:"Open"`
+	High             float64 `json:"High"`
+	Low              float64 `json:"Low"`
+	Close            float64 `json:"Close"`
+	Volume           int64   `json:"Volume"`
+	AdjustmentOpen   float64 `json:"AdjustmentOpen"`
+	AdjustmentHigh   float64 `json:"AdjustmentHigh"`
+	AdjustmentLow    float64 `json:"AdjustmentLow"`
+	AdjustmentClose  float64 `json:"AdjustmentClose"`
+	AdjustmentVolume float64 `json:"AdjustmentVolume"`
+	Provider         string  `json:"Provider"`
+}
+
+func NewStockDailyBar(bar models.StockDailyBar) StockDailyBar {
+	return StockDailyBar{
+		ID:               bar.ID,
+		StockID:          bar.StockID,
+		Date:             bar.Date,
+		Open:             bar.Open,
+		High:             bar.High,
+		Low:              bar.Low,
+		Close:            bar.Close,
+		Volume:           bar.Volume,
+		AdjustmentOpen:   bar.AdjustmentOpen,
+		AdjustmentHigh:   bar.AdjustmentHigh,
+		AdjustmentLow:    bar.AdjustmentLow,
+		AdjustmentClose:  bar.AdjustmentClose,
+		AdjustmentVolume: bar.AdjustmentVolume,
+		Provider:         bar.Provider,
+	}
+}
+
+// NewStockDailyBars 空の場合も null ではなく [] を返す
+func NewStockDailyBars(bars []models.StockDailyBar) []StockDailyBar {
+	result := make([]StockDailyBar, 0, len(bars))
+	for _, bar := range bars {
+		result = append(result, NewStockDailyBar(bar))
+	}
+	return result
+}
+
+// mapSlice スライスの要素を変換する（nil の場合は nil のまま。omitempty の項目に使う）
+func mapSlice[T any, U any](items []T, convert func(T) U) []U {
+	if items == nil {
+		return nil
+	}
+	result := make([]U, 0, len(items))
+	for _, item := range items {
+		result = append(result, convert(item))
+	}
+	return result
+}
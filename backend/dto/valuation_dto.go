@@ -0,0 +1,103 @@
+package dto
+
+import "stock-prediction/backend/models"
+
+// ValuationRatios 終値と1株あたりの指標から計算したバリュエーション（指標が無い場合は null）
+type ValuationRatios struct {
+	Close             float64  `json:"Close"`
+	EarningsPerShare  *float64 `json:"EarningsPerShare"`
+	BookValuePerShare *float64 `json:"BookValuePerShare"`
+	DividendPerShare  *float64 `json:"DividendPerShare"`
+	PER               *float64 `json:"PER"`
+	PBR               *float64 `json:"PBR"`
+	DividendYield     *float64 `json:"DividendYield"`
+	ROE               *float64 `json:"ROE"`
+}
+
+// PriceSignals 日足から計算した値動きの指標（本数が足りない場合は null）
+type PriceSignals struct {
+	ChangeRate *float64 `json:"ChangeRate"`
+	RSI14      *float64 `json:"RSI14"`
+}
+
+// StockValuation 米国株の日次のバリュエーション
+type StockValuation struct {
+	ID      uint   `json:"ID"`
+	StockID uint   `json:"StockID"`
+	Date    string `json:"Date"`
+	ValuationRatios
+	PriceSignals
+	Provider string `json:"Provider"`
+}
+
+// JapaneseStockValuation 日本株の日次のバリュエーション
+type JapaneseStockValuation struct {
+	ID   uint   `json:"ID"`
+	Code string `json:"Code"`
+	Date string `json:"Date"`
+	ValuationRatios
+	PriceSignals
+	MarketCap        *float64 `json:"MarketCap"`
+	DisclosureNumber string   `json:"DisclosureNumber"`
+}
+
+func NewValuationRatios(ratios models.ValuationRatios) ValuationRatios {
+	return ValuationRatios{
+		Close:             ratios.Close,
+		EarningsPerShare:  ratios.EarningsPerShare,
+		BookValuePerShare: ratios.BookValuePerShare,
+		DividendPerShare:  ratios.DividendPerShare,
+		PER:               ratios.PER,
+		PBR:               ratios.PBR,
+		DividendYield:     ratios.DividendYield,
+		ROE:               ratios.ROE,
+	}
+}
+
+func NewPriceSignals(signals models.PriceSignals) PriceSignals {
+	return PriceSignals{
+		ChangeRate: signals.ChangeRate,
+		RSI14:      signals.RSI14,
+	}
+}
+
+func NewStockValuation(valuation models.StockValuation) StockValuation {
+	return StockValuation{
+		ID:              valuation.ID,
+		StockID:         valuation.StockID,
+		Date:            valuation.Date,
+		ValuationRatios: NewValuationRatios(valuation.ValuationRatios),
+		PriceSignals:    NewPriceSignals(valuation.PriceSignals),
+		Provider:        valuation.Provider,
+	}
+}
+
+// NewStockValuations 空の場合も null ではなく [] を返す
+func NewStockValuations(valuations []models.StockValuation) []StockValuation {
+	result := make([]StockValuation, 0, len(valuations))
+	for _, valuation := range valuations {
+		result = append(result, NewStockValuation(valuation))
+	}
+	return result
+}
+
+func NewJapaneseStockValuation(valuation models.JapaneseStockValuation) JapaneseStockValuation {
+	return JapaneseStockValuation{
+		ID:               valuation.ID,
+		Code:             valuation.Code,
+		Date:             valuation.Date,
+		ValuationRatios:  NewValuationRatios(valuation.ValuationRatios),
+		PriceSignals:     NewPriceSignals(valuation.PriceSignals),
+		MarketCap:        valuation.MarketCap,
+		DisclosureNumber: valuation.DisclosureNumber,
+	}
+}
+
+// NewJapaneseStockValuations 空の場合も null ではなく [] を返す
+func NewJapaneseStockValuations(valuations []models.JapaneseStockValuation) []JapaneseStockValuation {
+	result := make([]JapaneseStockValuation, 0, len(valuations))
+	for _, valuation := range valuations {
+		result = append(result, NewJapaneseStockValuation(valuation))
+	}
+	return result
+}
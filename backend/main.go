@@ -5,10 +5,19 @@ import (
 	"os"
 	"stock-prediction/backend/controllers"
 	"stock-prediction/backend/db"
-	"stock-prediction/backend/models"
+	"stock-prediction/backend/migrations"
 	"stock-prediction/backend/repositories"
 	"stock-prediction/backend/router"
 	"stock-prediction/backend/services"
+	"stock-prediction/backend/services/AI/llm"
+	america_stock "stock-prediction/backend/services/America_stock"
+	japanesestock "stock-prediction/backend/services/Japanese_Stock"
+	"stock-prediction/backend/services/Japanese_Stock/analysis"
+	"stock-prediction/backend/services/Japanese_Stock/jquants"
+	"stock-prediction/backend/services/jobqueue"
+	"stock-prediction/backend/services/scheduler"
+	xpost "stock-prediction/backend/services/x_post"
+	"strconv"
 )
 
 func main() {
@@ -18,20 +27,108 @@ func main() {
 	dbConn := db.NewDB()
 	defer db.CloseDB(dbConn)
 
-	// Auto migrate: テーブルを自動的に作成・更新
+	// マイグレーション: 未適用のものだけを順に適用する（履歴は schema_migrations に記録）
 	log.Println("Running database migration...")
-	if err := dbConn.AutoMigrate(&models.Stock{}, &models.DailyRanking{}); err != nil {
+	if err := migrations.Migrate(dbConn); err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 	log.Println("Successfully migrated database")
 
 	// 依存性注入: Repository → Service → Controller
 	stockRepo := repositories.NewStockRepository(dbConn)
-	stockService := services.NewStockService(stockRepo)
-	stockController := controllers.NewStockController(stockService, stockRepo)
+	// 米国株のデータソース: ランキングはAlpha Vantage → FMP、企業情報・日足・1株あたりの指標はFMP → Alpha Vantage の順に試す
+	alphaVantageProvider := america_stock.NewAlphaVantageProvider(os.Getenv("ALPHA_VANTAGE_API_KEY"))
+	fmpProvider := america_stock.NewFMPProvider(os.Getenv("FMP_API_KEY"))
+	// 米国株のAI分析に使うLLM（LLM_PROVIDER / LLM_MODEL で OpenAI・Gemini・Anthropic を切り替える）
+	llmClient, err := llm.NewClientFromEnv()
+	if err != nil {
+		log.Fatal("Failed to create LLM client:", err)
+	}
+	log.Printf("LLM for AI analysis: %s (%s)", llmClient.Name(), llmClient.Model())
+	stockService := services.NewStockService(
+		stockRepo,
+		repositories.NewSyncRunRepository(dbConn),
+		america_stock.NewMoversFallback(alphaVantageProvider, fmpProvider),
+		america_stock.NewProfileFallback(fmpProvider, alphaVantageProvider),
+		america_stock.NewBarsFallback(fmpProvider, alphaVantageProvider),
+		america_stock.NewPerShareFallback(fmpProvider, alphaVantageProvider),
+		llmClient,
+	)
+
+	// 日本株: J-Quantsクライアント（IDトークンをキャッシュするためアプリ全体で1つを共有）
+	jquantsClient := jquants.NewClientFromEnv()
+	japaneseStockRepo := repositories.NewJapaneseStockRepository(dbConn)
+
+	// Python (LangGraph) 分析サービスへのgRPCクライアント（未設定の場合は分析APIのみ無効）
+	var analysisJob analysis.IAnalysisJob
+	var comparisonJob analysis.ISectorComparisonJob
+	analysisClient, err := analysis.NewClientFromEnv()
+	if err != nil {
+		log.Printf("Warning: analysis service disabled: %v", err)
+	} else {
+		defer analysisClient.Close()
+		analysisJob = analysis.NewAnalysisJob(japaneseStockRepo, analysisClient)
+		comparisonJob = analysis.NewSectorComparisonJob(japaneseStockRepo, analysisClient)
+	}
+
+	japaneseStockService := japanesestock.NewJapaneseStockService(japaneseStockRepo, jquantsClient, analysisJob, comparisonJob)
+	xPostService := xpost.NewXPostService(stockRepo)
+
+	// 管理系APIの同期・分析・投稿はジョブとして登録し、ワーカーがバックグラウンドで実行する
+	backgroundJobRepo := repositories.NewBackgroundJobRepository(dbConn)
+	jobQueue := jobqueue.NewJobQueue(backgroundJobRepo)
+	workerConcurrency := 2
+	if value := os.Getenv("JOB_WORKERS"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			workerConcurrency = parsed
+		} else {
+			log.Printf("Warning: invalid JOB_WORKERS=%q, using %d", value, workerConcurrency)
+		}
+	}
+	jobWorker := jobqueue.NewWorker(backgroundJobRepo, jobqueue.DefaultHandlers(stockService, japaneseStockService, xPostService), workerConcurrency)
+	jobWorker.Start()
+	defer jobWorker.Stop()
+
+	stockController := controllers.NewStockController(stockService, jobQueue)
+	japaneseStockController := controllers.NewJapaneseStockController(japaneseStockService, jobQueue)
+	jobController := controllers.NewJobController(jobQueue)
+
+	analysisService := services.NewAnalysisService(japaneseStockRepo)
+	analysisController := controllers.NewAnalysisController(analysisService)
+
+	screenerService := services.NewScreenerService(repositories.NewScreenerRepository(dbConn))
+	screenerController := controllers.NewScreenerController(screenerService)
+
+	// 定期実行（SCHEDULER_ENABLED=true の場合のみ起動。実行履歴の参照APIは常に有効）
+	jobRunRepo := repositories.NewJobRunRepository(dbConn)
+	jobScheduler, err := scheduler.NewScheduler(jobRunRepo, scheduler.DefaultJobs(jobQueue)...)
+	if err != nil {
+		log.Fatal("Failed to create scheduler:", err)
+	}
+	if os.Getenv("SCHEDULER_ENABLED") == "true" {
+		jobScheduler.Start()
+		defer jobScheduler.Stop()
+	} else {
+		log.Println("Scheduler is disabled (set SCHEDULER_ENABLED=true to enable)")
+	}
+	schedulerController := controllers.NewSchedulerController(jobScheduler)
+
+	// 管理系APIの認証（APIキー）と監査ログ
+	authRepo := repositories.NewAuthRepository(dbConn)
+	authService := services.NewAuthService(authRepo)
+	authController := controllers.NewAuthController(authService)
+
+	// APIの仕様（/api/openapi.json）
+	docsController, err := controllers.NewDocsController()
+	if err != nil {
+		log.Fatal("Failed to create docs controller:", err)
+	}
 
 	// ルーター設定
-	e := router.NewRouter(stockController)
+	e := router.NewRouter(stockController, japaneseStockController, analysisController, schedulerController, authController, jobController, screenerController, docsController, authService)
+	if err := controllers.CheckOpenAPIRoutes(e.Routes()); err != nil {
+		log.Fatal("OpenAPI document is out of date:", err)
+	}
 
 	// サーバー起動
 	port := os.Getenv("PORT")
@@ -0,0 +1,110 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"stock-prediction/backend/dto"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/services"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAPIKey Authorization: Bearer の代わりに使えるヘッダー
+const HeaderAPIKey = "X-API-Key"
+
+// contextKeyAPIKey 認証済みのAPIKeyをecho.Contextに保存するキー
+const contextKeyAPIKey = "apiKey"
+
+// CurrentAPIKey 認証済みのAPIキーを返す（未認証の場合はnil）
+func CurrentAPIKey(c echo.Context) *models.APIKey {
+	key, _ := c.Get(contextKeyAPIKey).(*models.APIKey)
+	return key
+}
+
+// APIKeyAuth Authorization: Bearer <key> または X-API-Key: <key> でAPIキー認証する
+func APIKeyAuth(authService services.IAuthService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key, err := authService.Authenticate(extractAPIKey(c.Request()))
+			if err != nil {
+				return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Code: dto.ErrorCodeUnauthorized, Message: err.Error()})
+			}
+
+			c.Set(contextKeyAPIKey, key)
+			return next(c)
+		}
+	}
+}
+
+// RequireRole 認証済みのAPIキーが role 以上の権限を持たない場合は403を返す（APIKeyAuthの後に使う）
+func RequireRole(role string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := CurrentAPIKey(c)
+			if key == nil {
+				return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Code: dto.ErrorCodeUnauthorized, Message: services.ErrUnauthorized.Error()})
+			}
+			if !services.HasRole(key.Role, role) {
+				return c.JSON(http.StatusForbidden, dto.ErrorResponse{Code: dto.ErrorCodeForbidden, Message: "role '" + role + "' is required"})
+			}
+			return next(c)
+		}
+	}
+}
+
+// AuditLog 管理系APIの操作（GET以外）と、認証・認可の失敗を監査ログに記録する
+// APIKeyAuthより外側（先）に登録すること
+func AuditLog(authService services.IAuthService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			var httpErr *echo.HTTPError
+			if errors.As(err, &httpErr) {
+				status = httpErr.Code
+			} else if err != nil && !c.Response().Committed {
+				// ハンドラーが返したエラーはこの後Echoのエラーハンドラーで500になる
+				status = http.StatusInternalServerError
+			}
+
+			req := c.Request()
+			denied := status == http.StatusUnauthorized || status == http.StatusForbidden
+			if req.Method == http.MethodGet && !denied {
+				return err
+			}
+
+			entry := &models.AuditLog{
+				Actor:      "anonymous",
+				Method:     req.Method,
+				Path:       c.Path(),
+				Query:      req.URL.RawQuery,
+				Status:     status,
+				RemoteIP:   c.RealIP(),
+				DurationMs: time.Since(start).Milliseconds(),
+			}
+			if key := CurrentAPIKey(c); key != nil {
+				entry.Actor = key.Name
+				entry.Role = key.Role
+				if key.ID != 0 {
+					id := key.ID
+					entry.APIKeyID = &id
+				}
+			}
+			authService.RecordAudit(entry)
+
+			return err
+		}
+	}
+}
+
+func extractAPIKey(req *http.Request) string {
+	if auth := req.Header.Get(echo.HeaderAuthorization); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return strings.TrimSpace(req.Header.Get(HeaderAPIKey))
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// 米国株テーブル（以前は起動時の AutoMigrate で作成していたもの + 未作成だった stock_metrics）
+var createUSStockTables = Migration{
+	Version: 1,
+	Name:    "create_us_stock_tables",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&stockV1{}, &dailyRankingV1{}, &stockMetricV1{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&stockMetricV1{}, &dailyRankingV1{}, &stockV1{})
+	},
+}
+
+// このマイグレーション時点のテーブル定義（models を変更しても内容が変わらないよう固定する）
+
+type stockV1 struct {
+	gorm.Model
+	Ticker            string `gorm:"uniqueIndex;not null"`
+	Name              string
+	Sector            string
+	Industry          string
+	Description       string `gorm:"type:text"`
+	Website           string
+	Country           string
+	FullTimeEmployees int
+	Image             string
+	IpoDate           string
+	CEO               string
+
+	Ranking []dailyRankingV1 `gorm:"foreignKey:StockID"`
+	Metrics []stockMetricV1  `gorm:"foreignKey:StockID"`
+}
+
+func (stockV1) TableName() string { return "stocks" }
+
+type dailyRankingV1 struct {
+	gorm.Model
+	StockID      uint
+	Date         string `gorm:"index"`
+	Rank         int
+	Category     string `gorm:"index"`
+	ChangeAmount float64
+	ChangeRate   float64
+	Price        float64
+	NewsSummary  string `gorm:"type:text"`
+	AiAnalysis   string `gorm:"type:text"`
+	Stock        stockV1
+}
+
+func (dailyRankingV1) TableName() string { return "daily_rankings" }
+
+type stockMetricV1 struct {
+	gorm.Model
+	StockID       uint   `gorm:"index"`
+	Date          string `gorm:"index"`
+	MarketCap     float64
+	Volume        int64
+	AverageVolume int64
+	Beta          float64
+	LastDividend  float64
+	Stock         stockV1
+}
+
+func (stockMetricV1) TableName() string { return "stock_metrics" }
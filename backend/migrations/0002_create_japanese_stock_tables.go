@@ -0,0 +1,152 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 日本株テーブル（J-Quants・Tavily・AI分析結果）
+var createJapaneseStockTables = Migration{
+	Version: 2,
+	Name:    "create_japanese_stock_tables",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(
+			&companyV2{},
+			&dailyQuoteV2{},
+			&financialStatementV2{},
+			&newsSearchV2{},
+			&newsItemV2{},
+			&sectorAnalysisResultV2{},
+			&analysisResultV2{},
+		)
+	},
+	Down: func(tx *gorm.DB) error {
+		// 外部キーの参照元から順に削除する
+		return tx.Migrator().DropTable(
+			&analysisResultV2{},
+			&sectorAnalysisResultV2{},
+			&newsItemV2{},
+			&newsSearchV2{},
+			&financialStatementV2{},
+			&dailyQuoteV2{},
+			&companyV2{},
+		)
+	},
+}
+
+// このマイグレーション時点のテーブル定義（models を変更しても内容が変わらないよう固定する）
+
+type companyV2 struct {
+	gorm.Model
+	Code               string `gorm:"uniqueIndex;not null"`
+	CompanyName        string
+	CompanyNameEnglish string
+	Sector17Code       string `gorm:"index"`
+	Sector17CodeName   string
+	Sector33Code       string `gorm:"index"`
+	Sector33CodeName   string
+	ScaleCategory      string
+	MarketCode         string `gorm:"index"`
+	MarketCodeName     string
+}
+
+func (companyV2) TableName() string { return "companies" }
+
+type dailyQuoteV2 struct {
+	ID               uint   `gorm:"primaryKey"`
+	Code             string `gorm:"index:idx_daily_quote_code_date,unique;not null"`
+	Date             string `gorm:"index:idx_daily_quote_code_date,unique;not null"`
+	Open             float64
+	High             float64
+	Low              float64
+	Close            float64
+	Volume           float64
+	TurnoverValue    float64
+	AdjustmentFactor float64
+	AdjustmentOpen   float64
+	AdjustmentHigh   float64
+	AdjustmentLow    float64
+	AdjustmentClose  float64
+	AdjustmentVolume float64
+}
+
+func (dailyQuoteV2) TableName() string { return "daily_quotes" }
+
+type financialStatementV2 struct {
+	ID                         uint   `gorm:"primaryKey"`
+	Code                       string `gorm:"index;not null"`
+	DisclosureNumber           string `gorm:"uniqueIndex;not null"`
+	DisclosedDate              string `gorm:"index"`
+	TypeOfDocument             string `gorm:"index"`
+	TypeOfCurrentPeriod        string
+	CurrentFiscalYearStartDate string
+	CurrentFiscalYearEndDate   string `gorm:"index"`
+	RawJSON                    string `gorm:"type:jsonb;not null"`
+}
+
+func (financialStatementV2) TableName() string { return "financial_statements" }
+
+type newsSearchV2 struct {
+	gorm.Model
+	Code            string    `gorm:"index;not null"`
+	SearchedAt      time.Time `gorm:"index;not null"`
+	CombinedContent string    `gorm:"type:text"`
+
+	Items []newsItemV2 `gorm:"foreignKey:NewsSearchID"`
+}
+
+func (newsSearchV2) TableName() string { return "news_searches" }
+
+type newsItemV2 struct {
+	gorm.Model
+	NewsSearchID uint   `gorm:"index;not null"`
+	SearchQuery  string `gorm:"index"`
+	Title        string
+	URL          string
+	Content      string `gorm:"type:text"`
+	Score        float64
+
+	NewsSearch newsSearchV2 `gorm:"foreignKey:NewsSearchID"`
+}
+
+func (newsItemV2) TableName() string { return "news_items" }
+
+type analysisResultV2 struct {
+	gorm.Model
+	Code             string    `gorm:"index;not null"`
+	AnalyzedAt       time.Time `gorm:"index;not null"`
+	StockSummary     string    `gorm:"type:text"`
+	FinancialSummary string    `gorm:"type:text"`
+	Sentiment        string    `gorm:"index"`
+	SummaryReasoning string    `gorm:"type:text"`
+	ThoughtLog       string    `gorm:"type:text"`
+	BusinessModel    string    `gorm:"type:text"`
+	KPI              string    `gorm:"type:text"`
+	PriceDataFrom    string
+	PriceDataTo      string
+	NewsSearchID     *uint `gorm:"index"`
+
+	SectorAnalysisResultID *uint                   `gorm:"index"`
+	SectorAnalysisResult   *sectorAnalysisResultV2 `gorm:"foreignKey:SectorAnalysisResultID"`
+}
+
+func (analysisResultV2) TableName() string { return "analysis_results" }
+
+type sectorAnalysisResultV2 struct {
+	gorm.Model
+	SectorCode     string    `gorm:"index;not null"`
+	AnalyzedAt     time.Time `gorm:"index;not null"`
+	Top1Code       string    `gorm:"index"`
+	Top1Reasoning  string    `gorm:"type:text"`
+	Top2Code       string    `gorm:"index"`
+	Top2Reasoning  string    `gorm:"type:text"`
+	Top3Code       string    `gorm:"index"`
+	Top3Reasoning  string    `gorm:"type:text"`
+	ComparisonLog  *string   `gorm:"type:text"`
+	OverallSummary *string   `gorm:"type:text"`
+
+	AnalysisResults []analysisResultV2 `gorm:"foreignKey:SectorAnalysisResultID"`
+}
+
+func (sectorAnalysisResultV2) TableName() string { return "sector_analysis_results" }
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// スケジューラーの実行履歴
+var createJobRuns = Migration{
+	Version: 3,
+	Name:    "create_job_runs",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&jobRunV3{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&jobRunV3{})
+	},
+}
+
+// このマイグレーション時点のテーブル定義（models を変更しても内容が変わらないよう固定する）
+type jobRunV3 struct {
+	gorm.Model
+	JobName     string `gorm:"index;not null"`
+	TradingDate string `gorm:"index"`
+	Status      string `gorm:"index;not null"`
+	StartedAt   time.Time
+	FinishedAt  *time.Time
+	Message     string `gorm:"type:text"`
+}
+
+func (jobRunV3) TableName() string { return "job_runs" }
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 管理系APIの認証（APIキー）と監査ログ
+var createAuthTables = Migration{
+	Version: 4,
+	Name:    "create_auth_tables",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&apiKeyV4{}, &auditLogV4{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&auditLogV4{}, &apiKeyV4{})
+	},
+}
+
+// このマイグレーション時点のテーブル定義（models を変更しても内容が変わらないよう固定する）
+
+type apiKeyV4 struct {
+	gorm.Model
+	Name       string `gorm:"not null"`
+	Prefix     string `gorm:"index;not null"`
+	KeyHash    string `gorm:"uniqueIndex;not null"`
+	Role       string `gorm:"not null"`
+	CreatedBy  string
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time `gorm:"index"`
+}
+
+func (apiKeyV4) TableName() string { return "api_keys" }
+
+type auditLogV4 struct {
+	gorm.Model
+	APIKeyID   *uint  `gorm:"index"`
+	Actor      string `gorm:"index"`
+	Role       string
+	Method     string
+	Path       string `gorm:"index"`
+	Query      string
+	Status     int
+	RemoteIP   string
+	DurationMs int64
+}
+
+func (auditLogV4) TableName() string { return "audit_logs" }
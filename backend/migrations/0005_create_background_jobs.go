@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 管理系APIから登録する非同期ジョブのキュー
+var createBackgroundJobs = Migration{
+	Version: 5,
+	Name:    "create_background_jobs",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&backgroundJobV5{}, &backgroundJobStepV5{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&backgroundJobStepV5{}, &backgroundJobV5{})
+	},
+}
+
+// このマイグレーション時点のテーブル定義（models を変更しても内容が変わらないよう固定する）
+
+type backgroundJobV5 struct {
+	gorm.Model
+	Type        string    `gorm:"index;not null"`
+	Payload     string    `gorm:"type:jsonb;not null;default:'{}'"`
+	Status      string    `gorm:"index:idx_background_jobs_status_run_at;not null"`
+	RunAt       time.Time `gorm:"index:idx_background_jobs_status_run_at;not null"`
+	Attempts    int
+	MaxAttempts int
+	LockedBy    string
+	LockedAt    *time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+	LastError   string `gorm:"type:text"`
+	Result      string `gorm:"type:jsonb;not null;default:'null'"`
+	CreatedBy   string
+
+	Steps []backgroundJobStepV5 `gorm:"foreignKey:JobID"`
+}
+
+func (backgroundJobV5) TableName() string { return "background_jobs" }
+
+type backgroundJobStepV5 struct {
+	gorm.Model
+	JobID   uint   `gorm:"uniqueIndex:idx_background_job_steps_job_item;not null"`
+	Item    string `gorm:"uniqueIndex:idx_background_job_steps_job_item;not null"`
+	Status  string `gorm:"not null"`
+	Message string `gorm:"type:text"`
+}
+
+func (backgroundJobStepV5) TableName() string { return "background_job_steps" }
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 米国株の同期の記録（取引日ごと・ステージごとの状態）
+var createSyncRuns = Migration{
+	Version: 6,
+	Name:    "create_sync_runs",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&syncRunV6{}, &syncRunStageV6{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&syncRunStageV6{}, &syncRunV6{})
+	},
+}
+
+// このマイグレーション時点のテーブル定義（models を変更しても内容が変わらないよう固定する）
+
+type syncRunV6 struct {
+	gorm.Model
+	TradingDate string `gorm:"uniqueIndex;not null"`
+	Status      string `gorm:"index;not null"`
+	Provider    string
+	Attempts    int
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+
+	Stages []syncRunStageV6 `gorm:"foreignKey:SyncRunID"`
+}
+
+func (syncRunV6) TableName() string { return "sync_runs" }
+
+type syncRunStageV6 struct {
+	gorm.Model
+	SyncRunID  uint   `gorm:"uniqueIndex:idx_sync_run_stages_run_stage;not null"`
+	Stage      string `gorm:"uniqueIndex:idx_sync_run_stages_run_stage;not null"`
+	Status     string `gorm:"not null"`
+	Attempts   int
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	Message    string `gorm:"type:text"`
+}
+
+func (syncRunStageV6) TableName() string { return "sync_run_stages" }
@@ -0,0 +1,30 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// 米国株の日足（OHLCV）
+var createStockDailyBars = Migration{
+	Version: 7,
+	Name:    "create_stock_daily_bars",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&stockDailyBarV7{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&stockDailyBarV7{})
+	},
+}
+
+// このマイグレーション時点のテーブル定義（models を変更しても内容が変わらないよう固定する）
+type stockDailyBarV7 struct {
+	ID       uint   `gorm:"primaryKey"`
+	StockID  uint   `gorm:"uniqueIndex:idx_stock_daily_bars_stock_date;not null"`
+	Date     string `gorm:"uniqueIndex:idx_stock_daily_bars_stock_date;not null"`
+	Open     float64
+	High     float64
+	Low      float64
+	Close    float64
+	Volume   int64
+	Provider string
+}
+
+func (stockDailyBarV7) TableName() string { return "stock_daily_bars" }
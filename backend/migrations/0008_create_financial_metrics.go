@@ -0,0 +1,66 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// 財務諸表から取り出した主要な数値・成長率・利益率
+var createFinancialMetrics = Migration{
+	Version: 8,
+	Name:    "create_financial_metrics",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&financialMetricV8{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&financialMetricV8{})
+	},
+}
+
+// このマイグレーション時点のテーブル定義（models を変更しても内容が変わらないよう固定する）
+type financialMetricV8 struct {
+	ID                               uint   `gorm:"primaryKey"`
+	FinancialStatementID             uint   `gorm:"index;not null"`
+	Code                             string `gorm:"index;not null"`
+	DisclosureNumber                 string `gorm:"uniqueIndex;not null"`
+	DisclosedDate                    string `gorm:"index"`
+	TypeOfDocument                   string
+	TypeOfCurrentPeriod              string `gorm:"index"`
+	CurrentPeriodEndDate             string
+	CurrentFiscalYearStartDate       string
+	CurrentFiscalYearEndDate         string `gorm:"index"`
+	NetSales                         *float64
+	OperatingProfit                  *float64
+	OrdinaryProfit                   *float64
+	Profit                           *float64
+	EarningsPerShare                 *float64
+	Equity                           *float64
+	TotalAssets                      *float64
+	EquityToAssetRatio               *float64
+	BookValuePerShare                *float64
+	IssuedShares                     *float64
+	TreasuryShares                   *float64
+	ForecastNetSales                 *float64
+	ForecastOperatingProfit          *float64
+	ForecastOrdinaryProfit           *float64
+	ForecastProfit                   *float64
+	ForecastEarningsPerShare         *float64
+	NextYearForecastNetSales         *float64
+	NextYearForecastOperatingProfit  *float64
+	NextYearForecastOrdinaryProfit   *float64
+	NextYearForecastProfit           *float64
+	NextYearForecastEarningsPerShare *float64
+	QuarterNetSales                  *float64
+	QuarterOperatingProfit           *float64
+	QuarterProfit                    *float64
+	OperatingMargin                  *float64
+	OrdinaryMargin                   *float64
+	NetMargin                        *float64
+	NetSalesYoY                      *float64
+	OperatingProfitYoY               *float64
+	OrdinaryProfitYoY                *float64
+	ProfitYoY                        *float64
+	EarningsPerShareYoY              *float64
+	NetSalesQoQ                      *float64
+	OperatingProfitQoQ               *float64
+	ProfitQoQ                        *float64
+}
+
+func (financialMetricV8) TableName() string { return "financial_metrics" }
@@ -0,0 +1,66 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// 日次のバリュエーション（米国株・日本株）と、計算に使う財務指標の配当のカラム
+var createValuations = Migration{
+	Version: 9,
+	Name:    "create_valuations",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&financialMetricDividendsV9{}, &stockValuationV9{}, &japaneseStockValuationV9{})
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := tx.Migrator().DropTable(&stockValuationV9{}, &japaneseStockValuationV9{}); err != nil {
+			return err
+		}
+		for _, column := range []string{"DividendPerShare", "ForecastDividendPerShare", "NextYearForecastDividendPerShare"} {
+			if err := tx.Migrator().DropColumn(&financialMetricDividendsV9{}, column); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// このマイグレーション時点のテーブル定義（models を変更しても内容が変わらないよう固定する）
+
+// financialMetricDividendsV9 financial_metrics に追加するカラム（既存のカラムは 0008 のまま）
+type financialMetricDividendsV9 struct {
+	ID                               uint `gorm:"primaryKey"`
+	DividendPerShare                 *float64
+	ForecastDividendPerShare         *float64
+	NextYearForecastDividendPerShare *float64
+}
+
+func (financialMetricDividendsV9) TableName() string { return "financial_metrics" }
+
+type valuationRatiosV9 struct {
+	Close             float64
+	EarningsPerShare  *float64
+	BookValuePerShare *float64
+	DividendPerShare  *float64
+	PER               *float64
+	PBR               *float64
+	DividendYield     *float64
+	ROE               *float64
+}
+
+type stockValuationV9 struct {
+	ID       uint              `gorm:"primaryKey"`
+	StockID  uint              `gorm:"uniqueIndex:idx_stock_valuations_stock_date;not null"`
+	Date     string            `gorm:"uniqueIndex:idx_stock_valuations_stock_date;not null"`
+	Ratios   valuationRatiosV9 `gorm:"embedded"`
+	Provider string
+}
+
+func (stockValuationV9) TableName() string { return "stock_valuations" }
+
+type japaneseStockValuationV9 struct {
+	ID               uint              `gorm:"primaryKey"`
+	Code             string            `gorm:"uniqueIndex:idx_japanese_stock_valuations_code_date;not null"`
+	Date             string            `gorm:"uniqueIndex:idx_japanese_stock_valuations_code_date;not null"`
+	Ratios           valuationRatiosV9 `gorm:"embedded"`
+	DisclosureNumber string
+}
+
+func (japaneseStockValuationV9) TableName() string { return "japanese_stock_valuations" }
@@ -0,0 +1,44 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// スクリーナー用に日次のバリュエーションへ前日比・RSI・時価総額（日本株）のカラムを追加する
+var addValuationPriceSignals = Migration{
+	Version: 10,
+	Name:    "add_valuation_price_signals",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&stockValuationSignalsV10{}, &japaneseStockValuationSignalsV10{})
+	},
+	Down: func(tx *gorm.DB) error {
+		for _, column := range []string{"ChangeRate", "RSI14"} {
+			if err := tx.Migrator().DropColumn(&stockValuationSignalsV10{}, column); err != nil {
+				return err
+			}
+		}
+		for _, column := range []string{"ChangeRate", "RSI14", "MarketCap"} {
+			if err := tx.Migrator().DropColumn(&japaneseStockValuationSignalsV10{}, column); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// このマイグレーションで追加するカラム（models を変更しても内容が変わらないよう固定する。既存のカラムは 0009 のまま）
+
+type stockValuationSignalsV10 struct {
+	ID         uint `gorm:"primaryKey"`
+	ChangeRate *float64
+	RSI14      *float64
+}
+
+func (stockValuationSignalsV10) TableName() string { return "stock_valuations" }
+
+type japaneseStockValuationSignalsV10 struct {
+	ID         uint `gorm:"primaryKey"`
+	ChangeRate *float64
+	RSI14      *float64
+	MarketCap  *float64
+}
+
+func (japaneseStockValuationSignalsV10) TableName() string { return "japanese_stock_valuations" }
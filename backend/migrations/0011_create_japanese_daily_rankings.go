@@ -0,0 +1,37 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// 全銘柄の日足から計算した日本株の日次ランキングのテーブル
+var createJapaneseDailyRankings = Migration{
+	Version: 11,
+	Name:    "create_japanese_daily_rankings",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&japaneseDailyRankingV11{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable(&japaneseDailyRankingV11{})
+	},
+}
+
+// このマイグレーション時点のテーブル定義（models を変更しても内容が変わらないよう固定する）
+type japaneseDailyRankingV11 struct {
+	ID               uint   `gorm:"primaryKey"`
+	Date             string `gorm:"uniqueIndex:idx_japanese_daily_rankings_key;not null"`
+	Segment          string `gorm:"uniqueIndex:idx_japanese_daily_rankings_key;not null"`
+	Category         string `gorm:"uniqueIndex:idx_japanese_daily_rankings_key;not null"`
+	Rank             int    `gorm:"uniqueIndex:idx_japanese_daily_rankings_key;not null"`
+	Code             string `gorm:"index;not null"`
+	CompanyName      string
+	MarketCode       string
+	MarketCodeName   string
+	Sector33CodeName string
+	Price            float64
+	PreviousClose    float64
+	ChangeAmount     float64
+	ChangeRate       float64
+	Volume           float64
+	TurnoverValue    float64
+}
+
+func (japaneseDailyRankingV11) TableName() string { return "japanese_daily_rankings" }
@@ -0,0 +1,35 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// スケジューラーの実行履歴をジョブ名と取引日で一意にする（複数のプロセスが同じ取引日のジョブを実行しないようにする）
+// 既に重複している実行履歴は最新の1件だけを残す
+var addJobRunsTradingDateUnique = Migration{
+	Version: 12,
+	Name:    "add_job_runs_trading_date_unique",
+	Up: func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM job_runs AS older USING job_runs AS newer
+			WHERE older.job_name = newer.job_name AND older.trading_date = newer.trading_date AND older.id < newer.id`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_job_runs_job_name_trading_date ON job_runs (job_name, trading_date)").Error; err != nil {
+			return err
+		}
+		// 一意インデックスで検索できるようになった単独のインデックスを削除する
+		for _, index := range []string{"idx_job_runs_job_name", "idx_job_runs_trading_date"} {
+			if err := tx.Exec("DROP INDEX IF EXISTS " + index).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := tx.Exec("DROP INDEX IF EXISTS idx_job_runs_job_name_trading_date").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_job_runs_job_name ON job_runs (job_name)").Error; err != nil {
+			return err
+		}
+		return tx.Exec("CREATE INDEX IF NOT EXISTS idx_job_runs_trading_date ON job_runs (trading_date)").Error
+	},
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// 米国株の日足に分割・配当の調整後の四本値・出来高のカラムを追加する
+// 既存の日足は調整後の値が0なので、次回の同期で調整後の値を含めて取得し直す
+var addStockDailyBarAdjustments = Migration{
+	Version: 13,
+	Name:    "add_stock_daily_bar_adjustments",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&stockDailyBarAdjustmentsV13{})
+	},
+	Down: func(tx *gorm.DB) error {
+		for _, column := range []string{"AdjustmentOpen", "AdjustmentHigh", "AdjustmentLow", "AdjustmentClose", "AdjustmentVolume"} {
+			if err := tx.Migrator().DropColumn(&stockDailyBarAdjustmentsV13{}, column); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// このマイグレーションで追加するカラム（models を変更しても内容が変わらないよう固定する。既存のカラムは 0007 のまま）
+type stockDailyBarAdjustmentsV13 struct {
+	ID               uint `gorm:"primaryKey"`
+	AdjustmentOpen   float64
+	AdjustmentHigh   float64
+	AdjustmentLow    float64
+	AdjustmentClose  float64
+	AdjustmentVolume float64
+}
+
+func (stockDailyBarAdjustmentsV13) TableName() string { return "stock_daily_bars" }
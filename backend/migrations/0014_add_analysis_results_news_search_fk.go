@@ -0,0 +1,33 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// AI分析結果から分析に使ったニュース検索への外部キーを追加する
+// （以前は 0002 が models の AutoMigrate だったため、0002 を後から適用した環境にだけ作成されていた）
+var addAnalysisResultsNewsSearchFK = Migration{
+	Version: 14,
+	Name:    "add_analysis_results_news_search_fk",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&analysisResultNewsSearchV14{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropConstraint(&analysisResultNewsSearchV14{}, "NewsSearch")
+	},
+}
+
+// このマイグレーション時点のテーブル定義（models を変更しても内容が変わらないよう固定する。既存のカラムは 0002 のまま）
+
+type analysisResultNewsSearchV14 struct {
+	ID           uint              `gorm:"primaryKey"`
+	NewsSearchID *uint             `gorm:"index"`
+	NewsSearch   *newsSearchRefV14 `gorm:"foreignKey:NewsSearchID"`
+}
+
+func (analysisResultNewsSearchV14) TableName() string { return "analysis_results" }
+
+// newsSearchRefV14 外部キーの参照先（主キーのみ）
+type newsSearchRefV14 struct {
+	ID uint `gorm:"primaryKey"`
+}
+
+func (newsSearchRefV14) TableName() string { return "news_searches" }
@@ -0,0 +1,187 @@
+// Package migrations はバージョン管理されたスキーママイグレーションを提供する
+//
+// 各マイグレーションは Version（連番）と Up/Down を持ち、適用済みのものは schema_migrations テーブルに記録される。
+// 起動時の Migrate は未適用のマイグレーションだけを Version 順に1件ずつトランザクション内で実行する。
+//
+// Up は既存環境（以前の起動時 AutoMigrate で作成済みのテーブル）でも安全に流せるよう、
+// AutoMigrate（冪等）または IF NOT EXISTS を付けたSQLで記述する。AutoMigrate に渡す構造体は models を使わず、
+// その時点のテーブル定義を各マイグレーションのファイル内に固定する（models を変更しても適用済みの内容が変わらないように）。
+// 既存テーブルへのカラム追加なども新しい Version を追加して行い、適用済みのマイグレーションは書き換えないこと。
+package migrations
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration 1件分のスキーマ変更
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SchemaMigration 適用済みマイグレーションの記録（schema_migrations テーブル）
+type SchemaMigration struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus マイグレーションごとの適用状況
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// all 登録済みのマイグレーション（Version順）
+var all = []Migration{
+	createUSStockTables,
+	createJapaneseStockTables,
+	createJobRuns,
+	createAuthTables,
+	createBackgroundJobs,
+	createSyncRuns,
+	createStockDailyBars,
+	createFinancialMetrics,
+	createValuations,
+	addValuationPriceSignals,
+	createJapaneseDailyRankings,
+	addJobRunsTradingDateUnique,
+	addStockDailyBarAdjustments,
+	addAnalysisResultsNewsSearchFK,
+}
+
+// Migrations 登録済みのマイグレーションをVersion順に返す
+func Migrations() []Migration {
+	migrations := make([]Migration, len(all))
+	copy(migrations, all)
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations
+}
+
+// Migrate 未適用のマイグレーションをすべて適用する
+func Migrate(db *gorm.DB) error {
+	if err := ensureSchemaMigrationsTable(db); err != nil {
+		return err
+	}
+
+	for _, m := range Migrations() {
+		applied, err := applyMigration(db, m)
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		if applied {
+			log.Printf("Applied migration %04d_%s", m.Version, m.Name)
+		}
+	}
+	return nil
+}
+
+// Rollback 適用済みのマイグレーションを新しい順にsteps件だけ取り消す
+func Rollback(db *gorm.DB, steps int) error {
+	if err := ensureSchemaMigrationsTable(db); err != nil {
+		return err
+	}
+
+	var applied []SchemaMigration
+	if err := db.Order("version DESC").Limit(steps).Find(&applied).Error; err != nil {
+		return fmt.Errorf("failed to find applied migrations: %w", err)
+	}
+
+	byVersion := make(map[uint]Migration)
+	for _, m := range Migrations() {
+		byVersion[m.Version] = m
+	}
+
+	for _, record := range applied {
+		m, ok := byVersion[record.Version]
+		if !ok {
+			return fmt.Errorf("migration %04d_%s is applied but not registered", record.Version, record.Name)
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, record.Version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to roll back migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		log.Printf("Rolled back migration %04d_%s", m.Version, m.Name)
+	}
+	return nil
+}
+
+// Status 登録済みマイグレーションの適用状況を返す
+func Status(db *gorm.DB) ([]MigrationStatus, error) {
+	if err := ensureSchemaMigrationsTable(db); err != nil {
+		return nil, err
+	}
+
+	var applied []SchemaMigration
+	if err := db.Find(&applied).Error; err != nil {
+		return nil, fmt.Errorf("failed to find applied migrations: %w", err)
+	}
+	appliedAt := make(map[uint]time.Time)
+	for _, record := range applied {
+		appliedAt[record.Version] = record.AppliedAt
+	}
+
+	var statuses []MigrationStatus
+	for _, m := range Migrations() {
+		status := MigrationStatus{Version: m.Version, Name: m.Name}
+		if t, ok := appliedAt[m.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &t
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func ensureSchemaMigrationsTable(db *gorm.DB) error {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// applyMigration 未適用であればマイグレーションを適用して記録する（適用した場合はtrueを返す）
+// 複数インスタンスが同時に起動しても二重に適用されないよう、schema_migrations をロックしてから確認する
+func applyMigration(db *gorm.DB, m Migration) (bool, error) {
+	applied := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE schema_migrations IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&SchemaMigration{}).Where("version = ?", m.Version).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := m.Up(tx); err != nil {
+			return err
+		}
+
+		applied = true
+		return tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+	})
+	return applied, err
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKey.Role の値（admin ⊃ operator ⊃ viewer）
+const (
+	RoleAdmin    = "admin"    // APIキーの発行・失効、監査ログの閲覧を含む全操作
+	RoleOperator = "operator" // 同期・X投稿・分析などの実行
+	RoleViewer   = "viewer"   // 管理系APIの参照のみ
+)
+
+// APIKey 管理系API（/api/admin）用のAPIキー
+// キー本体は保存せず、SHA-256ハッシュのみを保存する（発行時に一度だけ返す）
+type APIKey struct {
+	gorm.Model
+	Name       string     `gorm:"not null" json:"Name"`          // 利用者・用途（例: "github-actions"）
+	Prefix     string     `gorm:"index;not null" json:"Prefix"`  // キー先頭の数文字（一覧で識別するため）
+	KeyHash    string     `gorm:"uniqueIndex;not null" json:"-"` // キーのSHA-256（hex）
+	Role       string     `gorm:"not null" json:"Role"`          // admin / operator / viewer
+	CreatedBy  string     `json:"CreatedBy"`                     // 発行したキーのName
+	LastUsedAt *time.Time `json:"LastUsedAt"`                    // 最終利用日時
+	RevokedAt  *time.Time `gorm:"index" json:"RevokedAt"`        // 失効日時（nullなら有効）
+}
+
+// AuditLog 管理系APIの操作履歴（誰がいつ何を実行したか）
+type AuditLog struct {
+	gorm.Model
+	APIKeyID   *uint  `gorm:"index" json:"APIKeyID"` // 認証に使ったAPIキー（ブートストラップキー・未認証の場合はnull）
+	Actor      string `gorm:"index" json:"Actor"`    // APIキーのName（未認証の場合は "anonymous"）
+	Role       string `json:"Role"`                  // 実行時のロール
+	Method     string `json:"Method"`                // HTTPメソッド
+	Path       string `gorm:"index" json:"Path"`     // ルートのパス（例: /api/admin/sync）
+	Query      string `json:"Query"`                 // クエリ文字列
+	Status     int    `json:"Status"`                // レスポンスのステータスコード
+	RemoteIP   string `json:"RemoteIP"`              // 呼び出し元IP
+	DurationMs int64  `json:"DurationMs"`            // 処理時間（ミリ秒）
+}
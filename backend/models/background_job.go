@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BackgroundJob.Status の値
+const (
+	BackgroundJobStatusQueued    = "queued"    // 実行待ち（リトライ待ちを含む。RunAt以降に実行される）
+	BackgroundJobStatusRunning   = "running"   // ワーカーが実行中
+	BackgroundJobStatusSucceeded = "succeeded" // 成功
+	BackgroundJobStatusFailed    = "failed"    // リトライ上限に達した、またはリトライしても成功しないエラー
+)
+
+// BackgroundJob 管理系APIから登録され、ワーカーが非同期に実行するジョブ
+type BackgroundJob struct {
+	gorm.Model
+	Type        string     `gorm:"index;not null" json:"Type"`                      // ジョブの種類（例: "us_sync"）
+	Payload     string     `gorm:"type:jsonb;not null;default:'{}'" json:"Payload"` // ジョブの引数（JSON）
+	Status      string     `gorm:"index:idx_background_jobs_status_run_at;not null" json:"Status"`
+	RunAt       time.Time  `gorm:"index:idx_background_jobs_status_run_at;not null" json:"RunAt"` // この時刻以降に実行する
+	Attempts    int        `json:"Attempts"`                                                      // 実行した回数
+	MaxAttempts int        `json:"MaxAttempts"`                                                   // 最大実行回数
+	LockedBy    string     `json:"LockedBy"`                                                      // 実行中のワーカーID
+	LockedAt    *time.Time `json:"LockedAt"`                                                      // ワーカーが取得した時刻（実行中はハートビートで更新する）
+	StartedAt   *time.Time `json:"StartedAt"`                                                     // 初回の実行開始時刻
+	FinishedAt  *time.Time `json:"FinishedAt"`                                                    // 成功・失敗が確定した時刻
+	LastError   string     `gorm:"type:text" json:"LastError"`                                    // 直近の実行のエラー
+	Result      string     `gorm:"type:jsonb;not null;default:'null'" json:"Result"`              // 実行結果（JSON）
+	CreatedBy   string     `json:"CreatedBy"`                                                     // 登録したAPIキーのName
+
+	// リレーション
+	Steps []BackgroundJobStep `gorm:"foreignKey:JobID" json:"Steps,omitempty"`
+}
+
+// BackgroundJobStep ジョブ内の銘柄ごとの進捗
+type BackgroundJobStep struct {
+	gorm.Model
+	JobID   uint   `gorm:"uniqueIndex:idx_background_job_steps_job_item;not null" json:"JobID"`
+	Item    string `gorm:"uniqueIndex:idx_background_job_steps_job_item;not null" json:"Item"` // ticker・銘柄コードなど
+	Status  string `gorm:"not null" json:"Status"`                                             // running / succeeded / failed / skipped
+	Message string `gorm:"type:text" json:"Message"`                                           // エラー内容など
+}
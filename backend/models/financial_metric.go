@@ -0,0 +1,73 @@
+package models
+
+// FinancialMetric 財務諸表（FinancialStatement.RawJSON）から取り出した主要な数値と、そこから計算した成長率・利益率
+// 開示1件（DisclosureNumber）につき1行。J-Quantsで空文字の項目（予想修正の開示の実績値など）は null になる
+// 金額は円、成長率・利益率は%。実績値は期首からの累計（1Q/2Q/3Q/FY）
+type FinancialMetric struct {
+	ID                         uint   `gorm:"primaryKey" json:"ID"`
+	FinancialStatementID       uint   `gorm:"index;not null" json:"FinancialStatementID"`
+	Code                       string `gorm:"index;not null" json:"Code"`
+	DisclosureNumber           string `gorm:"uniqueIndex;not null" json:"DisclosureNumber"`
+	DisclosedDate              string `gorm:"index" json:"DisclosedDate"`
+	TypeOfDocument             string `json:"TypeOfDocument"`
+	TypeOfCurrentPeriod        string `gorm:"index" json:"TypeOfCurrentPeriod"` // 1Q / 2Q / 3Q / FY
+	CurrentPeriodEndDate       string `json:"CurrentPeriodEndDate"`
+	CurrentFiscalYearStartDate string `json:"CurrentFiscalYearStartDate"`
+	CurrentFiscalYearEndDate   string `gorm:"index" json:"CurrentFiscalYearEndDate"`
+
+	// 実績（累計）
+	NetSales           *float64 `json:"NetSales"`
+	OperatingProfit    *float64 `json:"OperatingProfit"`
+	OrdinaryProfit     *float64 `json:"OrdinaryProfit"`
+	Profit             *float64 `json:"Profit"` // 親会社株主に帰属する当期純利益
+	EarningsPerShare   *float64 `json:"EarningsPerShare"`
+	Equity             *float64 `json:"Equity"` // 純資産
+	TotalAssets        *float64 `json:"TotalAssets"`
+	EquityToAssetRatio *float64 `json:"EquityToAssetRatio"` // 自己資本比率（%）
+	BookValuePerShare  *float64 `json:"BookValuePerShare"`
+
+	// 期末発行済株式数（自己株式を含む）と期末自己株式数
+	IssuedShares   *float64 `json:"IssuedShares"`
+	TreasuryShares *float64 `json:"TreasuryShares"`
+
+	// 1株当たり年間配当（実績・当期予想・翌期予想）
+	DividendPerShare                 *float64 `json:"DividendPerShare"`
+	ForecastDividendPerShare         *float64 `json:"ForecastDividendPerShare"`
+	NextYearForecastDividendPerShare *float64 `json:"NextYearForecastDividendPerShare"`
+
+	// 当期の通期予想
+	ForecastNetSales         *float64 `json:"ForecastNetSales"`
+	ForecastOperatingProfit  *float64 `json:"ForecastOperatingProfit"`
+	ForecastOrdinaryProfit   *float64 `json:"ForecastOrdinaryProfit"`
+	ForecastProfit           *float64 `json:"ForecastProfit"`
+	ForecastEarningsPerShare *float64 `json:"ForecastEarningsPerShare"`
+
+	// 翌期の通期予想（FYの決算短信のみ）
+	NextYearForecastNetSales         *float64 `json:"NextYearForecastNetSales"`
+	NextYearForecastOperatingProfit  *float64 `json:"NextYearForecastOperatingProfit"`
+	NextYearForecastOrdinaryProfit   *float64 `json:"NextYearForecastOrdinaryProfit"`
+	NextYearForecastProfit           *float64 `json:"NextYearForecastProfit"`
+	NextYearForecastEarningsPerShare *float64 `json:"NextYearForecastEarningsPerShare"`
+
+	// 四半期単独の値（累計から前の四半期までの累計を引いたもの。1Qは累計と同じ）
+	QuarterNetSales        *float64 `json:"QuarterNetSales"`
+	QuarterOperatingProfit *float64 `json:"QuarterOperatingProfit"`
+	QuarterProfit          *float64 `json:"QuarterProfit"`
+
+	// 利益率（%）
+	OperatingMargin *float64 `json:"OperatingMargin"`
+	OrdinaryMargin  *float64 `json:"OrdinaryMargin"`
+	NetMargin       *float64 `json:"NetMargin"`
+
+	// 前年同期比（%。前期の同じ TypeOfCurrentPeriod の累計と比較）
+	NetSalesYoY         *float64 `json:"NetSalesYoY"`
+	OperatingProfitYoY  *float64 `json:"OperatingProfitYoY"`
+	OrdinaryProfitYoY   *float64 `json:"OrdinaryProfitYoY"`
+	ProfitYoY           *float64 `json:"ProfitYoY"`
+	EarningsPerShareYoY *float64 `json:"EarningsPerShareYoY"`
+
+	// 前四半期比（%。四半期単独の値を直前の四半期と比較。1Qは前期のFYの第4四半期と比較）
+	NetSalesQoQ        *float64 `json:"NetSalesQoQ"`
+	OperatingProfitQoQ *float64 `json:"OperatingProfitQoQ"`
+	ProfitQoQ          *float64 `json:"ProfitQoQ"`
+}
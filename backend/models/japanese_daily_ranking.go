@@ -0,0 +1,55 @@
+package models
+
+import "errors"
+
+// JapaneseDailyRanking 日本株の日次ランキング（値上がり率・値下がり率・売買代金）
+// J-Quantsにはランキングのエンドポイントが無いため、全銘柄の日足（DailyQuote）から市場区分ごとに計算して保存する
+// 銘柄名・市場区分は計算時点の銘柄マスタ（Company）の値
+type JapaneseDailyRanking struct {
+	ID               uint    `gorm:"primaryKey" json:"ID"`
+	Date             string  `gorm:"uniqueIndex:idx_japanese_daily_rankings_key;not null" json:"Date"`     // 取引日（YYYY-MM-DD）
+	Segment          string  `gorm:"uniqueIndex:idx_japanese_daily_rankings_key;not null" json:"Segment"`  // 市場区分（all / prime / standard / growth）
+	Category         string  `gorm:"uniqueIndex:idx_japanese_daily_rankings_key;not null" json:"Category"` // カテゴリ（DailyRanking.Category と同じ値）
+	Rank             int     `gorm:"uniqueIndex:idx_japanese_daily_rankings_key;not null" json:"Rank"`
+	Code             string  `gorm:"index;not null" json:"Code"`
+	CompanyName      string  `json:"CompanyName"`
+	MarketCode       string  `json:"MarketCode"`
+	MarketCodeName   string  `json:"MarketCodeName"`
+	Sector33CodeName string  `json:"Sector33CodeName"`
+	Price            float64 `json:"Price"`         // 終値
+	PreviousClose    float64 `json:"PreviousClose"` // 前営業日の終値（株式分割・併合は調整済み）
+	ChangeAmount     float64 `json:"ChangeAmount"`  // 変化額（値下がりの場合は負）
+	ChangeRate       float64 `json:"ChangeRate"`    // 変化率（%）
+	Volume           float64 `json:"Volume"`        // 出来高
+	TurnoverValue    float64 `json:"TurnoverValue"` // 売買代金（円）
+}
+
+// JapaneseDailyRanking.Segment に保存する市場区分
+// all はプライム・スタンダード・グロースの合計（ETF・REIT・TOKYO PRO Market などは含めない）
+const (
+	MarketSegmentAll      = "all"
+	MarketSegmentPrime    = "prime"
+	MarketSegmentStandard = "standard"
+	MarketSegmentGrowth   = "growth"
+)
+
+// MarketSegmentsByCode 銘柄マスタの市場コード（Company.MarketCode）と市場区分の対応
+var MarketSegmentsByCode = map[string]string{
+	"0111": MarketSegmentPrime,
+	"0112": MarketSegmentStandard,
+	"0113": MarketSegmentGrowth,
+}
+
+// ErrInvalidMarketSegment APIのmarketパラメータが不正
+var ErrInvalidMarketSegment = errors.New("invalid market. use 'all', 'prime', 'standard', or 'growth'")
+
+// ParseMarketSegment APIのmarketパラメータを JapaneseDailyRanking.Segment に変換する（空文字の場合は all）
+func ParseMarketSegment(segment string) (string, error) {
+	switch segment {
+	case "", MarketSegmentAll:
+		return MarketSegmentAll, nil
+	case MarketSegmentPrime, MarketSegmentStandard, MarketSegmentGrowth:
+		return segment, nil
+	}
+	return "", ErrInvalidMarketSegment
+}
@@ -109,6 +109,7 @@ type AnalysisResult struct {
 	PriceDataFrom string `json:"PriceDataFrom"`                       // 株価データの開始日
 	PriceDataTo   string `json:"PriceDataTo"`                         // 株価データの終了日
 	NewsSearchID  *uint  `gorm:"index" json:"NewsSearchID,omitempty"` // 使用したニュース検索バッチ（nullable）
+	NewsSearch    *NewsSearch `gorm:"foreignKey:NewsSearchID" json:"NewsSearch,omitempty"`
 
 	// リレーション
 	SectorAnalysisResultID *uint `gorm:"index" json:"SectorAnalysisResultID,omitempty"`
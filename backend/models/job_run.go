@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// JobRun.Status の値
+const (
+	JobRunStatusRunning   = "running"
+	JobRunStatusSucceeded = "succeeded"
+	JobRunStatusFailed    = "failed"
+	JobRunStatusSkipped   = "skipped" // 休場日などで実行しなかった
+)
+
+// JobRun スケジューラーが実行したジョブ1回分の履歴
+// ジョブ名と取引日の組は一意で、複数のプロセスでスケジューラーを動かしても同じ取引日には1回しか実行しない
+type JobRun struct {
+	gorm.Model
+	JobName     string     `gorm:"uniqueIndex:idx_job_runs_job_name_trading_date;not null" json:"JobName"` // ジョブ名（例: "us_sync"）
+	TradingDate string     `gorm:"uniqueIndex:idx_job_runs_job_name_trading_date" json:"TradingDate"`      // 対象の取引日（YYYY-MM-DD, 取引所の現地日付）
+	Status      string     `gorm:"index;not null" json:"Status"`                                           // running / succeeded / failed / skipped
+	StartedAt   time.Time  `json:"StartedAt"`                                                              // 実行開始時刻
+	FinishedAt  *time.Time `json:"FinishedAt"`                                                             // 実行終了時刻（実行中はnull）
+	Message     string     `gorm:"type:text" json:"Message"`                                               // エラー内容・スキップ理由・実行したジョブのIDなど
+}
@@ -1,18 +1,22 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
 
 type DailyRanking struct {
 	gorm.Model
 	StockID      uint    `json:"StockID"`                      // Foreign Key (Stockテーブルへの紐付け)
 	Date         string  `gorm:"index" json:"Date"`            // 日付 (例: "2025-01-01") ※米国基準（米国時間）
 	Rank         int     `json:"Rank"`                         // その日の順位
-	Category     string  `gorm:"index" json:"Category"`        // カテゴリ（例: Technology, Finance, Healthcare, etc.）
-	ChangeAmount float64 `json:"ChangeAmount"`                 // 上昇額
-	ChangeRate   float64 `json:"ChangeRate"`                   // 上昇率
+	Category     string  `gorm:"index" json:"Category"`        // カテゴリ（Top Gainers / Top Losers / Most Actively Traded）
+	ChangeAmount float64 `json:"ChangeAmount"`                 // 変化額（値下がりの場合は負）
+	ChangeRate   float64 `json:"ChangeRate"`                   // 変化率（%）
 	Price        float64 `json:"Price"`                        // その時の株価
 	NewsSummary  string  `gorm:"type:text" json:"NewsSummary"` // AIに読ませたニュースの要約（念のため保存）
-	AiAnalysis   string  `gorm:"type:text" json:"AiAnalysis"`  // AIが出した上昇理由
+	AiAnalysis   string  `gorm:"type:text" json:"AiAnalysis"`  // AIが出した値動きの理由
 	Stock        Stock   `json:"Stock,omitempty"`
 }
 
@@ -22,7 +26,7 @@ type Stock struct {
 	Name     string         `json:"Name"`// 企業名
 	Sector   string         `json:"Sector"` //セクター（大分類）
 	Industry string         `json:"Industry"` //業界（小分類）
-	Description string      `gorm:"type:text"json:"Description"` //企業の説明
+	Description string      `gorm:"type:text" json:"Description"` //企業の説明
 	Website string         `json:"Website"` //企業の公式WebsiteURL
 	Country string         `json:"Country"` //企業の本社所在地
     FullTimeEmployees int  `json:"FullTimeEmployees"` //企業の従業員数
@@ -48,3 +52,27 @@ type StockMetric struct {
 	// リレーション
     Stock         Stock   `json:"Stock,omitempty"`
 }
+
+// DailyRanking.Category に保存するランキングカテゴリ
+const (
+	CategoryTopGainers         = "Top Gainers"
+	CategoryTopLosers          = "Top Losers"
+	CategoryMostActivelyTraded = "Most Actively Traded"
+)
+
+// ErrInvalidCategory APIのcategoryパラメータが不正
+var ErrInvalidCategory = errors.New("invalid category. use 'gainers', 'losers', or 'active'")
+
+// ParseRankingCategory APIのcategoryパラメータ（gainers / losers / active）をDailyRanking.Categoryに変換する
+// 空文字の場合は従来通りTop Gainersとする
+func ParseRankingCategory(category string) (string, error) {
+	switch category {
+	case "", "gainers":
+		return CategoryTopGainers, nil
+	case "losers":
+		return CategoryTopLosers, nil
+	case "active":
+		return CategoryMostActivelyTraded, nil
+	}
+	return "", ErrInvalidCategory
+}
@@ -0,0 +1,21 @@
+package models
+
+// StockDailyBar 米国株の日足（OHLCV）
+// ランキング上位銘柄について FMP / Alpha Vantage から取得し、詳細ページの株価チャートに使う
+// テクニカル指標は分割・配当の調整後の値（Adjustment*）で計算する
+type StockDailyBar struct {
+	ID               uint    `gorm:"primaryKey" json:"ID"`
+	StockID          uint    `gorm:"uniqueIndex:idx_stock_daily_bars_stock_date;not null" json:"StockID"` // Foreign Key (Stockテーブルへの紐付け)
+	Date             string  `gorm:"uniqueIndex:idx_stock_daily_bars_stock_date;not null" json:"Date"`    // 取引日（YYYY-MM-DD, 米国時間）
+	Open             float64 `json:"Open"`
+	High             float64 `json:"High"`
+	Low              float64 `json:"Low"`
+	Close            float64 `json:"Close"`
+	Volume           int64   `json:"Volume"`
+	AdjustmentOpen   float64 `json:"AdjustmentOpen"`   // 調整後始値
+	AdjustmentHigh   float64 `json:"AdjustmentHigh"`   // 調整後高値
+	AdjustmentLow    float64 `json:"AdjustmentLow"`    // 調整後安値
+	AdjustmentClose  float64 `json:"AdjustmentClose"`  // 調整後終値
+	AdjustmentVolume float64 `json:"AdjustmentVolume"` // 調整後出来高
+	Provider         string  `json:"Provider"`         // 取得元（fmp / alphavantage）
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SyncRun.Status / SyncRunStage.Status の値
+const (
+	SyncStatusPending   = "pending" // 未実行
+	SyncStatusRunning   = "running"
+	SyncStatusSucceeded = "succeeded"
+	SyncStatusFailed    = "failed"
+)
+
+// SyncRunStage.Stage の値（米国株の同期はこの順に実行する）
+const (
+	SyncStageMovers    = "movers"    // ランキングの取得・保存
+	SyncStageProfiles  = "profiles"  // 企業情報（Stock / StockMetric）の同期
+	SyncStageBars      = "bars"      // 上位銘柄の日足（StockDailyBar）の同期
+	SyncStageValuation = "valuation" // 上位銘柄のバリュエーション（StockValuation）の計算
+	SyncStageNews      = "news"      // 上位銘柄のニュースを取得して DailyRanking.NewsSummary に保存
+	SyncStageAI        = "ai"        // 上位銘柄のAI分析
+)
+
+// SyncStages 実行順に並べた同期のステージ
+var SyncStages = []string{SyncStageMovers, SyncStageProfiles, SyncStageBars, SyncStageValuation, SyncStageNews, SyncStageAI}
+
+// SyncRun 取引日ごとの米国株の同期の記録
+// 同じ取引日の同期を再実行した場合は、成功済みのステージを飛ばして失敗・未実行のステージから再開する
+type SyncRun struct {
+	gorm.Model
+	TradingDate string     `gorm:"uniqueIndex;not null" json:"TradingDate"` // 対象の取引日（YYYY-MM-DD, 米国時間）
+	Status      string     `gorm:"index;not null" json:"Status"`            // running / succeeded / failed
+	Provider    string     `json:"Provider"`                                // ランキングを取得したプロバイダー
+	Attempts    int        `json:"Attempts"`                                // 実行した回数
+	StartedAt   *time.Time `json:"StartedAt"`                               // 直近の実行の開始時刻
+	FinishedAt  *time.Time `json:"FinishedAt"`                              // 直近の実行の終了時刻
+
+	// リレーション
+	Stages []SyncRunStage `gorm:"foreignKey:SyncRunID" json:"Stages,omitempty"`
+}
+
+// SyncRunStage 同期のステージごとの状態
+type SyncRunStage struct {
+	gorm.Model
+	SyncRunID  uint       `gorm:"uniqueIndex:idx_sync_run_stages_run_stage;not null" json:"SyncRunID"`
+	Stage      string     `gorm:"uniqueIndex:idx_sync_run_stages_run_stage;not null" json:"Stage"` // movers / profiles / bars / news / ai
+	Status     string     `gorm:"not null" json:"Status"`                                          // pending / running / succeeded / failed
+	Attempts   int        `json:"Attempts"`                                                        // 実行した回数
+	StartedAt  *time.Time `json:"StartedAt"`
+	FinishedAt *time.Time `json:"FinishedAt"`
+	Message    string     `gorm:"type:text" json:"Message"` // エラー内容（失敗した銘柄など）
+}
+
+// Stage 指定したステージを返す（無ければnil）
+func (r *SyncRun) Stage(stage string) *SyncRunStage {
+	for i := range r.Stages {
+		if r.Stages[i].Stage == stage {
+			return &r.Stages[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package models
+
+// ValuationRatios 終値と1株あたりの指標から計算したバリュエーション（StockValuation / JapaneseStockValuation に埋め込む）
+// 1株あたりの指標が取得できない・0以下の場合、その指標を使う比率は null になる
+type ValuationRatios struct {
+	Close             float64  `json:"Close"`             // 終値
+	EarningsPerShare  *float64 `json:"EarningsPerShare"`  // 1株当たり利益（直近12ヶ月）
+	BookValuePerShare *float64 `json:"BookValuePerShare"` // 1株当たり純資産
+	DividendPerShare  *float64 `json:"DividendPerShare"`  // 1株当たり年間配当
+	PER               *float64 `json:"PER"`               // 株価収益率（倍）
+	PBR               *float64 `json:"PBR"`               // 株価純資産倍率（倍）
+	DividendYield     *float64 `json:"DividendYield"`     // 配当利回り（%）
+	ROE               *float64 `json:"ROE"`               // 自己資本利益率（%）
+}
+
+// PriceSignals 日足から計算した値動きの指標（スクリーナーの条件に使う。本数が足りない場合は null）
+type PriceSignals struct {
+	ChangeRate *float64 `json:"ChangeRate"` // 前営業日の終値からの変化率（%）
+	RSI14      *float64 `json:"RSI14"`      // RSI(14)（Wilderの平滑化）
+}
+
+// StockValuation 米国株の日次のバリュエーション
+// ランキング上位銘柄について、日足の終値とプロバイダー（FMP / Alpha Vantage）の1株あたりの指標から計算する
+type StockValuation struct {
+	ID              uint   `gorm:"primaryKey" json:"ID"`
+	StockID         uint   `gorm:"uniqueIndex:idx_stock_valuations_stock_date;not null" json:"StockID"` // Foreign Key (Stockテーブルへの紐付け)
+	Date            string `gorm:"uniqueIndex:idx_stock_valuations_stock_date;not null" json:"Date"`    // 取引日（YYYY-MM-DD, 米国時間）
+	ValuationRatios `gorm:"embedded"`
+	PriceSignals    `gorm:"embedded"`
+	Provider        string `json:"Provider"` // 1株あたりの指標の取得元（fmp / alphavantage）
+}
+
+// JapaneseStockValuation 日本株の日次のバリュエーション
+// 日足（DailyQuote）の終値と、その日までに開示された財務指標（FinancialMetric）から計算する
+type JapaneseStockValuation struct {
+	ID               uint   `gorm:"primaryKey" json:"ID"`
+	Code             string `gorm:"uniqueIndex:idx_japanese_stock_valuations_code_date;not null" json:"Code"` // 銘柄コード
+	Date             string `gorm:"uniqueIndex:idx_japanese_stock_valuations_code_date;not null" json:"Date"` // 取引日（YYYY-MM-DD）
+	ValuationRatios  `gorm:"embedded"`
+	PriceSignals     `gorm:"embedded"`
+	MarketCap        *float64 `json:"MarketCap"`        // 時価総額（終値 × 自己株式を除く期末発行済株式数）
+	DisclosureNumber string   `json:"DisclosureNumber"` // 計算に使った直近の開示
+}
@@ -0,0 +1,197 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version 生成するOpenAPIのバージョン
+const Version = "3.0.3"
+
+const contentTypeJSON = "application/json"
+
+// Route 1つのルートの説明
+type Route struct {
+	Method         string // GET / POST / PUT / DELETE
+	Path           string // Echoのパス（例: /api/stocks/:ticker）。:param は {param} に変換する
+	OperationID    string
+	Tag            string
+	Summary        string
+	Description    string
+	PathParams     map[string]string // パスパラメータの説明（省略時は名前のみ）
+	Query          []Parameter
+	Body           any     // リクエストボディの型のゼロ値（例: services.ScreenerRequest{}）
+	Status         int     // 成功時のステータスコード（省略時は200）
+	Response       any     // レスポンスの型のゼロ値。nil の場合は ResponseSchema を使う
+	ResponseSchema *Schema // Goの型が無いレスポンス（HTMLなど）のスキーマ
+	ContentType    string  // レスポンスのContent-Type（省略時は application/json）
+	Errors         []int   // 返し得るエラーのステータスコード（500は常に追加する）
+	Security       []string
+}
+
+// Builder ルートを登録して Document を組み立てる
+type Builder struct {
+	document    *Document
+	registry    *schemaRegistry
+	errorSchema *Schema // エラーレスポンスのスキーマ（SetErrorResponse で設定する）
+}
+
+func NewBuilder(info Info, tags ...Tag) *Builder {
+	return &Builder{
+		document: &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Tags:    tags,
+			Paths:   make(map[string]*PathItem),
+		},
+		registry: newSchemaRegistry(),
+	}
+}
+
+// SetErrorResponse すべてのエラーレスポンス（Route.Errors と500）の型を設定する
+func (b *Builder) SetErrorResponse(value any) {
+	b.errorSchema = b.registry.schemaFor(reflect.TypeOf(value))
+}
+
+// AddSecurityScheme 認証方式を登録する（Route.Security で名前を指定する）
+func (b *Builder) AddSecurityScheme(name string, scheme *SecurityScheme) {
+	if b.document.Components.SecuritySchemes == nil {
+		b.document.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+	}
+	b.document.Components.SecuritySchemes[name] = scheme
+}
+
+func (b *Builder) Add(route Route) {
+	operation := &Operation{
+		Tags:        []string{route.Tag},
+		Summary:     route.Summary,
+		Description: route.Description,
+		OperationID: route.OperationID,
+		Responses:   make(map[string]*Response),
+	}
+
+	path, pathParams := ConvertPath(route.Path)
+	for _, name := range pathParams {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:        name,
+			In:          "path",
+			Description: route.PathParams[name],
+			Required:    true,
+			Schema:      &Schema{Type: "string"},
+		})
+	}
+	for _, param := range route.Query {
+		param.In = "query"
+		operation.Parameters = append(operation.Parameters, param)
+	}
+
+	if route.Body != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{contentTypeJSON: {Schema: b.registry.schemaFor(reflect.TypeOf(route.Body))}},
+		}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	responseSchema := route.ResponseSchema
+	if route.Response != nil {
+		responseSchema = b.registry.schemaFor(reflect.TypeOf(route.Response))
+	}
+	contentType := route.ContentType
+	if contentType == "" {
+		contentType = contentTypeJSON
+	}
+	operation.Responses[strconv.Itoa(status)] = &Response{
+		Description: http.StatusText(status),
+		Content:     map[string]*MediaType{contentType: {Schema: responseSchema}},
+	}
+
+	errorCodes := append([]int{}, route.Errors...)
+	if len(route.Security) > 0 {
+		errorCodes = append(errorCodes, http.StatusUnauthorized, http.StatusForbidden)
+		for _, name := range route.Security {
+			operation.Security = append(operation.Security, map[string][]string{name: {}})
+		}
+	}
+	errorCodes = append(errorCodes, http.StatusInternalServerError)
+	for _, code := range errorCodes {
+		response := &Response{Description: http.StatusText(code)}
+		if b.errorSchema != nil {
+			response.Content = map[string]*MediaType{contentTypeJSON: {Schema: b.errorSchema}}
+		}
+		operation.Responses[strconv.Itoa(code)] = response
+	}
+
+	item, ok := b.document.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		b.document.Paths[path] = item
+	}
+	item.setOperation(route.Method, operation)
+}
+
+// Document 登録したルートとスキーマからドキュメントを返す
+func (b *Builder) Document() *Document {
+	b.document.Components.Schemas = b.registry.schemas
+	for _, schema := range b.document.Components.Schemas {
+		sort.Strings(schema.Required)
+	}
+	return b.document
+}
+
+// ConvertPath Echoのパス（/api/stocks/:ticker）をOpenAPIのパス（/api/stocks/{ticker}）にしてパラメータ名を返す
+func ConvertPath(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// QueryParam クエリパラメータ
+func QueryParam(name string, description string, schema *Schema) Parameter {
+	return Parameter{Name: name, Description: description, Schema: schema}
+}
+
+// RequiredQueryParam 必須のクエリパラメータ
+func RequiredQueryParam(name string, description string, schema *Schema) Parameter {
+	return Parameter{Name: name, Description: description, Required: true, Schema: schema}
+}
+
+// String 文字列のスキーマ（values を指定した場合はその値のみ）
+func String(values ...string) *Schema {
+	schema := &Schema{Type: "string"}
+	for _, value := range values {
+		schema.Enum = append(schema.Enum, value)
+	}
+	return schema
+}
+
+// Date YYYY-MM-DD の日付のスキーマ
+func Date() *Schema {
+	return &Schema{Type: "string", Format: "date"}
+}
+
+// Integer minValue〜maxValue の整数のスキーマ（defaultValue が0の場合は既定値なし）
+func Integer(defaultValue int, minValue int, maxValue int) *Schema {
+	schema := &Schema{Type: "integer", Minimum: float(float64(minValue)), Maximum: float(float64(maxValue))}
+	if defaultValue != 0 {
+		schema.Default = defaultValue
+	}
+	return schema
+}
+
+// Number 下限のある数値のスキーマ
+func Number(minValue float64) *Schema {
+	return &Schema{Type: "number", Minimum: float(minValue)}
+}
@@ -0,0 +1,9 @@
+package openapi
+
+import _ "embed"
+
+// DocsHTML 仕様（同じディレクトリの openapi.json）を表示するSwagger UIのページ
+// Swagger UI はCDNから読み込む
+//
+//go:embed docs.html
+var DocsHTML []byte
@@ -0,0 +1,145 @@
+// Package openapi は /api のOpenAPI 3の仕様を生成し、レスポンスを仕様と照合する
+//
+// スキーマはハンドラーが返すGoの型（dto・models・servicesの構造体）からリフレクションで生成するので、
+// 型にフィールドを追加すると仕様にも反映される。ルートを追加した場合は controllers/openapi.go にも追加すること。
+package openapi
+
+// Document OpenAPI 3.0 のドキュメント（このAPIで使う項目のみ）
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Tags       []Tag                `json:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem パスごとのオペレーション（HTTPメソッドごと）
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+}
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path / query
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`             // http / apiKey
+	Scheme      string `json:"scheme,omitempty"` // bearer
+	Name        string `json:"name,omitempty"`
+	In          string `json:"in,omitempty"` // header
+	Description string `json:"description,omitempty"`
+}
+
+// Schema OpenAPI 3.0 のスキーマ（JSON Schemaのサブセット）
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+// Operation method（GET / POST など）のオペレーションを返す（無ければnil）
+func (p *PathItem) Operation(method string) *Operation {
+	switch method {
+	case "GET":
+		return p.Get
+	case "POST":
+		return p.Post
+	case "PUT":
+		return p.Put
+	case "DELETE":
+		return p.Delete
+	}
+	return nil
+}
+
+// Methods オペレーションが登録されているメソッド
+func (p *PathItem) Methods() []string {
+	var methods []string
+	for _, method := range []string{"GET", "POST", "PUT", "DELETE"} {
+		if p.Operation(method) != nil {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+func (p *PathItem) setOperation(method string, operation *Operation) {
+	switch method {
+	case "GET":
+		p.Get = operation
+	case "POST":
+		p.Post = operation
+	case "PUT":
+		p.Put = operation
+	case "DELETE":
+		p.Delete = operation
+	}
+}
+
+// ResolveRef "#/components/schemas/Name" の参照先のスキーマを返す
+func (d *Document) ResolveRef(ref string) *Schema {
+	const prefix = "#/components/schemas/"
+	if len(ref) <= len(prefix) || ref[:len(prefix)] != prefix {
+		return nil
+	}
+	return d.Components.Schemas[ref[len(prefix):]]
+}
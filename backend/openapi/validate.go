@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Validate value（レスポンスのJSONをデコードした値）が schema に合うかを確認し、合わない箇所を返す
+// 契約の確認に使うため、スキーマに無いプロパティ（仕様への追加漏れ）も報告する
+func (d *Document) Validate(schema *Schema, value any) []string {
+	var problems []string
+	d.validate("$", schema, value, &problems)
+	return problems
+}
+
+func (d *Document) validate(path string, schema *Schema, value any, problems *[]string) {
+	report := func(format string, args ...any) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		resolved := d.ResolveRef(schema.Ref)
+		if resolved == nil {
+			report("unknown schema %s", schema.Ref)
+			return
+		}
+		d.validate(path, resolved, value, problems)
+		return
+	}
+	if value == nil {
+		if !schema.Nullable && (schema.Type != "" || len(schema.AllOf) > 0) {
+			report("null is not allowed")
+		}
+		return
+	}
+	for _, sub := range schema.AllOf {
+		d.validate(path, sub, value, problems)
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			report("expected object, got %s", jsonType(value))
+			return
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				report("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := schema.Properties[name]; ok {
+				d.validate(path+"."+name, property, object[name], problems)
+			} else if schema.AdditionalProperties != nil {
+				d.validate(path+"."+name, schema.AdditionalProperties, object[name], problems)
+			} else if schema.Properties != nil {
+				report("undocumented property %q", name)
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			report("expected array, got %s", jsonType(value))
+			return
+		}
+		for i, item := range array {
+			d.validate(fmt.Sprintf("%s[%d]", path, i), schema.Items, item, problems)
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			report("expected string, got %s", jsonType(value))
+			return
+		}
+		if len(schema.Enum) > 0 && !containsValue(schema.Enum, text) {
+			report("%q is not one of %v", text, schema.Enum)
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, text); err != nil {
+				report("%q is not a date-time", text)
+			}
+		}
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok {
+			report("expected %s, got %s", schema.Type, jsonType(value))
+			return
+		}
+		if schema.Type == "integer" && number != math.Trunc(number) {
+			report("expected integer, got %v", number)
+		}
+		if schema.Minimum != nil && number < *schema.Minimum {
+			report("%v is less than %v", number, *schema.Minimum)
+		}
+		if schema.Maximum != nil && number > *schema.Maximum {
+			report("%v is greater than %v", number, *schema.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			report("expected boolean, got %s", jsonType(value))
+		}
+	}
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType encoding/json でデコードした値のJSONの型名
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,1351 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.28.3
+// source: proto/analysis.proto
+
+package analysispb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Sentiment int32
+
+const (
+	Sentiment_SENTIMENT_UNSPECIFIED Sentiment = 0
+	Sentiment_SENTIMENT_STRONG_BUY  Sentiment = 1
+	Sentiment_SENTIMENT_BUY         Sentiment = 2
+	Sentiment_SENTIMENT_HOLD        Sentiment = 3
+	Sentiment_SENTIMENT_SELL        Sentiment = 4
+)
+
+// Enum value maps for Sentiment.
+var (
+	Sentiment_name = map[int32]string{
+		0: "SENTIMENT_UNSPECIFIED",
+		1: "SENTIMENT_STRONG_BUY",
+		2: "SENTIMENT_BUY",
+		3: "SENTIMENT_HOLD",
+		4: "SENTIMENT_SELL",
+	}
+	Sentiment_value = map[string]int32{
+		"SENTIMENT_UNSPECIFIED": 0,
+		"SENTIMENT_STRONG_BUY":  1,
+		"SENTIMENT_BUY":         2,
+		"SENTIMENT_HOLD":        3,
+		"SENTIMENT_SELL":        4,
+	}
+)
+
+func (x Sentiment) Enum() *Sentiment {
+	p := new(Sentiment)
+	*p = x
+	return p
+}
+
+func (x Sentiment) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Sentiment) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_analysis_proto_enumTypes[0].Descriptor()
+}
+
+func (Sentiment) Type() protoreflect.EnumType {
+	return &file_proto_analysis_proto_enumTypes[0]
+}
+
+func (x Sentiment) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Sentiment.Descriptor instead.
+func (Sentiment) EnumDescriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{0}
+}
+
+type CompanyInfo struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Code               string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	CompanyName        string                 `protobuf:"bytes,2,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty"`
+	CompanyNameEnglish string                 `protobuf:"bytes,3,opt,name=company_name_english,json=companyNameEnglish,proto3" json:"company_name_english,omitempty"`
+	Sector17Code       string                 `protobuf:"bytes,4,opt,name=sector17_code,json=sector17Code,proto3" json:"sector17_code,omitempty"`
+	Sector17CodeName   string                 `protobuf:"bytes,5,opt,name=sector17_code_name,json=sector17CodeName,proto3" json:"sector17_code_name,omitempty"`
+	Sector33Code       string                 `protobuf:"bytes,6,opt,name=sector33_code,json=sector33Code,proto3" json:"sector33_code,omitempty"`
+	Sector33CodeName   string                 `protobuf:"bytes,7,opt,name=sector33_code_name,json=sector33CodeName,proto3" json:"sector33_code_name,omitempty"`
+	ScaleCategory      string                 `protobuf:"bytes,8,opt,name=scale_category,json=scaleCategory,proto3" json:"scale_category,omitempty"`
+	MarketCode         string                 `protobuf:"bytes,9,opt,name=market_code,json=marketCode,proto3" json:"market_code,omitempty"`
+	MarketCodeName     string                 `protobuf:"bytes,10,opt,name=market_code_name,json=marketCodeName,proto3" json:"market_code_name,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CompanyInfo) Reset() {
+	*x = CompanyInfo{}
+	mi := &file_proto_analysis_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompanyInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyInfo) ProtoMessage() {}
+
+func (x *CompanyInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analysis_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyInfo.ProtoReflect.Descriptor instead.
+func (*CompanyInfo) Descriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CompanyInfo) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CompanyInfo) GetCompanyName() string {
+	if x != nil {
+		return x.CompanyName
+	}
+	return ""
+}
+
+func (x *CompanyInfo) GetCompanyNameEnglish() string {
+	if x != nil {
+		return x.CompanyNameEnglish
+	}
+	return ""
+}
+
+func (x *CompanyInfo) GetSector17Code() string {
+	if x != nil {
+		return x.Sector17Code
+	}
+	return ""
+}
+
+func (x *CompanyInfo) GetSector17CodeName() string {
+	if x != nil {
+		return x.Sector17CodeName
+	}
+	return ""
+}
+
+func (x *CompanyInfo) GetSector33Code() string {
+	if x != nil {
+		return x.Sector33Code
+	}
+	return ""
+}
+
+func (x *CompanyInfo) GetSector33CodeName() string {
+	if x != nil {
+		return x.Sector33CodeName
+	}
+	return ""
+}
+
+func (x *CompanyInfo) GetScaleCategory() string {
+	if x != nil {
+		return x.ScaleCategory
+	}
+	return ""
+}
+
+func (x *CompanyInfo) GetMarketCode() string {
+	if x != nil {
+		return x.MarketCode
+	}
+	return ""
+}
+
+func (x *CompanyInfo) GetMarketCodeName() string {
+	if x != nil {
+		return x.MarketCodeName
+	}
+	return ""
+}
+
+type StockPrice struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Date             string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Open             float64                `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
+	High             float64                `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
+	Low              float64                `protobuf:"fixed64,4,opt,name=low,proto3" json:"low,omitempty"`
+	Close            float64                `protobuf:"fixed64,5,opt,name=close,proto3" json:"close,omitempty"`
+	Volume           float64                `protobuf:"fixed64,6,opt,name=volume,proto3" json:"volume,omitempty"`
+	TurnoverValue    float64                `protobuf:"fixed64,7,opt,name=turnover_value,json=turnoverValue,proto3" json:"turnover_value,omitempty"`
+	AdjustmentFactor float64                `protobuf:"fixed64,8,opt,name=adjustment_factor,json=adjustmentFactor,proto3" json:"adjustment_factor,omitempty"`
+	AdjustmentOpen   float64                `protobuf:"fixed64,9,opt,name=adjustment_open,json=adjustmentOpen,proto3" json:"adjustment_open,omitempty"`
+	AdjustmentHigh   float64                `protobuf:"fixed64,10,opt,name=adjustment_high,json=adjustmentHigh,proto3" json:"adjustment_high,omitempty"`
+	AdjustmentLow    float64                `protobuf:"fixed64,11,opt,name=adjustment_low,json=adjustmentLow,proto3" json:"adjustment_low,omitempty"`
+	AdjustmentClose  float64                `protobuf:"fixed64,12,opt,name=adjustment_close,json=adjustmentClose,proto3" json:"adjustment_close,omitempty"`
+	AdjustmentVolume float64                `protobuf:"fixed64,13,opt,name=adjustment_volume,json=adjustmentVolume,proto3" json:"adjustment_volume,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StockPrice) Reset() {
+	*x = StockPrice{}
+	mi := &file_proto_analysis_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockPrice) ProtoMessage() {}
+
+func (x *StockPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analysis_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockPrice.ProtoReflect.Descriptor instead.
+func (*StockPrice) Descriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StockPrice) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *StockPrice) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *StockPrice) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *StockPrice) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *StockPrice) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *StockPrice) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *StockPrice) GetTurnoverValue() float64 {
+	if x != nil {
+		return x.TurnoverValue
+	}
+	return 0
+}
+
+func (x *StockPrice) GetAdjustmentFactor() float64 {
+	if x != nil {
+		return x.AdjustmentFactor
+	}
+	return 0
+}
+
+func (x *StockPrice) GetAdjustmentOpen() float64 {
+	if x != nil {
+		return x.AdjustmentOpen
+	}
+	return 0
+}
+
+func (x *StockPrice) GetAdjustmentHigh() float64 {
+	if x != nil {
+		return x.AdjustmentHigh
+	}
+	return 0
+}
+
+func (x *StockPrice) GetAdjustmentLow() float64 {
+	if x != nil {
+		return x.AdjustmentLow
+	}
+	return 0
+}
+
+func (x *StockPrice) GetAdjustmentClose() float64 {
+	if x != nil {
+		return x.AdjustmentClose
+	}
+	return 0
+}
+
+func (x *StockPrice) GetAdjustmentVolume() float64 {
+	if x != nil {
+		return x.AdjustmentVolume
+	}
+	return 0
+}
+
+type TechnicalIndicators struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Date                 string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Close                float64                `protobuf:"fixed64,2,opt,name=close,proto3" json:"close,omitempty"`
+	Bars                 int32                  `protobuf:"varint,3,opt,name=bars,proto3" json:"bars,omitempty"`
+	Sma20                *float64               `protobuf:"fixed64,4,opt,name=sma20,proto3,oneof" json:"sma20,omitempty"`
+	Sma50                *float64               `protobuf:"fixed64,5,opt,name=sma50,proto3,oneof" json:"sma50,omitempty"`
+	Sma200               *float64               `protobuf:"fixed64,6,opt,name=sma200,proto3,oneof" json:"sma200,omitempty"`
+	Ema12                *float64               `protobuf:"fixed64,7,opt,name=ema12,proto3,oneof" json:"ema12,omitempty"`
+	Ema26                *float64               `protobuf:"fixed64,8,opt,name=ema26,proto3,oneof" json:"ema26,omitempty"`
+	Rsi14                *float64               `protobuf:"fixed64,9,opt,name=rsi14,proto3,oneof" json:"rsi14,omitempty"`
+	Macd                 *float64               `protobuf:"fixed64,10,opt,name=macd,proto3,oneof" json:"macd,omitempty"`
+	MacdSignal           *float64               `protobuf:"fixed64,11,opt,name=macd_signal,json=macdSignal,proto3,oneof" json:"macd_signal,omitempty"`
+	MacdHistogram        *float64               `protobuf:"fixed64,12,opt,name=macd_histogram,json=macdHistogram,proto3,oneof" json:"macd_histogram,omitempty"`
+	BollingerUpper       *float64               `protobuf:"fixed64,13,opt,name=bollinger_upper,json=bollingerUpper,proto3,oneof" json:"bollinger_upper,omitempty"`
+	BollingerMiddle      *float64               `protobuf:"fixed64,14,opt,name=bollinger_middle,json=bollingerMiddle,proto3,oneof" json:"bollinger_middle,omitempty"`
+	BollingerLower       *float64               `protobuf:"fixed64,15,opt,name=bollinger_lower,json=bollingerLower,proto3,oneof" json:"bollinger_lower,omitempty"`
+	BollingerPercentB    *float64               `protobuf:"fixed64,16,opt,name=bollinger_percent_b,json=bollingerPercentB,proto3,oneof" json:"bollinger_percent_b,omitempty"`
+	Atr14                *float64               `protobuf:"fixed64,17,opt,name=atr14,proto3,oneof" json:"atr14,omitempty"`
+	Atr14Percent         *float64               `protobuf:"fixed64,18,opt,name=atr14_percent,json=atr14Percent,proto3,oneof" json:"atr14_percent,omitempty"`
+	VolumeZScore20       *float64               `protobuf:"fixed64,19,opt,name=volume_z_score20,json=volumeZScore20,proto3,oneof" json:"volume_z_score20,omitempty"`
+	YearHigh             float64                `protobuf:"fixed64,20,opt,name=year_high,json=yearHigh,proto3" json:"year_high,omitempty"`
+	YearLow              float64                `protobuf:"fixed64,21,opt,name=year_low,json=yearLow,proto3" json:"year_low,omitempty"`
+	DistanceFromYearHigh float64                `protobuf:"fixed64,22,opt,name=distance_from_year_high,json=distanceFromYearHigh,proto3" json:"distance_from_year_high,omitempty"`
+	DistanceFromYearLow  float64                `protobuf:"fixed64,23,opt,name=distance_from_year_low,json=distanceFromYearLow,proto3" json:"distance_from_year_low,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TechnicalIndicators) Reset() {
+	*x = TechnicalIndicators{}
+	mi := &file_proto_analysis_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TechnicalIndicators) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TechnicalIndicators) ProtoMessage() {}
+
+func (x *TechnicalIndicators) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analysis_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TechnicalIndicators.ProtoReflect.Descriptor instead.
+func (*TechnicalIndicators) Descriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TechnicalIndicators) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *TechnicalIndicators) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetBars() int32 {
+	if x != nil {
+		return x.Bars
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetSma20() float64 {
+	if x != nil && x.Sma20 != nil {
+		return *x.Sma20
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetSma50() float64 {
+	if x != nil && x.Sma50 != nil {
+		return *x.Sma50
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetSma200() float64 {
+	if x != nil && x.Sma200 != nil {
+		return *x.Sma200
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetEma12() float64 {
+	if x != nil && x.Ema12 != nil {
+		return *x.Ema12
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetEma26() float64 {
+	if x != nil && x.Ema26 != nil {
+		return *x.Ema26
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetRsi14() float64 {
+	if x != nil && x.Rsi14 != nil {
+		return *x.Rsi14
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetMacd() float64 {
+	if x != nil && x.Macd != nil {
+		return *x.Macd
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetMacdSignal() float64 {
+	if x != nil && x.MacdSignal != nil {
+		return *x.MacdSignal
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetMacdHistogram() float64 {
+	if x != nil && x.MacdHistogram != nil {
+		return *x.MacdHistogram
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetBollingerUpper() float64 {
+	if x != nil && x.BollingerUpper != nil {
+		return *x.BollingerUpper
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetBollingerMiddle() float64 {
+	if x != nil && x.BollingerMiddle != nil {
+		return *x.BollingerMiddle
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetBollingerLower() float64 {
+	if x != nil && x.BollingerLower != nil {
+		return *x.BollingerLower
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetBollingerPercentB() float64 {
+	if x != nil && x.BollingerPercentB != nil {
+		return *x.BollingerPercentB
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetAtr14() float64 {
+	if x != nil && x.Atr14 != nil {
+		return *x.Atr14
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetAtr14Percent() float64 {
+	if x != nil && x.Atr14Percent != nil {
+		return *x.Atr14Percent
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetVolumeZScore20() float64 {
+	if x != nil && x.VolumeZScore20 != nil {
+		return *x.VolumeZScore20
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetYearHigh() float64 {
+	if x != nil {
+		return x.YearHigh
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetYearLow() float64 {
+	if x != nil {
+		return x.YearLow
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetDistanceFromYearHigh() float64 {
+	if x != nil {
+		return x.DistanceFromYearHigh
+	}
+	return 0
+}
+
+func (x *TechnicalIndicators) GetDistanceFromYearLow() float64 {
+	if x != nil {
+		return x.DistanceFromYearLow
+	}
+	return 0
+}
+
+type StrategyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Ticker          string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	CompanyInfo     *CompanyInfo           `protobuf:"bytes,2,opt,name=company_info,json=companyInfo,proto3" json:"company_info,omitempty"`
+	QualitativeInfo []string               `protobuf:"bytes,3,rep,name=qualitative_info,json=qualitativeInfo,proto3" json:"qualitative_info,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StrategyRequest) Reset() {
+	*x = StrategyRequest{}
+	mi := &file_proto_analysis_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StrategyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StrategyRequest) ProtoMessage() {}
+
+func (x *StrategyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analysis_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StrategyRequest.ProtoReflect.Descriptor instead.
+func (*StrategyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StrategyRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *StrategyRequest) GetCompanyInfo() *CompanyInfo {
+	if x != nil {
+		return x.CompanyInfo
+	}
+	return nil
+}
+
+func (x *StrategyRequest) GetQualitativeInfo() []string {
+	if x != nil {
+		return x.QualitativeInfo
+	}
+	return nil
+}
+
+type StrategyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BusinessModel string                 `protobuf:"bytes,1,opt,name=business_model,json=businessModel,proto3" json:"business_model,omitempty"`
+	Kpi           string                 `protobuf:"bytes,2,opt,name=kpi,proto3" json:"kpi,omitempty"`
+	ThoughtLog    string                 `protobuf:"bytes,3,opt,name=thought_log,json=thoughtLog,proto3" json:"thought_log,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StrategyResponse) Reset() {
+	*x = StrategyResponse{}
+	mi := &file_proto_analysis_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StrategyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StrategyResponse) ProtoMessage() {}
+
+func (x *StrategyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analysis_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StrategyResponse.ProtoReflect.Descriptor instead.
+func (*StrategyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StrategyResponse) GetBusinessModel() string {
+	if x != nil {
+		return x.BusinessModel
+	}
+	return ""
+}
+
+func (x *StrategyResponse) GetKpi() string {
+	if x != nil {
+		return x.Kpi
+	}
+	return ""
+}
+
+func (x *StrategyResponse) GetThoughtLog() string {
+	if x != nil {
+		return x.ThoughtLog
+	}
+	return ""
+}
+
+type ExecutionRequest struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Ticker                  string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	CompanyInfo             *CompanyInfo           `protobuf:"bytes,2,opt,name=company_info,json=companyInfo,proto3" json:"company_info,omitempty"`
+	BusinessModel           string                 `protobuf:"bytes,3,opt,name=business_model,json=businessModel,proto3" json:"business_model,omitempty"`
+	Kpi                     string                 `protobuf:"bytes,4,opt,name=kpi,proto3" json:"kpi,omitempty"`
+	FinancialStatementsJson string                 `protobuf:"bytes,5,opt,name=financial_statements_json,json=financialStatementsJson,proto3" json:"financial_statements_json,omitempty"`
+	StockPrices             []*StockPrice          `protobuf:"bytes,6,rep,name=stock_prices,json=stockPrices,proto3" json:"stock_prices,omitempty"`
+	QualitativeInfo         []string               `protobuf:"bytes,7,rep,name=qualitative_info,json=qualitativeInfo,proto3" json:"qualitative_info,omitempty"`
+	TechnicalIndicators     *TechnicalIndicators   `protobuf:"bytes,8,opt,name=technical_indicators,json=technicalIndicators,proto3" json:"technical_indicators,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ExecutionRequest) Reset() {
+	*x = ExecutionRequest{}
+	mi := &file_proto_analysis_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionRequest) ProtoMessage() {}
+
+func (x *ExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analysis_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionRequest.ProtoReflect.Descriptor instead.
+func (*ExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExecutionRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *ExecutionRequest) GetCompanyInfo() *CompanyInfo {
+	if x != nil {
+		return x.CompanyInfo
+	}
+	return nil
+}
+
+func (x *ExecutionRequest) GetBusinessModel() string {
+	if x != nil {
+		return x.BusinessModel
+	}
+	return ""
+}
+
+func (x *ExecutionRequest) GetKpi() string {
+	if x != nil {
+		return x.Kpi
+	}
+	return ""
+}
+
+func (x *ExecutionRequest) GetFinancialStatementsJson() string {
+	if x != nil {
+		return x.FinancialStatementsJson
+	}
+	return ""
+}
+
+func (x *ExecutionRequest) GetStockPrices() []*StockPrice {
+	if x != nil {
+		return x.StockPrices
+	}
+	return nil
+}
+
+func (x *ExecutionRequest) GetQualitativeInfo() []string {
+	if x != nil {
+		return x.QualitativeInfo
+	}
+	return nil
+}
+
+func (x *ExecutionRequest) GetTechnicalIndicators() *TechnicalIndicators {
+	if x != nil {
+		return x.TechnicalIndicators
+	}
+	return nil
+}
+
+type ExecutionResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Sentiment        Sentiment              `protobuf:"varint,1,opt,name=sentiment,proto3,enum=stockanalysis.v1.Sentiment" json:"sentiment,omitempty"`
+	SummaryReasoning string                 `protobuf:"bytes,2,opt,name=summary_reasoning,json=summaryReasoning,proto3" json:"summary_reasoning,omitempty"`
+	StockSummary     string                 `protobuf:"bytes,3,opt,name=stock_summary,json=stockSummary,proto3" json:"stock_summary,omitempty"`
+	FinancialSummary string                 `protobuf:"bytes,4,opt,name=financial_summary,json=financialSummary,proto3" json:"financial_summary,omitempty"`
+	ThoughtLog       string                 `protobuf:"bytes,5,opt,name=thought_log,json=thoughtLog,proto3" json:"thought_log,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExecutionResponse) Reset() {
+	*x = ExecutionResponse{}
+	mi := &file_proto_analysis_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionResponse) ProtoMessage() {}
+
+func (x *ExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analysis_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionResponse.ProtoReflect.Descriptor instead.
+func (*ExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExecutionResponse) GetSentiment() Sentiment {
+	if x != nil {
+		return x.Sentiment
+	}
+	return Sentiment_SENTIMENT_UNSPECIFIED
+}
+
+func (x *ExecutionResponse) GetSummaryReasoning() string {
+	if x != nil {
+		return x.SummaryReasoning
+	}
+	return ""
+}
+
+func (x *ExecutionResponse) GetStockSummary() string {
+	if x != nil {
+		return x.StockSummary
+	}
+	return ""
+}
+
+func (x *ExecutionResponse) GetFinancialSummary() string {
+	if x != nil {
+		return x.FinancialSummary
+	}
+	return ""
+}
+
+func (x *ExecutionResponse) GetThoughtLog() string {
+	if x != nil {
+		return x.ThoughtLog
+	}
+	return ""
+}
+
+type StockAnalysisSummary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Ticker           string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	CompanyName      string                 `protobuf:"bytes,2,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty"`
+	Sentiment        Sentiment              `protobuf:"varint,3,opt,name=sentiment,proto3,enum=stockanalysis.v1.Sentiment" json:"sentiment,omitempty"`
+	SummaryReasoning string                 `protobuf:"bytes,4,opt,name=summary_reasoning,json=summaryReasoning,proto3" json:"summary_reasoning,omitempty"`
+	StockSummary     string                 `protobuf:"bytes,5,opt,name=stock_summary,json=stockSummary,proto3" json:"stock_summary,omitempty"`
+	FinancialSummary string                 `protobuf:"bytes,6,opt,name=financial_summary,json=financialSummary,proto3" json:"financial_summary,omitempty"`
+	BusinessModel    string                 `protobuf:"bytes,7,opt,name=business_model,json=businessModel,proto3" json:"business_model,omitempty"`
+	Kpi              string                 `protobuf:"bytes,8,opt,name=kpi,proto3" json:"kpi,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StockAnalysisSummary) Reset() {
+	*x = StockAnalysisSummary{}
+	mi := &file_proto_analysis_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockAnalysisSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockAnalysisSummary) ProtoMessage() {}
+
+func (x *StockAnalysisSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analysis_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockAnalysisSummary.ProtoReflect.Descriptor instead.
+func (*StockAnalysisSummary) Descriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StockAnalysisSummary) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *StockAnalysisSummary) GetCompanyName() string {
+	if x != nil {
+		return x.CompanyName
+	}
+	return ""
+}
+
+func (x *StockAnalysisSummary) GetSentiment() Sentiment {
+	if x != nil {
+		return x.Sentiment
+	}
+	return Sentiment_SENTIMENT_UNSPECIFIED
+}
+
+func (x *StockAnalysisSummary) GetSummaryReasoning() string {
+	if x != nil {
+		return x.SummaryReasoning
+	}
+	return ""
+}
+
+func (x *StockAnalysisSummary) GetStockSummary() string {
+	if x != nil {
+		return x.StockSummary
+	}
+	return ""
+}
+
+func (x *StockAnalysisSummary) GetFinancialSummary() string {
+	if x != nil {
+		return x.FinancialSummary
+	}
+	return ""
+}
+
+func (x *StockAnalysisSummary) GetBusinessModel() string {
+	if x != nil {
+		return x.BusinessModel
+	}
+	return ""
+}
+
+func (x *StockAnalysisSummary) GetKpi() string {
+	if x != nil {
+		return x.Kpi
+	}
+	return ""
+}
+
+type SectorComparisonRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	SectorCode    string                  `protobuf:"bytes,1,opt,name=sector_code,json=sectorCode,proto3" json:"sector_code,omitempty"`
+	SectorName    string                  `protobuf:"bytes,2,opt,name=sector_name,json=sectorName,proto3" json:"sector_name,omitempty"`
+	AnalysisDate  string                  `protobuf:"bytes,3,opt,name=analysis_date,json=analysisDate,proto3" json:"analysis_date,omitempty"`
+	Analyses      []*StockAnalysisSummary `protobuf:"bytes,4,rep,name=analyses,proto3" json:"analyses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SectorComparisonRequest) Reset() {
+	*x = SectorComparisonRequest{}
+	mi := &file_proto_analysis_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SectorComparisonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectorComparisonRequest) ProtoMessage() {}
+
+func (x *SectorComparisonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analysis_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectorComparisonRequest.ProtoReflect.Descriptor instead.
+func (*SectorComparisonRequest) Descriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SectorComparisonRequest) GetSectorCode() string {
+	if x != nil {
+		return x.SectorCode
+	}
+	return ""
+}
+
+func (x *SectorComparisonRequest) GetSectorName() string {
+	if x != nil {
+		return x.SectorName
+	}
+	return ""
+}
+
+func (x *SectorComparisonRequest) GetAnalysisDate() string {
+	if x != nil {
+		return x.AnalysisDate
+	}
+	return ""
+}
+
+func (x *SectorComparisonRequest) GetAnalyses() []*StockAnalysisSummary {
+	if x != nil {
+		return x.Analyses
+	}
+	return nil
+}
+
+type RankedPick struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Ticker        string                 `protobuf:"bytes,2,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Reasoning     string                 `protobuf:"bytes,3,opt,name=reasoning,proto3" json:"reasoning,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankedPick) Reset() {
+	*x = RankedPick{}
+	mi := &file_proto_analysis_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankedPick) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankedPick) ProtoMessage() {}
+
+func (x *RankedPick) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analysis_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankedPick.ProtoReflect.Descriptor instead.
+func (*RankedPick) Descriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RankedPick) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *RankedPick) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *RankedPick) GetReasoning() string {
+	if x != nil {
+		return x.Reasoning
+	}
+	return ""
+}
+
+type SectorComparisonResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TopPicks       []*RankedPick          `protobuf:"bytes,1,rep,name=top_picks,json=topPicks,proto3" json:"top_picks,omitempty"`
+	OverallSummary string                 `protobuf:"bytes,2,opt,name=overall_summary,json=overallSummary,proto3" json:"overall_summary,omitempty"`
+	ComparisonLog  string                 `protobuf:"bytes,3,opt,name=comparison_log,json=comparisonLog,proto3" json:"comparison_log,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SectorComparisonResponse) Reset() {
+	*x = SectorComparisonResponse{}
+	mi := &file_proto_analysis_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SectorComparisonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectorComparisonResponse) ProtoMessage() {}
+
+func (x *SectorComparisonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analysis_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectorComparisonResponse.ProtoReflect.Descriptor instead.
+func (*SectorComparisonResponse) Descriptor() ([]byte, []int) {
+	return file_proto_analysis_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SectorComparisonResponse) GetTopPicks() []*RankedPick {
+	if x != nil {
+		return x.TopPicks
+	}
+	return nil
+}
+
+func (x *SectorComparisonResponse) GetOverallSummary() string {
+	if x != nil {
+		return x.OverallSummary
+	}
+	return ""
+}
+
+func (x *SectorComparisonResponse) GetComparisonLog() string {
+	if x != nil {
+		return x.ComparisonLog
+	}
+	return ""
+}
+
+var File_proto_analysis_proto protoreflect.FileDescriptor
+
+const file_proto_analysis_proto_rawDesc = "" +
+	"\n" +
+	"\x14proto/analysis.proto\x12\x10stockanalysis.v1\"\x8e\x03\n" +
+	"\vCompanyInfo\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12!\n" +
+	"\fcompany_name\x18\x02 \x01(\tR\vcompanyName\x120\n" +
+	"\x14company_name_english\x18\x03 \x01(\tR\x12companyNameEnglish\x12#\n" +
+	"\rsector17_code\x18\x04 \x01(\tR\fsector17Code\x12,\n" +
+	"\x12sector17_code_name\x18\x05 \x01(\tR\x10sector17CodeName\x12#\n" +
+	"\rsector33_code\x18\x06 \x01(\tR\fsector33Code\x12,\n" +
+	"\x12sector33_code_name\x18\a \x01(\tR\x10sector33CodeName\x12%\n" +
+	"\x0escale_category\x18\b \x01(\tR\rscaleCategory\x12\x1f\n" +
+	"\vmarket_code\x18\t \x01(\tR\n" +
+	"marketCode\x12(\n" +
+	"\x10market_code_name\x18\n" +
+	" \x01(\tR\x0emarketCodeName\"\xad\x03\n" +
+	"\n" +
+	"StockPrice\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\x01R\x04open\x12\x12\n" +
+	"\x04high\x18\x03 \x01(\x01R\x04high\x12\x10\n" +
+	"\x03low\x18\x04 \x01(\x01R\x03low\x12\x14\n" +
+	"\x05close\x18\x05 \x01(\x01R\x05close\x12\x16\n" +
+	"\x06volume\x18\x06 \x01(\x01R\x06volume\x12%\n" +
+	"\x0eturnover_value\x18\a \x01(\x01R\rturnoverValue\x12+\n" +
+	"\x11adjustment_factor\x18\b \x01(\x01R\x10adjustmentFactor\x12'\n" +
+	"\x0fadjustment_open\x18\t \x01(\x01R\x0eadjustmentOpen\x12'\n" +
+	"\x0fadjustment_high\x18\n" +
+	" \x01(\x01R\x0eadjustmentHigh\x12%\n" +
+	"\x0eadjustment_low\x18\v \x01(\x01R\radjustmentLow\x12)\n" +
+	"\x10adjustment_close\x18\f \x01(\x01R\x0fadjustmentClose\x12+\n" +
+	"\x11adjustment_volume\x18\r \x01(\x01R\x10adjustmentVolume\"\xaa\b\n" +
+	"\x13TechnicalIndicators\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x14\n" +
+	"\x05close\x18\x02 \x01(\x01R\x05close\x12\x12\n" +
+	"\x04bars\x18\x03 \x01(\x05R\x04bars\x12\x19\n" +
+	"\x05sma20\x18\x04 \x01(\x01H\x00R\x05sma20\x88\x01\x01\x12\x19\n" +
+	"\x05sma50\x18\x05 \x01(\x01H\x01R\x05sma50\x88\x01\x01\x12\x1b\n" +
+	"\x06sma200\x18\x06 \x01(\x01H\x02R\x06sma200\x88\x01\x01\x12\x19\n" +
+	"\x05ema12\x18\a \x01(\x01H\x03R\x05ema12\x88\x01\x01\x12\x19\n" +
+	"\x05ema26\x18\b \x01(\x01H\x04R\x05ema26\x88\x01\x01\x12\x19\n" +
+	"\x05rsi14\x18\t \x01(\x01H\x05R\x05rsi14\x88\x01\x01\x12\x17\n" +
+	"\x04macd\x18\n" +
+	" \x01(\x01H\x06R\x04macd\x88\x01\x01\x12$\n" +
+	"\vmacd_signal\x18\v \x01(\x01H\aR\n" +
+	"macdSignal\x88\x01\x01\x12*\n" +
+	"\x0emacd_histogram\x18\f \x01(\x01H\bR\rmacdHistogram\x88\x01\x01\x12,\n" +
+	"\x0fbollinger_upper\x18\r \x01(\x01H\tR\x0ebollingerUpper\x88\x01\x01\x12.\n" +
+	"\x10bollinger_middle\x18\x0e \x01(\x01H\n" +
+	"R\x0fbollingerMiddle\x88\x01\x01\x12,\n" +
+	"\x0fbollinger_lower\x18\x0f \x01(\x01H\vR\x0ebollingerLower\x88\x01\x01\x123\n" +
+	"\x13bollinger_percent_b\x18\x10 \x01(\x01H\fR\x11bollingerPercentB\x88\x01\x01\x12\x19\n" +
+	"\x05atr14\x18\x11 \x01(\x01H\rR\x05atr14\x88\x01\x01\x12(\n" +
+	"\ratr14_percent\x18\x12 \x01(\x01H\x0eR\fatr14Percent\x88\x01\x01\x12-\n" +
+	"\x10volume_z_score20\x18\x13 \x01(\x01H\x0fR\x0evolumeZScore20\x88\x01\x01\x12\x1b\n" +
+	"\tyear_high\x18\x14 \x01(\x01R\byearHigh\x12\x19\n" +
+	"\byear_low\x18\x15 \x01(\x01R\ayearLow\x125\n" +
+	"\x17distance_from_year_high\x18\x16 \x01(\x01R\x14distanceFromYearHigh\x123\n" +
+	"\x16distance_from_year_low\x18\x17 \x01(\x01R\x13distanceFromYearLowB\b\n" +
+	"\x06_sma20B\b\n" +
+	"\x06_sma50B\t\n" +
+	"\a_sma200B\b\n" +
+	"\x06_ema12B\b\n" +
+	"\x06_ema26B\b\n" +
+	"\x06_rsi14B\a\n" +
+	"\x05_macdB\x0e\n" +
+	"\f_macd_signalB\x11\n" +
+	"\x0f_macd_histogramB\x12\n" +
+	"\x10_bollinger_upperB\x13\n" +
+	"\x11_bollinger_middleB\x12\n" +
+	"\x10_bollinger_lowerB\x16\n" +
+	"\x14_bollinger_percent_bB\b\n" +
+	"\x06_atr14B\x10\n" +
+	"\x0e_atr14_percentB\x13\n" +
+	"\x11_volume_z_score20\"\x96\x01\n" +
+	"\x0fStrategyRequest\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\x12@\n" +
+	"\fcompany_info\x18\x02 \x01(\v2\x1d.stockanalysis.v1.CompanyInfoR\vcompanyInfo\x12)\n" +
+	"\x10qualitative_info\x18\x03 \x03(\tR\x0fqualitativeInfo\"l\n" +
+	"\x10StrategyResponse\x12%\n" +
+	"\x0ebusiness_model\x18\x01 \x01(\tR\rbusinessModel\x12\x10\n" +
+	"\x03kpi\x18\x02 \x01(\tR\x03kpi\x12\x1f\n" +
+	"\vthought_log\x18\x03 \x01(\tR\n" +
+	"thoughtLog\"\xa7\x03\n" +
+	"\x10ExecutionRequest\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\x12@\n" +
+	"\fcompany_info\x18\x02 \x01(\v2\x1d.stockanalysis.v1.CompanyInfoR\vcompanyInfo\x12%\n" +
+	"\x0ebusiness_model\x18\x03 \x01(\tR\rbusinessModel\x12\x10\n" +
+	"\x03kpi\x18\x04 \x01(\tR\x03kpi\x12:\n" +
+	"\x19financial_statements_json\x18\x05 \x01(\tR\x17financialStatementsJson\x12?\n" +
+	"\fstock_prices\x18\x06 \x03(\v2\x1c.stockanalysis.v1.StockPriceR\vstockPrices\x12)\n" +
+	"\x10qualitative_info\x18\a \x03(\tR\x0fqualitativeInfo\x12X\n" +
+	"\x14technical_indicators\x18\b \x01(\v2%.stockanalysis.v1.TechnicalIndicatorsR\x13technicalIndicators\"\xee\x01\n" +
+	"\x11ExecutionResponse\x129\n" +
+	"\tsentiment\x18\x01 \x01(\x0e2\x1b.stockanalysis.v1.SentimentR\tsentiment\x12+\n" +
+	"\x11summary_reasoning\x18\x02 \x01(\tR\x10summaryReasoning\x12#\n" +
+	"\rstock_summary\x18\x03 \x01(\tR\fstockSummary\x12+\n" +
+	"\x11financial_summary\x18\x04 \x01(\tR\x10financialSummary\x12\x1f\n" +
+	"\vthought_log\x18\x05 \x01(\tR\n" +
+	"thoughtLog\"\xc4\x02\n" +
+	"\x14StockAnalysisSummary\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\x12!\n" +
+	"\fcompany_name\x18\x02 \x01(\tR\vcompanyName\x129\n" +
+	"\tsentiment\x18\x03 \x01(\x0e2\x1b.stockanalysis.v1.SentimentR\tsentiment\x12+\n" +
+	"\x11summary_reasoning\x18\x04 \x01(\tR\x10summaryReasoning\x12#\n" +
+	"\rstock_summary\x18\x05 \x01(\tR\fstockSummary\x12+\n" +
+	"\x11financial_summary\x18\x06 \x01(\tR\x10financialSummary\x12%\n" +
+	"\x0ebusiness_model\x18\a \x01(\tR\rbusinessModel\x12\x10\n" +
+	"\x03kpi\x18\b \x01(\tR\x03kpi\"\xc4\x01\n" +
+	"\x17SectorComparisonRequest\x12\x1f\n" +
+	"\vsector_code\x18\x01 \x01(\tR\n" +
+	"sectorCode\x12\x1f\n" +
+	"\vsector_name\x18\x02 \x01(\tR\n" +
+	"sectorName\x12#\n" +
+	"\ranalysis_date\x18\x03 \x01(\tR\fanalysisDate\x12B\n" +
+	"\banalyses\x18\x04 \x03(\v2&.stockanalysis.v1.StockAnalysisSummaryR\banalyses\"V\n" +
+	"\n" +
+	"RankedPick\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x16\n" +
+	"\x06ticker\x18\x02 \x01(\tR\x06ticker\x12\x1c\n" +
+	"\treasoning\x18\x03 \x01(\tR\treasoning\"\xa5\x01\n" +
+	"\x18SectorComparisonResponse\x129\n" +
+	"\ttop_picks\x18\x01 \x03(\v2\x1c.stockanalysis.v1.RankedPickR\btopPicks\x12'\n" +
+	"\x0foverall_summary\x18\x02 \x01(\tR\x0eoverallSummary\x12%\n" +
+	"\x0ecomparison_log\x18\x03 \x01(\tR\rcomparisonLog*{\n" +
+	"\tSentiment\x12\x19\n" +
+	"\x15SENTIMENT_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14SENTIMENT_STRONG_BUY\x10\x01\x12\x11\n" +
+	"\rSENTIMENT_BUY\x10\x02\x12\x12\n" +
+	"\x0eSENTIMENT_HOLD\x10\x03\x12\x12\n" +
+	"\x0eSENTIMENT_SELL\x10\x042\xb5\x02\n" +
+	"\x14StockAnalysisService\x12X\n" +
+	"\x0fAnalyzeStrategy\x12!.stockanalysis.v1.StrategyRequest\x1a\".stockanalysis.v1.StrategyResponse\x12[\n" +
+	"\x10AnalyzeExecution\x12\".stockanalysis.v1.ExecutionRequest\x1a#.stockanalysis.v1.ExecutionResponse\x12f\n" +
+	"\rCompareSector\x12).stockanalysis.v1.SectorComparisonRequest\x1a*.stockanalysis.v1.SectorComparisonResponseB+Z)stock-prediction/backend/proto/analysispbb\x06proto3"
+
+var (
+	file_proto_analysis_proto_rawDescOnce sync.Once
+	file_proto_analysis_proto_rawDescData []byte
+)
+
+func file_proto_analysis_proto_rawDescGZIP() []byte {
+	file_proto_analysis_proto_rawDescOnce.Do(func() {
+		file_proto_analysis_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_analysis_proto_rawDesc), len(file_proto_analysis_proto_rawDesc)))
+	})
+	return file_proto_analysis_proto_rawDescData
+}
+
+var file_proto_analysis_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_analysis_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_analysis_proto_goTypes = []any{
+	(Sentiment)(0),                   // 0: stockanalysis.v1.Sentiment
+	(*CompanyInfo)(nil),              // 1: stockanalysis.v1.CompanyInfo
+	(*StockPrice)(nil),               // 2: stockanalysis.v1.StockPrice
+	(*TechnicalIndicators)(nil),      // 3: stockanalysis.v1.TechnicalIndicators
+	(*StrategyRequest)(nil),          // 4: stockanalysis.v1.StrategyRequest
+	(*StrategyResponse)(nil),         // 5: stockanalysis.v1.StrategyResponse
+	(*ExecutionRequest)(nil),         // 6: stockanalysis.v1.ExecutionRequest
+	(*ExecutionResponse)(nil),        // 7: stockanalysis.v1.ExecutionResponse
+	(*StockAnalysisSummary)(nil),     // 8: stockanalysis.v1.StockAnalysisSummary
+	(*SectorComparisonRequest)(nil),  // 9: stockanalysis.v1.SectorComparisonRequest
+	(*RankedPick)(nil),               // 10: stockanalysis.v1.RankedPick
+	(*SectorComparisonResponse)(nil), // 11: stockanalysis.v1.SectorComparisonResponse
+}
+var file_proto_analysis_proto_depIdxs = []int32{
+	1,  // 0: stockanalysis.v1.StrategyRequest.company_info:type_name -> stockanalysis.v1.CompanyInfo
+	1,  // 1: stockanalysis.v1.ExecutionRequest.company_info:type_name -> stockanalysis.v1.CompanyInfo
+	2,  // 2: stockanalysis.v1.ExecutionRequest.stock_prices:type_name -> stockanalysis.v1.StockPrice
+	3,  // 3: stockanalysis.v1.ExecutionRequest.technical_indicators:type_name -> stockanalysis.v1.TechnicalIndicators
+	0,  // 4: stockanalysis.v1.ExecutionResponse.sentiment:type_name -> stockanalysis.v1.Sentiment
+	0,  // 5: stockanalysis.v1.StockAnalysisSummary.sentiment:type_name -> stockanalysis.v1.Sentiment
+	8,  // 6: stockanalysis.v1.SectorComparisonRequest.analyses:type_name -> stockanalysis.v1.StockAnalysisSummary
+	10, // 7: stockanalysis.v1.SectorComparisonResponse.top_picks:type_name -> stockanalysis.v1.RankedPick
+	4,  // 8: stockanalysis.v1.StockAnalysisService.AnalyzeStrategy:input_type -> stockanalysis.v1.StrategyRequest
+	6,  // 9: stockanalysis.v1.StockAnalysisService.AnalyzeExecution:input_type -> stockanalysis.v1.ExecutionRequest
+	9,  // 10: stockanalysis.v1.StockAnalysisService.CompareSector:input_type -> stockanalysis.v1.SectorComparisonRequest
+	5,  // 11: stockanalysis.v1.StockAnalysisService.AnalyzeStrategy:output_type -> stockanalysis.v1.StrategyResponse
+	7,  // 12: stockanalysis.v1.StockAnalysisService.AnalyzeExecution:output_type -> stockanalysis.v1.ExecutionResponse
+	11, // 13: stockanalysis.v1.StockAnalysisService.CompareSector:output_type -> stockanalysis.v1.SectorComparisonResponse
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_proto_analysis_proto_init() }
+func file_proto_analysis_proto_init() {
+	if File_proto_analysis_proto != nil {
+		return
+	}
+	file_proto_analysis_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_analysis_proto_rawDesc), len(file_proto_analysis_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_analysis_proto_goTypes,
+		DependencyIndexes: file_proto_analysis_proto_depIdxs,
+		EnumInfos:         file_proto_analysis_proto_enumTypes,
+		MessageInfos:      file_proto_analysis_proto_msgTypes,
+	}.Build()
+	File_proto_analysis_proto = out.File
+	file_proto_analysis_proto_goTypes = nil
+	file_proto_analysis_proto_depIdxs = nil
+}
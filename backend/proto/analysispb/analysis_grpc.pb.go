@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: proto/analysis.proto
+
+package analysispb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StockAnalysisService_AnalyzeStrategy_FullMethodName  = "/stockanalysis.v1.StockAnalysisService/AnalyzeStrategy"
+	StockAnalysisService_AnalyzeExecution_FullMethodName = "/stockanalysis.v1.StockAnalysisService/AnalyzeExecution"
+	StockAnalysisService_CompareSector_FullMethodName    = "/stockanalysis.v1.StockAnalysisService/CompareSector"
+)
+
+// StockAnalysisServiceClient is the client API for StockAnalysisService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StockAnalysisServiceClient interface {
+	AnalyzeStrategy(ctx context.Context, in *StrategyRequest, opts ...grpc.CallOption) (*StrategyResponse, error)
+	AnalyzeExecution(ctx context.Context, in *ExecutionRequest, opts ...grpc.CallOption) (*ExecutionResponse, error)
+	CompareSector(ctx context.Context, in *SectorComparisonRequest, opts ...grpc.CallOption) (*SectorComparisonResponse, error)
+}
+
+type stockAnalysisServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStockAnalysisServiceClient(cc grpc.ClientConnInterface) StockAnalysisServiceClient {
+	return &stockAnalysisServiceClient{cc}
+}
+
+func (c *stockAnalysisServiceClient) AnalyzeStrategy(ctx context.Context, in *StrategyRequest, opts ...grpc.CallOption) (*StrategyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StrategyResponse)
+	err := c.cc.Invoke(ctx, StockAnalysisService_AnalyzeStrategy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockAnalysisServiceClient) AnalyzeExecution(ctx context.Context, in *ExecutionRequest, opts ...grpc.CallOption) (*ExecutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecutionResponse)
+	err := c.cc.Invoke(ctx, StockAnalysisService_AnalyzeExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockAnalysisServiceClient) CompareSector(ctx context.Context, in *SectorComparisonRequest, opts ...grpc.CallOption) (*SectorComparisonResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SectorComparisonResponse)
+	err := c.cc.Invoke(ctx, StockAnalysisService_CompareSector_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StockAnalysisServiceServer is the server API for StockAnalysisService service.
+// All implementations must embed UnimplementedStockAnalysisServiceServer
+// for forward compatibility.
+type StockAnalysisServiceServer interface {
+	AnalyzeStrategy(context.Context, *StrategyRequest) (*StrategyResponse, error)
+	AnalyzeExecution(context.Context, *ExecutionRequest) (*ExecutionResponse, error)
+	CompareSector(context.Context, *SectorComparisonRequest) (*SectorComparisonResponse, error)
+	mustEmbedUnimplementedStockAnalysisServiceServer()
+}
+
+// UnimplementedStockAnalysisServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStockAnalysisServiceServer struct{}
+
+func (UnimplementedStockAnalysisServiceServer) AnalyzeStrategy(context.Context, *StrategyRequest) (*StrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeStrategy not implemented")
+}
+func (UnimplementedStockAnalysisServiceServer) AnalyzeExecution(context.Context, *ExecutionRequest) (*ExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeExecution not implemented")
+}
+func (UnimplementedStockAnalysisServiceServer) CompareSector(context.Context, *SectorComparisonRequest) (*SectorComparisonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareSector not implemented")
+}
+func (UnimplementedStockAnalysisServiceServer) mustEmbedUnimplementedStockAnalysisServiceServer() {}
+func (UnimplementedStockAnalysisServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeStockAnalysisServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StockAnalysisServiceServer will
+// result in compilation errors.
+type UnsafeStockAnalysisServiceServer interface {
+	mustEmbedUnimplementedStockAnalysisServiceServer()
+}
+
+func RegisterStockAnalysisServiceServer(s grpc.ServiceRegistrar, srv StockAnalysisServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStockAnalysisServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StockAnalysisService_ServiceDesc, srv)
+}
+
+func _StockAnalysisService_AnalyzeStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockAnalysisServiceServer).AnalyzeStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockAnalysisService_AnalyzeStrategy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockAnalysisServiceServer).AnalyzeStrategy(ctx, req.(*StrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockAnalysisService_AnalyzeExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockAnalysisServiceServer).AnalyzeExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockAnalysisService_AnalyzeExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockAnalysisServiceServer).AnalyzeExecution(ctx, req.(*ExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockAnalysisService_CompareSector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SectorComparisonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockAnalysisServiceServer).CompareSector(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockAnalysisService_CompareSector_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockAnalysisServiceServer).CompareSector(ctx, req.(*SectorComparisonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StockAnalysisService_ServiceDesc is the grpc.ServiceDesc for StockAnalysisService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StockAnalysisService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stockanalysis.v1.StockAnalysisService",
+	HandlerType: (*StockAnalysisServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AnalyzeStrategy",
+			Handler:    _StockAnalysisService_AnalyzeStrategy_Handler,
+		},
+		{
+			MethodName: "AnalyzeExecution",
+			Handler:    _StockAnalysisService_AnalyzeExecution_Handler,
+		},
+		{
+			MethodName: "CompareSector",
+			Handler:    _StockAnalysisService_CompareSector_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/analysis.proto",
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"stock-prediction/backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IAuthRepository interface {
+	CreateAPIKey(key *models.APIKey) error
+	FindAPIKeys() (*[]models.APIKey, error)
+	FindAPIKeyByID(id uint) (*models.APIKey, error)
+	FindAPIKeyByHash(keyHash string) (*models.APIKey, error)
+	UpdateAPIKey(key *models.APIKey) error
+	TouchAPIKey(id uint, usedAt time.Time) error
+	CreateAuditLog(log *models.AuditLog) error
+	FindAuditLogs(actor string, limit int) (*[]models.AuditLog, error)
+}
+
+type authrepository struct {
+	db *gorm.DB
+}
+
+func NewAuthRepository(db *gorm.DB) IAuthRepository {
+	return &authrepository{db: db}
+}
+
+func (r *authrepository) CreateAPIKey(key *models.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *authrepository) FindAPIKeys() (*[]models.APIKey, error) {
+	var keys []models.APIKey
+	if err := r.db.Order("id ASC").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return &keys, nil
+}
+
+func (r *authrepository) FindAPIKeyByID(id uint) (*models.APIKey, error) {
+	var key models.APIKey
+	result := r.db.First(&key, id)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, notFound("api key")
+		}
+		return nil, result.Error
+	}
+
+	return &key, nil
+}
+
+func (r *authrepository) FindAPIKeyByHash(keyHash string) (*models.APIKey, error) {
+	var key models.APIKey
+	result := r.db.Where("key_hash = ?", keyHash).First(&key)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, notFound("api key")
+		}
+		return nil, result.Error
+	}
+
+	return &key, nil
+}
+
+func (r *authrepository) UpdateAPIKey(key *models.APIKey) error {
+	return r.db.Save(key).Error
+}
+
+// TouchAPIKey 最終利用日時だけを更新する（他のカラムを上書きしないように）
+func (r *authrepository) TouchAPIKey(id uint, usedAt time.Time) error {
+	return r.db.Model(&models.APIKey{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
+
+func (r *authrepository) CreateAuditLog(log *models.AuditLog) error {
+	return r.db.Create(log).Error
+}
+
+// FindAuditLogs 監査ログを新しい順に取得する（actorが空の場合は全員分）
+func (r *authrepository) FindAuditLogs(actor string, limit int) (*[]models.AuditLog, error) {
+	var logs []models.AuditLog
+
+	query := r.db.Order("id DESC").Limit(limit)
+	if actor != "" {
+		query = query.Where("actor = ?", actor)
+	}
+
+	if err := query.Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return &logs, nil
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"stock-prediction/backend/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IBackgroundJobRepository interface {
+	CreateJob(job *models.BackgroundJob) error
+	ReleaseJob(job *models.BackgroundJob, lockedBy string) (bool, error)
+	HeartbeatJob(id uint, lockedBy string, now time.Time) (bool, error)
+	FindJobByID(id uint) (*models.BackgroundJob, error)
+	FindJobs(status string, limit int) (*[]models.BackgroundJob, error)
+	ClaimNextJob(workerID string, now time.Time) (*models.BackgroundJob, error)
+	RequeueStaleJobs(lockedBefore time.Time, now time.Time) (int64, error)
+	UpsertJobStep(step *models.BackgroundJobStep) error
+}
+
+// claimJobLockKey ClaimNextJob のアドバイザリロックのキー（他の用途のロックと重ならない任意の値）
+const claimJobLockKey = 7_310_001
+
+type backgroundjobrepository struct {
+	db *gorm.DB
+}
+
+func NewBackgroundJobRepository(db *gorm.DB) IBackgroundJobRepository {
+	return &backgroundjobrepository{db: db}
+}
+
+func (r *backgroundjobrepository) CreateJob(job *models.BackgroundJob) error {
+	return r.db.Create(job).Error
+}
+
+// ReleaseJob 実行を終えたジョブの状態（成功・失敗・リトライ待ち）を保存してロックを解放する
+// lockedBy のワーカーがまだロックを持っている場合のみ更新し、ロックを失っていた場合
+// （停止とみなされて実行待ちに戻され、別のワーカーが実行しているなど）は何も更新せずに false を返す
+func (r *backgroundjobrepository) ReleaseJob(job *models.BackgroundJob, lockedBy string) (bool, error) {
+	// Stepsはワーカーが個別に保存するので、ここでは更新しない
+	result := r.db.Model(&models.BackgroundJob{}).
+		Where("id = ? AND status = ? AND locked_by = ?", job.ID, models.BackgroundJobStatusRunning, lockedBy).
+		Updates(map[string]interface{}{
+			"status":      job.Status,
+			"run_at":      job.RunAt,
+			"locked_by":   "",
+			"locked_at":   nil,
+			"finished_at": job.FinishedAt,
+			"last_error":  job.LastError,
+			"result":      job.Result,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// HeartbeatJob 実行中のジョブの locked_at を now に更新する（RequeueStaleJobs で実行待ちに戻されないようにする）
+// lockedBy のワーカーがロックを失っていた場合は false を返す
+func (r *backgroundjobrepository) HeartbeatJob(id uint, lockedBy string, now time.Time) (bool, error) {
+	result := r.db.Model(&models.BackgroundJob{}).
+		Where("id = ? AND status = ? AND locked_by = ?", id, models.BackgroundJobStatusRunning, lockedBy).
+		Update("locked_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindJobByID ジョブと銘柄ごとの進捗を取得する
+func (r *backgroundjobrepository) FindJobByID(id uint) (*models.BackgroundJob, error) {
+	var job models.BackgroundJob
+	result := r.db.Preload("Steps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&job, id)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, notFound("job")
+		}
+		return nil, result.Error
+	}
+
+	return &job, nil
+}
+
+// FindJobs ジョブを新しい順に取得する（statusが空の場合は全件。進捗は含まない）
+func (r *backgroundjobrepository) FindJobs(status string, limit int) (*[]models.BackgroundJob, error) {
+	var jobs []models.BackgroundJob
+
+	query := r.db.Order("id DESC").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return &jobs, nil
+}
+
+// ClaimNextJob 実行可能な最も古いジョブを1件取得して running にする（無ければ nil, nil）
+// FOR UPDATE SKIP LOCKED で、複数のワーカー（複数プロセスを含む）が同じジョブを取得しないようにする
+// 同じ種類のジョブが running の間はその種類のジョブを取得しない（定期実行と管理系APIの同期などを同時に実行しない）。
+// 取得はアドバイザリロックで直列化し、2つのワーカーが同じ種類の別々のジョブを同時に取得しないようにする
+func (r *backgroundjobrepository) ClaimNextJob(workerID string, now time.Time) (*models.BackgroundJob, error) {
+	var claimed *models.BackgroundJob
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", claimJobLockKey).Error; err != nil {
+			return err
+		}
+
+		var job models.BackgroundJob
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND run_at <= ?", models.BackgroundJobStatusQueued, now).
+			Where("NOT EXISTS (SELECT 1 FROM background_jobs AS running WHERE running.type = background_jobs.type AND running.status = ? AND running.deleted_at IS NULL)", models.BackgroundJobStatusRunning).
+			Order("run_at ASC, id ASC").
+			Limit(1).
+			Find(&job)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		job.Status = models.BackgroundJobStatusRunning
+		job.Attempts++
+		job.LockedBy = workerID
+		job.LockedAt = &now
+		if job.StartedAt == nil {
+			job.StartedAt = &now
+		}
+		if err := tx.Omit(clause.Associations).Save(&job).Error; err != nil {
+			return err
+		}
+
+		claimed = &job
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// RequeueStaleJobs lockedBefore 以降にハートビートが無い running のジョブ（ワーカーのプロセスが落ちたもの）を実行待ちに戻す
+func (r *backgroundjobrepository) RequeueStaleJobs(lockedBefore time.Time, now time.Time) (int64, error) {
+	result := r.db.Model(&models.BackgroundJob{}).
+		Where("status = ? AND locked_at < ?", models.BackgroundJobStatusRunning, lockedBefore).
+		Updates(map[string]interface{}{
+			"status":     models.BackgroundJobStatusQueued,
+			"run_at":     now,
+			"locked_by":  "",
+			"locked_at":  nil,
+			"last_error": "worker stopped while running the job",
+		})
+	return result.RowsAffected, result.Error
+}
+
+// UpsertJobStep 銘柄ごとの進捗を保存する（同じジョブ・銘柄は上書き）
+func (r *backgroundjobrepository) UpsertJobStep(step *models.BackgroundJobStep) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job_id"}, {Name: "item"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "message", "updated_at"}),
+	}).Create(step).Error
+}
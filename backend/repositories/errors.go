@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound 検索したレコードが存在しない（APIでは404にする）
+// メッセージは対象ごとに notFound でラップする（例: "stock not found"）
+var ErrNotFound = errors.New("not found")
+
+func notFound(target string) error {
+	return fmt.Errorf("%s %w", target, ErrNotFound)
+}
@@ -3,20 +3,41 @@ package repositories
 import (
 	"errors"
 	"stock-prediction/backend/models"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// 一括保存で1回のINSERTに含める行数（PostgreSQLのパラメータ数の上限 65535 に収まるようにする）
+const upsertBatchSize = 1000
+
 type IJapaneseStockRepository interface {
 	CreateOrUpdateCompany(company *models.Company) error
+	UpsertCompanies(companies []models.Company) error
+	FindCompaniesBySector(sector33 string, sector17 string) ([]models.Company, error)
+	FindCompanyByCode(code string) (*models.Company, error)
 	CreateOrUpdateDailyQuote(dailyQuote *models.DailyQuote) error
+	UpsertDailyQuotes(dailyQuotes []models.DailyQuote) error
+	FindDailyQuotesByDate(date string) ([]models.DailyQuote, error)
 	CreateOrUpdateFinancialStatement(financialStatement *models.FinancialStatement) error
 	CreateNewsSearchWithItems(newsSearch *models.NewsSearch, items []models.NewsItem) error
 	FindNewsByCode(code string) (*models.NewsSearch, error)
 	FindDailyQuotesByCode(code string, fromDate string, toDate string) ([]models.DailyQuote, error)
 	FindFinancialStatementsByCode(code string) ([]models.FinancialStatement, error)
+	UpsertFinancialMetrics(metrics []models.FinancialMetric) error
+	FindFinancialMetricsByCode(code string) ([]models.FinancialMetric, error)
+	UpsertJapaneseStockValuations(valuations []models.JapaneseStockValuation) error
+	FindJapaneseStockValuations(code string, fromDate string, toDate string) ([]models.JapaneseStockValuation, error)
+	ReplaceJapaneseDailyRankings(date string, rankings []models.JapaneseDailyRanking) error
+	FindLatestJapaneseRankingDate(category string, segment string) (string, error)
+	FindJapaneseDailyRanking(date string, category string, segment string, limit int) ([]models.JapaneseDailyRanking, error)
 	CreateOrUpdateAnalysisResult(analysisResult *models.AnalysisResult) error
 	CreateOrUpdateSectorAnalysisResult(sectorAnalysisResult *models.SectorAnalysisResult) error
+	FindCompletedAnalysisResultsBySector(sector33Code string, from time.Time, to time.Time) ([]models.AnalysisResult, error)
+	LinkAnalysisResultsToSector(sectorAnalysisResultID uint, analysisResultIDs []uint) error
+	FindAnalysisResultsByCode(code string) ([]models.AnalysisResult, error)
+	FindLatestSectorAnalysisResult(sectorCode string) (*models.SectorAnalysisResult, error)
 }
 
 type japanesestockrepository struct {
@@ -27,6 +48,7 @@ func NewJapaneseStockRepository(db *gorm.DB) IJapaneseStockRepository {
 	return &japanesestockrepository{db: db}
 }
 
+// CreateOrUpdateCompany 銘柄を1件保存する（SELECT → INSERT/UPDATE。まとめて保存する場合は UpsertCompanies を使う）
 func (r *japanesestockrepository) CreateOrUpdateCompany(company *models.Company) error {
 	var existingCompany models.Company
 	result := r.db.Where("code = ?", company.Code).First(&existingCompany)
@@ -41,6 +63,54 @@ func (r *japanesestockrepository) CreateOrUpdateCompany(company *models.Company)
 	return r.db.Model(&existingCompany).Updates(company).Error
 }
 
+// UpsertCompanies 銘柄マスタをまとめて保存する（同じ銘柄コードは上書き、削除済みの銘柄は復活させる）
+// 1行ずつ SELECT → INSERT/UPDATE する CreateOrUpdateCompany と違い、upsertBatchSize 件ごとに INSERT ... ON CONFLICT を1回発行する
+func (r *japanesestockrepository) UpsertCompanies(companies []models.Company) error {
+	companies = lastByKey(companies, func(company models.Company) string { return company.Code })
+	if len(companies) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "code"}},
+			UpdateAll: true,
+		}).CreateInBatches(companies, upsertBatchSize).Error
+	})
+}
+
+// FindCompaniesBySector 33業種・17業種（コードまたは名称）で銘柄を絞り込む
+// 空文字の条件は無視する（両方空の場合は全銘柄を返す）
+func (r *japanesestockrepository) FindCompaniesBySector(sector33 string, sector17 string) ([]models.Company, error) {
+	var companies []models.Company
+	query := r.db.Model(&models.Company{})
+
+	if sector33 != "" {
+		query = query.Where("sector33_code = ? OR sector33_code_name = ?", sector33, sector33)
+	}
+	if sector17 != "" {
+		query = query.Where("sector17_code = ? OR sector17_code_name = ?", sector17, sector17)
+	}
+
+	result := query.Order("code ASC").Find(&companies)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return companies, nil
+}
+
+func (r *japanesestockrepository) FindCompanyByCode(code string) (*models.Company, error) {
+	var company models.Company
+	result := r.db.Where("code = ?", code).First(&company)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, notFound("company")
+		}
+		return nil, result.Error
+	}
+	return &company, nil
+}
+
+// CreateOrUpdateDailyQuote 日足を1件保存する（SELECT → INSERT/UPDATE。まとめて保存する場合は UpsertDailyQuotes を使う）
 func (r *japanesestockrepository) CreateOrUpdateDailyQuote(dailyQuote *models.DailyQuote) error {
 	var existingDailyQuote models.DailyQuote
 	result := r.db.Where("code = ? AND date = ?", dailyQuote.Code, dailyQuote.Date).First(&existingDailyQuote)
@@ -98,7 +168,7 @@ func (r *japanesestockrepository) FindNewsByCode(code string) (*models.NewsSearc
 	result := r.db.Where("code = ?", code).Order("searched_at DESC").Preload("Items").First(&newsSearch)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("news search not found")
+			return nil, notFound("news search")
 		}
 		return nil, result.Error
 	}
@@ -123,6 +193,119 @@ func (r *japanesestockrepository) FindDailyQuotesByCode(code string, fromDate st
 	return dailyQuotes, nil
 }
 
+// UpsertDailyQuotes 日足をまとめて保存する（同じ銘柄・日付は上書き）
+// upsertBatchSize 件ごとに INSERT ... ON CONFLICT を発行し、途中で失敗した場合は全件ロールバックする
+func (r *japanesestockrepository) UpsertDailyQuotes(dailyQuotes []models.DailyQuote) error {
+	dailyQuotes = lastByKey(dailyQuotes, func(quote models.DailyQuote) string { return quote.Code + "/" + quote.Date })
+	if len(dailyQuotes) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "code"}, {Name: "date"}},
+			UpdateAll: true,
+		}).CreateInBatches(dailyQuotes, upsertBatchSize).Error
+	})
+}
+
+// FindDailyQuotesByDate 指定日の全銘柄の日足を銘柄コード順に返す
+func (r *japanesestockrepository) FindDailyQuotesByDate(date string) ([]models.DailyQuote, error) {
+	var dailyQuotes []models.DailyQuote
+	result := r.db.Where("date = ?", date).Order("code ASC").Find(&dailyQuotes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return dailyQuotes, nil
+}
+
+// UpsertFinancialMetrics 財務指標を保存する（同じ開示番号は上書き）
+func (r *japanesestockrepository) UpsertFinancialMetrics(metrics []models.FinancialMetric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "disclosure_number"}},
+		UpdateAll: true,
+	}).CreateInBatches(metrics, 500).Error
+}
+
+// FindFinancialMetricsByCode 銘柄の財務指標を開示日の新しい順に返す
+func (r *japanesestockrepository) FindFinancialMetricsByCode(code string) ([]models.FinancialMetric, error) {
+	var metrics []models.FinancialMetric
+	result := r.db.Where("code = ?", code).Order("disclosed_date DESC, disclosure_number DESC").Find(&metrics)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return metrics, nil
+}
+
+// UpsertJapaneseStockValuations バリュエーションを保存する（同じ銘柄・日付は上書き）
+func (r *japanesestockrepository) UpsertJapaneseStockValuations(valuations []models.JapaneseStockValuation) error {
+	if len(valuations) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}, {Name: "date"}},
+		UpdateAll: true,
+	}).CreateInBatches(valuations, 500).Error
+}
+
+// FindJapaneseStockValuations 銘柄の fromDate〜toDate（両端を含む）のバリュエーションを日付の古い順に返す
+func (r *japanesestockrepository) FindJapaneseStockValuations(code string, fromDate string, toDate string) ([]models.JapaneseStockValuation, error) {
+	var valuations []models.JapaneseStockValuation
+	result := r.db.
+		Where("code = ? AND date BETWEEN ? AND ?", code, fromDate, toDate).
+		Order("date ASC").
+		Find(&valuations)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return valuations, nil
+}
+
+// ReplaceJapaneseDailyRankings 指定日のランキングを全市場区分・全カテゴリまとめて入れ替える
+// 再計算で件数が減った場合に古い順位が残らないよう、削除と保存を1つのトランザクションで行う
+func (r *japanesestockrepository) ReplaceJapaneseDailyRankings(date string, rankings []models.JapaneseDailyRanking) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("date = ?", date).Delete(&models.JapaneseDailyRanking{}).Error; err != nil {
+			return err
+		}
+		if len(rankings) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(rankings, 500).Error
+	})
+}
+
+// FindLatestJapaneseRankingDate 指定カテゴリ・市場区分のランキングがある最新の日付（無い場合は空文字）
+func (r *japanesestockrepository) FindLatestJapaneseRankingDate(category string, segment string) (string, error) {
+	var latestDate *string
+	result := r.db.Model(&models.JapaneseDailyRanking{}).
+		Where("category = ? AND segment = ?", category, segment).
+		Select("MAX(date)").
+		Scan(&latestDate)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if latestDate == nil {
+		return "", nil
+	}
+	return *latestDate, nil
+}
+
+// FindJapaneseDailyRanking 指定日・カテゴリ・市場区分のランキングの1〜limit位を返す
+func (r *japanesestockrepository) FindJapaneseDailyRanking(date string, category string, segment string, limit int) ([]models.JapaneseDailyRanking, error) {
+	var rankings []models.JapaneseDailyRanking
+	result := r.db.
+		Where("date = ? AND category = ? AND segment = ? AND rank <= ?", date, category, segment, limit).
+		Order("rank ASC").
+		Find(&rankings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rankings, nil
+}
+
 func (r *japanesestockrepository) FindFinancialStatementsByCode(code string) ([]models.FinancialStatement, error) {
 	var financialStatements []models.FinancialStatement
 	result := r.db.Where("code = ?", code).Order("current_fiscal_year_end_date DESC").Find(&financialStatements)
@@ -164,3 +347,78 @@ func (r *japanesestockrepository) CreateOrUpdateSectorAnalysisResult(sectorAnaly
 	sectorAnalysisResult.ID = existingResult.ID
 	return r.db.Model(&existingResult).Updates(sectorAnalysisResult).Error
 }
+
+// FindCompletedAnalysisResultsBySector 33業種コードに属する銘柄の、Phase 2まで完了した分析結果を期間指定で取得する
+// AnalyzedAt が from 以上 to 未満のものを新しい順に返す
+func (r *japanesestockrepository) FindCompletedAnalysisResultsBySector(sector33Code string, from time.Time, to time.Time) ([]models.AnalysisResult, error) {
+	var analysisResults []models.AnalysisResult
+	result := r.db.
+		Joins("JOIN companies ON companies.code = analysis_results.code AND companies.deleted_at IS NULL").
+		Where("companies.sector33_code = ?", sector33Code).
+		Where("analysis_results.analyzed_at >= ? AND analysis_results.analyzed_at < ?", from, to).
+		Where("analysis_results.sentiment <> ''").
+		Order("analysis_results.analyzed_at DESC").
+		Find(&analysisResults)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return analysisResults, nil
+}
+
+// LinkAnalysisResultsToSector 比較対象となった分析結果にセクター分析結果を紐付ける
+func (r *japanesestockrepository) LinkAnalysisResultsToSector(sectorAnalysisResultID uint, analysisResultIDs []uint) error {
+	if len(analysisResultIDs) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.AnalysisResult{}).
+		Where("id IN ?", analysisResultIDs).
+		Update("sector_analysis_result_id", sectorAnalysisResultID).Error
+}
+
+// FindAnalysisResultsByCode 銘柄の分析結果を新しい順に取得する（使用したニュース検索バッチと個別記事も含む）
+func (r *japanesestockrepository) FindAnalysisResultsByCode(code string) ([]models.AnalysisResult, error) {
+	var analysisResults []models.AnalysisResult
+	result := r.db.Preload("NewsSearch.Items").
+		Where("code = ?", code).
+		Order("analyzed_at DESC").
+		Find(&analysisResults)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return analysisResults, nil
+}
+
+// FindLatestSectorAnalysisResult セクターの最新の比較分析結果を、紐付いた各銘柄の分析結果と合わせて取得する
+func (r *japanesestockrepository) FindLatestSectorAnalysisResult(sectorCode string) (*models.SectorAnalysisResult, error) {
+	var sectorAnalysisResult models.SectorAnalysisResult
+	result := r.db.Preload("AnalysisResults.NewsSearch.Items").
+		Where("sector_code = ?", sectorCode).
+		Order("analyzed_at DESC").
+		First(&sectorAnalysisResult)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, notFound("sector analysis result")
+		}
+		return nil, result.Error
+	}
+	return &sectorAnalysisResult, nil
+}
+
+// lastByKey 同じキーの行が複数ある場合は最後の行だけを残す（順序は保つ）
+// 1回の INSERT ... ON CONFLICT DO UPDATE の中で同じ行を2回更新するとエラーになるため
+func lastByKey[T any](rows []T, key func(T) string) []T {
+	last := make(map[string]int, len(rows))
+	for i, row := range rows {
+		last[key(row)] = i
+	}
+	if len(last) == len(rows) {
+		return rows
+	}
+	unique := make([]T, 0, len(last))
+	for i, row := range rows {
+		if last[key(row)] == i {
+			unique = append(unique, row)
+		}
+	}
+	return unique
+}
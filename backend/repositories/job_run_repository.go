@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"stock-prediction/backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IJobRunRepository interface {
+	ClaimJobRun(run *models.JobRun) (bool, error)
+	UpdateJobRun(run *models.JobRun) error
+	FindJobRuns(jobName string, limit int) (*[]models.JobRun, error)
+	HasSucceededJobRun(jobName string, tradingDate string) (bool, error)
+}
+
+type jobrunrepository struct {
+	db *gorm.DB
+}
+
+func NewJobRunRepository(db *gorm.DB) IJobRunRepository {
+	return &jobrunrepository{db: db}
+}
+
+// ClaimJobRun 実行履歴を作成して、その取引日のジョブの実行権を取得する
+// 同じジョブ名・取引日の実行履歴が既にある場合（別のプロセスが実行済み・実行中）は何も作成せずに false を返す
+func (r *jobrunrepository) ClaimJobRun(run *models.JobRun) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job_name"}, {Name: "trading_date"}},
+		DoNothing: true,
+	}).Create(run)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *jobrunrepository) UpdateJobRun(run *models.JobRun) error {
+	return r.db.Save(run).Error
+}
+
+// FindJobRuns 実行履歴を新しい順に取得する（jobNameが空の場合は全ジョブ）
+func (r *jobrunrepository) FindJobRuns(jobName string, limit int) (*[]models.JobRun, error) {
+	var runs []models.JobRun
+
+	query := r.db.Order("started_at DESC").Limit(limit)
+	if jobName != "" {
+		query = query.Where("job_name = ?", jobName)
+	}
+
+	if err := query.Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return &runs, nil
+}
+
+// HasSucceededJobRun 指定した取引日にジョブが成功しているか
+func (r *jobrunrepository) HasSucceededJobRun(jobName string, tradingDate string) (bool, error) {
+	var count int64
+	result := r.db.Model(&models.JobRun{}).
+		Where("job_name = ? AND trading_date = ? AND status = ?", jobName, tradingDate, models.JobRunStatusSucceeded).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,231 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// スクリーナーの対象市場
+const (
+	ScreenerMarketJP = "JP"
+	ScreenerMarketUS = "US"
+)
+
+// スクリーナーの演算子
+const (
+	ScreenerOpEq      = "eq"
+	ScreenerOpNe      = "ne"
+	ScreenerOpGt      = "gt"
+	ScreenerOpGte     = "gte"
+	ScreenerOpLt      = "lt"
+	ScreenerOpLte     = "lte"
+	ScreenerOpBetween = "between" // Values: [下限, 上限]（両端を含む）
+	ScreenerOpIn      = "in"      // Values: 候補の一覧
+)
+
+// ScreenerField 条件・並び替えに使える項目
+// Column はSQLにそのまま埋め込むので、ここに定義した固定の式以外は使わないこと
+type ScreenerField struct {
+	Column string
+	Text   bool // 文字列の項目（eq / ne / in のみ使える）
+}
+
+// ScreenerFields 市場ごとに条件・並び替えに使える項目（項目名は ScreenerRow のJSONのキーと同じ）
+var ScreenerFields = map[string]map[string]ScreenerField{
+	ScreenerMarketJP: {
+		"MarketCode":      {Column: "c.market_code", Text: true},
+		"ScaleCategory":   {Column: "c.scale_category", Text: true},
+		"Sector33Code":    {Column: "c.sector33_code", Text: true},
+		"Sector17Code":    {Column: "c.sector17_code", Text: true},
+		"Close":           {Column: "v.close"},
+		"PER":             {Column: "v.per"},
+		"PBR":             {Column: "v.pbr"},
+		"DividendYield":   {Column: "v.dividend_yield"},
+		"ROE":             {Column: "v.roe"},
+		"MarketCap":       {Column: "v.market_cap"},
+		"ChangeRate":      {Column: "v.change_rate"},
+		"RSI14":           {Column: "v.rsi14"},
+		"RevenueGrowth":   {Column: "fm.net_sales_yoy"},
+		"OperatingMargin": {Column: "fm.operating_margin"},
+	},
+	ScreenerMarketUS: {
+		"Sector":        {Column: "s.sector", Text: true},
+		"Industry":      {Column: "s.industry", Text: true},
+		"Close":         {Column: "v.close"},
+		"PER":           {Column: "v.per"},
+		"PBR":           {Column: "v.pbr"},
+		"DividendYield": {Column: "v.dividend_yield"},
+		"ROE":           {Column: "v.roe"},
+		"MarketCap":     {Column: "m.market_cap"},
+		"ChangeRate":    {Column: "v.change_rate"},
+		"RSI14":         {Column: "v.rsi14"},
+	},
+}
+
+// screenerSources 市場ごとのSELECT句・FROM句
+// 日次の値は銘柄ごとに最新の1行（DISTINCT ON）を結合する
+var screenerSources = map[string]struct {
+	selects string
+	from    string
+	key     string // 並び順を一意にするための列
+}{
+	ScreenerMarketJP: {
+		selects: `'JP' AS market, c.code AS code, c.company_name AS name, c.market_code AS market_code, c.market_code_name AS market_name,
+			c.scale_category AS scale_category, c.sector33_code AS sector_code, c.sector33_code_name AS sector, c.sector17_code_name AS industry,
+			v.date AS date, v.close AS close, v.per AS per, v.pbr AS pbr, v.dividend_yield AS dividend_yield, v.roe AS roe,
+			v.market_cap AS market_cap, v.change_rate AS change_rate, v.rsi14 AS rsi14,
+			fm.net_sales_yoy AS revenue_growth, fm.operating_margin AS operating_margin`,
+		from: `companies c
+			LEFT JOIN (SELECT DISTINCT ON (code) * FROM japanese_stock_valuations ORDER BY code, date DESC) v ON v.code = c.code
+			LEFT JOIN (SELECT DISTINCT ON (code) code, net_sales_yoy, operating_margin FROM financial_metrics
+				WHERE net_sales IS NOT NULL ORDER BY code, disclosed_date DESC, disclosure_number DESC) fm ON fm.code = c.code
+			WHERE c.deleted_at IS NULL`,
+		key: "c.code",
+	},
+	ScreenerMarketUS: {
+		selects: `'US' AS market, s.ticker AS code, s.name AS name, s.sector AS sector, s.industry AS industry,
+			v.date AS date, v.close AS close, v.per AS per, v.pbr AS pbr, v.dividend_yield AS dividend_yield, v.roe AS roe,
+			m.market_cap AS market_cap, v.change_rate AS change_rate, v.rsi14 AS rsi14`,
+		from: `stocks s
+			LEFT JOIN (SELECT DISTINCT ON (stock_id) * FROM stock_valuations ORDER BY stock_id, date DESC) v ON v.stock_id = s.id
+			LEFT JOIN (SELECT DISTINCT ON (stock_id) stock_id, NULLIF(market_cap, 0) AS market_cap FROM stock_metrics
+				WHERE deleted_at IS NULL ORDER BY stock_id, date DESC) m ON m.stock_id = s.id
+			WHERE s.deleted_at IS NULL`,
+		key: "s.ticker",
+	},
+}
+
+// ScreenerCondition 1つの絞り込み条件（Values は演算子に応じた個数の値。検証は呼び出し側で行う）
+type ScreenerCondition struct {
+	Field  string
+	Op     string
+	Values []any
+}
+
+// ScreenerQuery スクリーナーの検索条件（条件はすべてAND）
+type ScreenerQuery struct {
+	Market     string
+	Conditions []ScreenerCondition
+	SortBy     string // 空の場合は銘柄コード順
+	Descending bool
+	Limit      int
+	Offset     int
+}
+
+// ScreenerRow スクリーナーの結果1件分（日次の値が無い銘柄は null）
+type ScreenerRow struct {
+	Market          string   `json:"Market"`
+	Code            string   `json:"Code"` // 日本株は銘柄コード、米国株はティッカー
+	Name            string   `json:"Name"`
+	MarketCode      string   `json:"MarketCode,omitempty"`
+	MarketName      string   `json:"MarketName,omitempty"`
+	ScaleCategory   string   `json:"ScaleCategory,omitempty"`
+	SectorCode      string   `json:"SectorCode,omitempty"`
+	Sector          string   `json:"Sector"`
+	Industry        string   `json:"Industry"`
+	Date            *string  `json:"Date"` // 日次の値の日付
+	Close           *float64 `json:"Close"`
+	PER             *float64 `json:"PER"`
+	PBR             *float64 `json:"PBR"`
+	DividendYield   *float64 `json:"DividendYield"`
+	ROE             *float64 `json:"ROE"`
+	MarketCap       *float64 `json:"MarketCap"`
+	ChangeRate      *float64 `json:"ChangeRate"`
+	RSI14           *float64 `gorm:"column:rsi14" json:"RSI14"`
+	RevenueGrowth   *float64 `json:"RevenueGrowth,omitempty"`
+	OperatingMargin *float64 `json:"OperatingMargin,omitempty"`
+}
+
+type IScreenerRepository interface {
+	Screen(query ScreenerQuery) ([]ScreenerRow, int64, error)
+}
+
+type screenerrepository struct {
+	db *gorm.DB
+}
+
+func NewScreenerRepository(db *gorm.DB) IScreenerRepository {
+	return &screenerrepository{db: db}
+}
+
+// Screen 条件に合う銘柄と、ページングする前の件数を返す
+// 項目名・演算子は ScreenerFields と定数の一覧にあるものだけをSQLに変換し、値はすべてプレースホルダで渡す
+func (r *screenerrepository) Screen(query ScreenerQuery) ([]ScreenerRow, int64, error) {
+	source, ok := screenerSources[query.Market]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown screener market: %q", query.Market)
+	}
+	fields := ScreenerFields[query.Market]
+
+	where := []string{}
+	args := []any{}
+	for _, condition := range query.Conditions {
+		field, ok := fields[condition.Field]
+		if !ok {
+			return nil, 0, fmt.Errorf("unknown screener field: %q", condition.Field)
+		}
+		clause, err := screenerClause(field.Column, condition)
+		if err != nil {
+			return nil, 0, err
+		}
+		where = append(where, clause)
+		args = append(args, condition.Values...)
+	}
+	from := source.from
+	if len(where) > 0 {
+		from += " AND " + strings.Join(where, " AND ")
+	}
+
+	var total int64
+	if err := r.db.Raw("SELECT COUNT(*) FROM "+from, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	orderBy := source.key
+	if query.SortBy != "" {
+		field, ok := fields[query.SortBy]
+		if !ok {
+			return nil, 0, fmt.Errorf("unknown screener field: %q", query.SortBy)
+		}
+		direction := "ASC"
+		if query.Descending {
+			direction = "DESC"
+		}
+		// 値が無い銘柄は並び順に関係なく末尾にする
+		orderBy = field.Column + " " + direction + " NULLS LAST, " + source.key
+	}
+
+	var rows []ScreenerRow
+	sql := "SELECT " + source.selects + " FROM " + from + " ORDER BY " + orderBy + " LIMIT ? OFFSET ?"
+	if err := r.db.Raw(sql, append(args, query.Limit, query.Offset)...).Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+// screenerClause 1つの条件をプレースホルダ付きのSQLに変換する
+func screenerClause(column string, condition ScreenerCondition) (string, error) {
+	switch condition.Op {
+	case ScreenerOpEq:
+		return column + " = ?", nil
+	case ScreenerOpNe:
+		return column + " <> ?", nil
+	case ScreenerOpGt:
+		return column + " > ?", nil
+	case ScreenerOpGte:
+		return column + " >= ?", nil
+	case ScreenerOpLt:
+		return column + " < ?", nil
+	case ScreenerOpLte:
+		return column + " <= ?", nil
+	case ScreenerOpBetween:
+		return column + " BETWEEN ? AND ?", nil
+	case ScreenerOpIn:
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(condition.Values)), ", ")
+		return column + " IN (" + placeholders + ")", nil
+	default:
+		return "", fmt.Errorf("unknown screener operator: %q", condition.Op)
+	}
+}
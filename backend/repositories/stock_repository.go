@@ -1,25 +1,52 @@
 package repositories
 
 import (
-	"errors"
 	"stock-prediction/backend/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// DefaultRankingMaxRank ランキングAPI・AI分析・X投稿で扱う上位の順位（Top 5）
+const DefaultRankingMaxRank = 5
+
+// RankingHistoryQuery 銘柄のランキング履歴の検索条件（空文字・0の条件は無視する）
+type RankingHistoryQuery struct {
+	From     string         // 日付の下限（YYYY-MM-DD, 含む）
+	To       string         // 日付の上限（YYYY-MM-DD, 含む）
+	Category string         // DailyRanking.Category の値
+	MaxRank  int            // この順位以内のみ
+	Limit    int            // 取得する件数
+	After    *RankingCursor // この行より後（日付の古い側）から取得する
+}
+
+// RankingCursor 履歴の並び（日付の新しい順、同じ日付はIDの大きい順）での位置
+type RankingCursor struct {
+	Date string
+	ID   uint
+}
+
 type IStockRepository interface {
-	FindLatestRanking() (*[]models.DailyRanking, error)
-	FindDailyRanking(date string) (*[]models.DailyRanking, error)
-	FindStock(ticker string) (*[]models.DailyRanking, error)
+	FindLatestRanking(category string, maxRank int) (*[]models.DailyRanking, error)
+	FindDailyRanking(date string, category string, maxRank int) (*[]models.DailyRanking, error)
+	FindStock(ticker string, query RankingHistoryQuery) (*[]models.DailyRanking, error)
 	CreateOrUpdateStock(stock *models.Stock) error
 	CreateOrUpdateDailyRanking(ranking *models.DailyRanking) error
-	FindTopRankingsByCategory(category string, limit int) (*[]models.DailyRanking, error)
+	FindTopRankingsByCategory(date string, category string, limit int) (*[]models.DailyRanking, error)
 	FindStockByID(id uint) (*models.Stock, error)
 	UpdateDailyRanking(ranking *models.DailyRanking) error
 	UpdateStock(stock *models.Stock) error
 	UpdateStockMetric(metric *models.StockMetric) error
 	FindStockByTicker(ticker string) (*models.Stock, error)
 	FindDailyRankingByDateAndRank(date string, rank int, category string) (*models.DailyRanking, error)
+	HasStockMetric(stockID uint, date string) (bool, error)
+	FindRankingsByDate(date string) (*[]models.DailyRanking, error)
+	UpsertDailyBars(bars []models.StockDailyBar) error
+	ReplaceDailyBars(stockID uint, bars []models.StockDailyBar) error
+	FindLatestDailyBarDate(stockID uint) (string, error)
+	FindDailyBars(ticker string, from string, to string) (*[]models.StockDailyBar, error)
+	UpsertStockValuation(valuation *models.StockValuation) error
+	FindStockValuations(ticker string, from string, to string) (*[]models.StockValuation, error)
 }
 
 type stockrepository struct {
@@ -30,30 +57,31 @@ func NewStockRepository(db *gorm.DB) IStockRepository {
 	return &stockrepository{db: db}
 }
 
-func (r *stockrepository) FindLatestRanking() (*[]models.DailyRanking, error) {
+func (r *stockrepository) FindLatestRanking(category string, maxRank int) (*[]models.DailyRanking, error) {
 	var dailyRanking []models.DailyRanking
 
-	// 1. 最新の日付を取得
-	var latestDate string
+	// 1. 指定カテゴリの最新の日付を取得（ランキングが無い場合は MAX(date) が NULL になる）
+	var latestDate *string
 	dateResult := r.db.Model(&models.DailyRanking{}).
+		Where("category = ?", category).
 		Select("MAX(date)").
 		Scan(&latestDate)
 	if dateResult.Error != nil {
 		return nil, dateResult.Error
 	}
-	if latestDate == "" {
-		return nil, errors.New("no data found")
+	if latestDate == nil || *latestDate == "" {
+		return nil, notFound("ranking")
 	}
 
-	// 2. 最新日付のTop Gainersの1~5位を取得
+	// 2. 最新日付の指定カテゴリの1~maxRank位を取得
 	result := r.db.Preload("Stock").
-		Where("date = ? AND category = ? AND rank <= ?", latestDate, "Top Gainers", 5).
+		Where("date = ? AND category = ? AND rank <= ?", *latestDate, category, maxRank).
 		Order("rank ASC").
 		Find(&dailyRanking)
 
 	if result.Error != nil {
 		if result.Error.Error() == "record not found" {
-			return nil, errors.New("no data found")
+			return nil, notFound("ranking")
 		}
 		return nil, result.Error
 	}
@@ -61,33 +89,52 @@ func (r *stockrepository) FindLatestRanking() (*[]models.DailyRanking, error) {
 	return &dailyRanking, nil
 }
 
-func (r *stockrepository) FindDailyRanking(date string) (*[]models.DailyRanking, error) {
+func (r *stockrepository) FindDailyRanking(date string, category string, maxRank int) (*[]models.DailyRanking, error) {
 	var dailyRanking []models.DailyRanking
-	// 指定日付のTop Gainersの1~5位を取得
+	// 指定日付の指定カテゴリの1~maxRank位を取得
 	result := r.db.Preload("Stock").
-		Where("date = ? AND category = ? AND rank <= ?", date, "Top Gainers", 5).
+		Where("date = ? AND category = ? AND rank <= ?", date, category, maxRank).
 		Order("rank ASC").
 		Find(&dailyRanking)
 	if result.Error != nil {
 		if result.Error.Error() == "record not found" {
-			return nil, errors.New("no data found")
+			return nil, notFound("ranking")
 		}
 		return nil, result.Error
 	}
 	return &dailyRanking, nil
 }
 
-func (r *stockrepository) FindStock(ticker string) (*[]models.DailyRanking, error) {
+// FindStock 銘柄のランキング履歴を日付の新しい順（同じ日付はIDの大きい順）に query.Limit 件返す
+func (r *stockrepository) FindStock(ticker string, query RankingHistoryQuery) (*[]models.DailyRanking, error) {
 	var dailyRanking []models.DailyRanking
 	// StockテーブルとJOINしてtickerで検索
-	result := r.db.Preload("Stock").
+	tx := r.db.Preload("Stock").
 		Joins("JOIN stocks ON daily_rankings.stock_id = stocks.id").
-		Where("stocks.ticker = ?", ticker).
-		Order("daily_rankings.date DESC").
+		Where("stocks.ticker = ?", ticker)
+	if query.From != "" {
+		tx = tx.Where("daily_rankings.date >= ?", query.From)
+	}
+	if query.To != "" {
+		tx = tx.Where("daily_rankings.date <= ?", query.To)
+	}
+	if query.Category != "" {
+		tx = tx.Where("daily_rankings.category = ?", query.Category)
+	}
+	if query.MaxRank > 0 {
+		tx = tx.Where("daily_rankings.rank <= ?", query.MaxRank)
+	}
+	if query.After != nil {
+		tx = tx.Where("daily_rankings.date < ? OR (daily_rankings.date = ? AND daily_rankings.id < ?)",
+			query.After.Date, query.After.Date, query.After.ID)
+	}
+	result := tx.
+		Order("daily_rankings.date DESC, daily_rankings.id DESC").
+		Limit(query.Limit).
 		Find(&dailyRanking)
 	if result.Error != nil {
 		if result.Error.Error() == "record not found" {
-			return nil, errors.New("no data found")
+			return nil, notFound("ranking")
 		}
 		return nil, result.Error
 	}
@@ -124,34 +171,20 @@ func (r *stockrepository) CreateOrUpdateDailyRanking(ranking *models.DailyRankin
 	return r.db.Model(&existingRanking).Updates(ranking).Error
 }
 
-func (r *stockrepository) FindTopRankingsByCategory(category string, limit int) (*[]models.DailyRanking, error) {
+// FindTopRankingsByCategory 指定日・指定カテゴリの上位 limit 件のうち、AI分析が未実行のものを取得する
+func (r *stockrepository) FindTopRankingsByCategory(date string, category string, limit int) (*[]models.DailyRanking, error) {
 	var rankings []models.DailyRanking
 
-	// 最新の日付を取得
-	var latestDate string
-	dateResult := r.db.Model(&models.DailyRanking{}).
-		Where("category = ?", category).
-		Select("MAX(date)").
-		Scan(&latestDate)
-
-	if dateResult.Error != nil {
-		return nil, dateResult.Error
-	}
-
-	if latestDate == "" {
-		return nil, errors.New("no data found")
-	}
-
-	// 最新日で、かつAiAnalysisが空のもののみ取得
+	// AiAnalysisが空のもののみ取得
 	result := r.db.Preload("Stock").
 		Where("category = ? AND rank <= ? AND date = ? AND (ai_analysis = '' OR ai_analysis IS NULL)",
-			category, limit, latestDate).
+			category, limit, date).
 		Order("rank ASC").
 		Find(&rankings)
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
-			return nil, errors.New("no data found")
+			return nil, notFound("ranking")
 		}
 		return nil, result.Error
 	}
@@ -165,7 +198,7 @@ func (r *stockrepository) FindStockByID(id uint) (*models.Stock, error) {
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
-			return nil, errors.New("stock not found")
+			return nil, notFound("stock")
 		}
 		return nil, result.Error
 	}
@@ -191,7 +224,7 @@ func (r *stockrepository) FindStockByTicker(ticker string) (*models.Stock, error
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
-			return nil, errors.New("stock not found")
+			return nil, notFound("stock")
 		}
 		return nil, result.Error
 	}
@@ -205,10 +238,112 @@ func (r *stockrepository) FindDailyRankingByDateAndRank(date string, rank int, c
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
-			return nil, errors.New("daily ranking not found")
+			return nil, notFound("daily ranking")
 		}
 		return nil, result.Error
 	}
 
 	return &ranking, nil
 }
+
+// HasStockMetric 指定日の動的情報（StockMetric）が保存済みか
+func (r *stockrepository) HasStockMetric(stockID uint, date string) (bool, error) {
+	var count int64
+	result := r.db.Model(&models.StockMetric{}).
+		Where("stock_id = ? AND date = ?", stockID, date).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+// FindRankingsByDate 指定日の全カテゴリ・全順位のランキングを取得する
+func (r *stockrepository) FindRankingsByDate(date string) (*[]models.DailyRanking, error) {
+	var rankings []models.DailyRanking
+	result := r.db.Preload("Stock").
+		Where("date = ?", date).
+		Order("category ASC, rank ASC").
+		Find(&rankings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &rankings, nil
+}
+
+// UpsertDailyBars 日足を保存する（同じ銘柄・日付は上書き）
+func (r *stockrepository) UpsertDailyBars(bars []models.StockDailyBar) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "stock_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"open", "high", "low", "close", "volume",
+			"adjustment_open", "adjustment_high", "adjustment_low", "adjustment_close", "adjustment_volume",
+			"provider",
+		}),
+	}).CreateInBatches(bars, 500).Error
+}
+
+// ReplaceDailyBars 銘柄の保存済みの日足を全て削除して bars に置き換える（分割・配当で過去の調整後の値が変わった場合に使う）
+func (r *stockrepository) ReplaceDailyBars(stockID uint, bars []models.StockDailyBar) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("stock_id = ?", stockID).Delete(&models.StockDailyBar{}).Error; err != nil {
+			return err
+		}
+		if len(bars) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(bars, 500).Error
+	})
+}
+
+// FindLatestDailyBarDate 保存済みの日足の最新日（未保存の場合は空文字）
+func (r *stockrepository) FindLatestDailyBarDate(stockID uint) (string, error) {
+	var latestDate *string
+	result := r.db.Model(&models.StockDailyBar{}).
+		Where("stock_id = ?", stockID).
+		Select("MAX(date)").
+		Scan(&latestDate)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if latestDate == nil {
+		return "", nil
+	}
+	return *latestDate, nil
+}
+
+// FindDailyBars tickerの from〜to（両端を含む）の日足を日付の古い順に取得する
+func (r *stockrepository) FindDailyBars(ticker string, from string, to string) (*[]models.StockDailyBar, error) {
+	var bars []models.StockDailyBar
+	result := r.db.
+		Joins("JOIN stocks ON stock_daily_bars.stock_id = stocks.id").
+		Where("stocks.ticker = ? AND stock_daily_bars.date BETWEEN ? AND ?", ticker, from, to).
+		Order("stock_daily_bars.date ASC").
+		Find(&bars)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &bars, nil
+}
+
+// UpsertStockValuation バリュエーションを保存する（同じ銘柄・日付は上書き）
+func (r *stockrepository) UpsertStockValuation(valuation *models.StockValuation) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "stock_id"}, {Name: "date"}},
+		UpdateAll: true,
+	}).Create(valuation).Error
+}
+
+// FindStockValuations tickerの from〜to（YYYY-MM-DD, 両端を含む）のバリュエーションを日付の古い順に返す
+func (r *stockrepository) FindStockValuations(ticker string, from string, to string) (*[]models.StockValuation, error) {
+	var valuations []models.StockValuation
+	result := r.db.
+		Joins("JOIN stocks ON stock_valuations.stock_id = stocks.id").
+		Where("stocks.ticker = ? AND stock_valuations.date BETWEEN ? AND ?", ticker, from, to).
+		Order("stock_valuations.date ASC").
+		Find(&valuations)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &valuations, nil
+}
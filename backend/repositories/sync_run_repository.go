@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"stock-prediction/backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ISyncRunRepository interface {
+	FindSyncRunByDate(tradingDate string) (*models.SyncRun, error)
+	FindOrCreateSyncRun(tradingDate string) (*models.SyncRun, error)
+	UpdateSyncRun(run *models.SyncRun) error
+	UpdateSyncRunStage(stage *models.SyncRunStage) error
+	FindSyncRuns(limit int) (*[]models.SyncRun, error)
+}
+
+type syncrunrepository struct {
+	db *gorm.DB
+}
+
+func NewSyncRunRepository(db *gorm.DB) ISyncRunRepository {
+	return &syncrunrepository{db: db}
+}
+
+func preloadSyncRunStages(db *gorm.DB) *gorm.DB {
+	return db.Order("id ASC")
+}
+
+// FindSyncRunByDate 指定した取引日の同期の記録をステージ込みで取得する
+func (r *syncrunrepository) FindSyncRunByDate(tradingDate string) (*models.SyncRun, error) {
+	var run models.SyncRun
+	result := r.db.Preload("Stages", preloadSyncRunStages).
+		Where("trading_date = ?", tradingDate).
+		First(&run)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, notFound("sync run")
+		}
+		return nil, result.Error
+	}
+
+	if err := addMissingSyncRunStages(r.db, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// FindOrCreateSyncRun 指定した取引日の同期の記録を取得する（無ければ全ステージ pending で作成する）
+func (r *syncrunrepository) FindOrCreateSyncRun(tradingDate string) (*models.SyncRun, error) {
+	var run models.SyncRun
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Preload("Stages", preloadSyncRunStages).
+			Where("trading_date = ?", tradingDate).
+			Limit(1).
+			Find(&run)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return addMissingSyncRunStages(tx, &run)
+		}
+
+		run = models.SyncRun{TradingDate: tradingDate, Status: models.SyncStatusPending}
+		for _, stage := range models.SyncStages {
+			run.Stages = append(run.Stages, models.SyncRunStage{Stage: stage, Status: models.SyncStatusPending})
+		}
+		return tx.Create(&run).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+// addMissingSyncRunStages ステージを追加する前に作成された記録に、不足しているステージを pending で追加する
+func addMissingSyncRunStages(db *gorm.DB, run *models.SyncRun) error {
+	for _, name := range models.SyncStages {
+		if run.Stage(name) != nil {
+			continue
+		}
+		stage := models.SyncRunStage{SyncRunID: run.ID, Stage: name, Status: models.SyncStatusPending}
+		if err := db.Create(&stage).Error; err != nil {
+			return err
+		}
+		run.Stages = append(run.Stages, stage)
+	}
+	return nil
+}
+
+func (r *syncrunrepository) UpdateSyncRun(run *models.SyncRun) error {
+	// ステージは UpdateSyncRunStage で個別に保存する
+	return r.db.Omit(clause.Associations).Save(run).Error
+}
+
+func (r *syncrunrepository) UpdateSyncRunStage(stage *models.SyncRunStage) error {
+	return r.db.Save(stage).Error
+}
+
+// FindSyncRuns 同期の記録を取引日の新しい順に取得する
+func (r *syncrunrepository) FindSyncRuns(limit int) (*[]models.SyncRun, error) {
+	var runs []models.SyncRun
+
+	result := r.db.Preload("Stages", preloadSyncRunStages).
+		Order("trading_date DESC").
+		Limit(limit).
+		Find(&runs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &runs, nil
+}
@@ -2,13 +2,18 @@ package router
 
 import (
 	"stock-prediction/backend/controllers"
+	"stock-prediction/backend/middlewares"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/services"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
-func NewRouter(sc controllers.IStockController) *echo.Echo {
+func NewRouter(sc controllers.IStockController, jc controllers.IJapaneseStockController, ac controllers.IAnalysisController, schc controllers.ISchedulerController, auc controllers.IAuthController, jbc controllers.IJobController, scc controllers.IScreenerController, dc controllers.IDocsController, authService services.IAuthService) *echo.Echo {
 	e := echo.New()
+	// 存在しないルート・パニックなどのエラーもAPIと同じ形式（dto.ErrorResponse）で返す
+	e.HTTPErrorHandler = controllers.HTTPErrorHandler
 
 	// CORS設定
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
@@ -18,7 +23,7 @@ func NewRouter(sc controllers.IStockController) *echo.Echo {
 			"http://localhost:3004",
 			"https://stock-prediction-fawn.vercel.app",
 		},
-		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept},
+		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, middlewares.HeaderAPIKey},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowCredentials: true,
 	}))
@@ -35,11 +40,62 @@ func NewRouter(sc controllers.IStockController) *echo.Echo {
 	stocks.GET("/latest", sc.FindLatestRanking)
 	stocks.GET("/date", sc.FindDailyRanking)
 	stocks.GET("/:ticker", sc.FindStock)
+	stocks.GET("/:ticker/bars", sc.FindDailyBars)
+	stocks.GET("/:ticker/indicators", sc.FindIndicators)
+	stocks.GET("/:ticker/valuation", sc.FindValuation)
 
-	// Admin routes
-	admin := api.Group("/admin")
-	admin.POST("/sync", sc.SyncData)
-	admin.POST("/xpost", sc.XAutomaticallyPost)
+	// Japanese stock analysis routes
+	jp := api.Group("/jp")
+	jp.GET("/analysis/:code", ac.FindStockAnalysis)
+	jp.GET("/sectors/:sectorCode/latest", ac.FindLatestSectorTopPicks)
+	jp.GET("/indicators/:code", ac.FindIndicators)
+	jp.GET("/financials/:code", ac.FindFinancialMetrics)
+	jp.GET("/valuation/:code", ac.FindValuation)
+
+	// Japanese stock ranking routes（SyncMovers で全銘柄の日足から計算したランキング）
+	jp.GET("/stocks/latest", jc.FindLatestRanking)
+	jp.GET("/stocks/date", jc.FindDailyRanking)
+
+	// Screener routes（日本株・米国株を会社情報・バリュエーション・財務指標の条件で絞り込む）
+	api.POST("/screener", scc.Screen)
+
+	// API docs（OpenAPI仕様とSwagger UI。ルートを追加した場合は controllers.NewOpenAPIDocument にも追加する。一致しない場合は起動時にエラーになる）
+	api.GET("/openapi.json", dc.FindOpenAPI)
+	api.GET("/docs", dc.FindDocs)
+
+	// Admin routes（APIキー必須。操作と認証失敗は監査ログに記録する）
+	admin := api.Group("/admin", middlewares.AuditLog(authService), middlewares.APIKeyAuth(authService))
+	operator := middlewares.RequireRole(models.RoleOperator)
+	viewer := middlewares.RequireRole(models.RoleViewer)
+	adminOnly := middlewares.RequireRole(models.RoleAdmin)
+
+	admin.POST("/sync", sc.SyncData, operator)
+	admin.GET("/sync-runs", sc.FindSyncRuns, viewer)
+	admin.POST("/xpost", sc.XAutomaticallyPost, operator)
+
+	// Japanese stock admin routes
+	adminJP := admin.Group("/jp", operator)
+	adminJP.POST("/sync", jc.SyncSector)
+	adminJP.POST("/analyze", jc.AnalyzeSector)
+	adminJP.POST("/compare", jc.CompareSector)
+	adminJP.POST("/movers", jc.SyncMovers)
+
+	// Background job routes（同期・分析・投稿の各APIはジョブIDを返すので、ここで進捗を確認する）
+	adminJobs := admin.Group("/jobs", viewer)
+	adminJobs.GET("", jbc.FindJobs)
+	adminJobs.GET("/:id", jbc.FindJob)
+
+	// Scheduler admin routes
+	adminScheduler := admin.Group("/scheduler", viewer)
+	adminScheduler.GET("/jobs", schc.FindJobs)
+	adminScheduler.GET("/runs", schc.FindJobRuns)
+
+	// API key management and audit log routes
+	adminKeys := admin.Group("/keys", adminOnly)
+	adminKeys.GET("", auc.FindAPIKeys)
+	adminKeys.POST("", auc.IssueAPIKey)
+	adminKeys.DELETE("/:id", auc.RevokeAPIKey)
+	admin.GET("/audit-logs", auc.FindAuditLogs, adminOnly)
 
 	return e
 }
@@ -1,52 +1,141 @@
 package AI
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
 	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/AI/llm"
+	"stock-prediction/backend/services/indicators"
 	"stock-prediction/backend/services/news"
+	"stock-prediction/backend/services/progress"
+	"time"
 )
 
-func PerformDailyAnalysis(repo repositories.IStockRepository) error {
-	// Repository層からTop Gainersの上位5件を取得
-	rankings, err := repo.FindTopRankingsByCategory("Top Gainers", 5)
+// CollectDailyNews 指定日・指定カテゴリの上位5件のうち、ニュースが未取得のものについてニュースを取得し DailyRanking.NewsSummary に保存する
+// 銘柄ごとの結果は reporter に "news/<category>/<ticker>" の単位で通知し、取得に失敗した銘柄があればエラーを返す
+// ctx がキャンセルされた場合は残りの銘柄を取得せずに ctx のエラーを返す
+func CollectDailyNews(ctx context.Context, repo repositories.IStockRepository, date string, category string, reporter progress.Reporter) error {
+	rankings, err := repo.FindDailyRanking(date, category, repositories.DefaultRankingMaxRank)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("Starting AI analysis for %d stocks...", len(*rankings))
-
+	tavilyApiKey := os.Getenv("TAVILY_API_KEY")
+	failed := 0
 	for _, ranking := range *rankings {
-		// Stock情報は既にPreloadされているので、直接アクセス可能
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if ranking.NewsSummary != "" {
+			continue
+		}
 		stock := ranking.Stock
+		item := "news/" + category + "/" + stock.Ticker
 
 		log.Printf("Fetching news for %s...", stock.Ticker)
-
-		// ニュースを取得
-		tavilyApiKey := os.Getenv("TAVILY_API_KEY")
 		headlines, err := news.SearchStockNews(stock.Ticker, tavilyApiKey)
 		if err != nil {
+			// 失敗した銘柄はニュースなしでAI分析する（再実行時にもう一度取得する）
 			log.Printf("Warning: Failed to fetch news for %s: %v", stock.Ticker, err)
-			// エラーでも止まらず、ニュースなしで分析させる（Brave導入ならここで呼ぶ）
-			headlines = []string{}
+			reporter.Report(item, progress.StatusFailed, err.Error())
+			failed++
+			continue
+		}
+
+		ranking.NewsSummary = FormatNewsSummary(headlines)
+		if err := repo.UpdateDailyRanking(&ranking); err != nil {
+			log.Printf("Warning: Failed to save news for %s: %v", stock.Ticker, err)
+			reporter.Report(item, progress.StatusFailed, err.Error())
+			failed++
+			continue
+		}
+		reporter.Report(item, progress.StatusSucceeded, "")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to collect news for %d stocks", failed)
+	}
+	return nil
+}
+
+// PerformDailyAnalysis 指定日・指定カテゴリ（models.CategoryTopGainers など）の上位5件のうち、未分析のものを client のLLMで分析する
+// ニュースは CollectDailyNews で保存した NewsSummary を使う（未取得の場合はニュースなしで分析する）
+// テクニカル指標は同期済みの日足（StockDailyBar）から計算する
+// 銘柄ごとの結果は reporter に "analysis/<category>/<ticker>" の単位で通知し（成功時のメッセージはモデルとトークン使用量）、
+// 分析に失敗した銘柄があればエラーを返す。ctx がキャンセルされた場合は残りの銘柄を分析せずに ctx のエラーを返す
+func PerformDailyAnalysis(ctx context.Context, repo repositories.IStockRepository, client llm.LLMClient, date string, category string, reporter progress.Reporter) error {
+	// Repository層から指定カテゴリの上位 DefaultRankingMaxRank 件（未分析のもの）を取得
+	rankings, err := repo.FindTopRankingsByCategory(date, category, repositories.DefaultRankingMaxRank)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Starting AI analysis for %d stocks (%s) with %s (%s)...", len(*rankings), category, client.Name(), client.Model())
+
+	failed := 0
+	var usage llm.Usage
+	for _, ranking := range *rankings {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Stock情報は既にPreloadされているので、直接アクセス可能
+		stock := ranking.Stock
+		item := "analysis/" + category + "/" + stock.Ticker
+		reporter.Report(item, progress.StatusRunning, "")
+
+		// 保存済みの日足からテクニカル指標を計算する（日足が無い場合は指標なしで分析する）
+		technicalText := ""
+		if snapshot, err := dailyIndicators(repo, stock.Ticker, date); err != nil {
+			log.Printf("Warning: Failed to compute indicators for %s: %v", stock.Ticker, err)
+		} else {
+			technicalText = snapshot.Summary()
 		}
 
 		// AI分析を実行
-		analysis, err := AnalyzeStockRise(stock.Ticker, ranking.ChangeRate, headlines)
+		analysis, err := AnalyzeStockMoveWithSummary(ctx, client, stock.Ticker, category, ranking.ChangeRate, ranking.NewsSummary, technicalText)
 		if err != nil {
 			log.Printf("Warning: Failed to analyze %s: %v", stock.Ticker, err)
+			reporter.Report(item, progress.StatusFailed, err.Error())
+			failed++
 			continue
 		}
 
+		usage.Add(analysis.Usage)
+
 		// 分析結果を更新
-		ranking.AiAnalysis = analysis
+		ranking.AiAnalysis = analysis.Content
 		if err := repo.UpdateDailyRanking(&ranking); err != nil {
 			log.Printf("Warning: Failed to update ranking for %s: %v", stock.Ticker, err)
+			reporter.Report(item, progress.StatusFailed, err.Error())
+			failed++
 			continue
 		}
+		reporter.Report(item, progress.StatusSucceeded, analysis.Model+": "+analysis.Usage.String())
 
-		log.Printf("Completed analysis for %s", stock.Ticker)
+		log.Printf("Completed analysis for %s (%s)", stock.Ticker, analysis.Usage)
 	}
 
+	log.Printf("Finished AI analysis for %s: %s", category, usage)
+
+	if failed > 0 {
+		return fmt.Errorf("failed to analyze %d stocks", failed)
+	}
 	return nil
 }
+
+// dailyIndicators date 時点のテクニカル指標を保存済みの日足から計算する（日足が無い場合は nil）
+func dailyIndicators(repo repositories.IStockRepository, ticker string, date string) (*indicators.Snapshot, error) {
+	to, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, err
+	}
+	from := to.AddDate(0, 0, -indicators.LookbackDays).Format("2006-01-02")
+
+	bars, err := repo.FindDailyBars(ticker, from, date)
+	if err != nil {
+		return nil, err
+	}
+	return indicators.Compute(indicators.FromStockDailyBars(*bars)), nil
+}
@@ -0,0 +1,106 @@
+package AI
+
+import (
+	"context"
+	"fmt"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/services/AI/llm"
+)
+
+// カテゴリごとのシステムプロンプト
+const riseSystemPrompt = `
+あなたはプロの株式市場アナリストです。
+提供された「銘柄」「上昇率」「関連ニュース」をもとに、
+その株がなぜ急上昇したのか、その要因を簡潔に日本語で解説してください。
+ニュースがない場合は、その企業の一般的な事業内容と、この上昇率が通常あり得るものかどうかを述べてください。
+「テクニカル指標」が提供された場合は、移動平均からの乖離やRSI・52週高値/安値との位置関係も踏まえてください。
+回答は150文字以内で、投資家向けに要約してください。
+`
+
+const fallSystemPrompt = `
+あなたはプロの株式市場アナリストです。
+提供された「銘柄」「下落率」「関連ニュース」をもとに、
+その株がなぜ急落したのか、その要因を簡潔に日本語で解説してください。
+ニュースがない場合は、その企業の一般的な事業内容と、この下落率が通常あり得るものかどうかを述べてください。
+「テクニカル指標」が提供された場合は、移動平均からの乖離やRSI・52週高値/安値との位置関係も踏まえてください。
+回答は150文字以内で、投資家向けに要約してください。
+`
+
+const activeSystemPrompt = `
+あなたはプロの株式市場アナリストです。
+提供された「銘柄」「騰落率」「関連ニュース」をもとに、
+その株がなぜ本日これほど活発に売買されたのか、その要因を簡潔に日本語で解説してください。
+ニュースがない場合は、その企業の一般的な事業内容と、出来高が膨らんだ背景として考えられることを述べてください。
+「テクニカル指標」が提供された場合は、移動平均からの乖離やRSI・52週高値/安値との位置関係も踏まえてください。
+回答は150文字以内で、投資家向けに要約してください。
+`
+
+// noNewsSummary ニュースが見つからなかった場合にプロンプトに入れる文言
+const noNewsSummary = "特になし"
+
+// AnalyzeStockRise 上昇銘柄の上昇理由を分析する
+func AnalyzeStockRise(client llm.LLMClient, ticker string, changeRate float64, newsHeadlines []string) (string, error) {
+	return AnalyzeStockMove(client, ticker, models.CategoryTopGainers, changeRate, newsHeadlines)
+}
+
+// AnalyzeStockMove カテゴリ（上昇・下落・出来高上位）に応じて値動きの理由を分析する
+func AnalyzeStockMove(client llm.LLMClient, ticker string, category string, changeRate float64, newsHeadlines []string) (string, error) {
+	resp, err := AnalyzeStockMoveWithSummary(context.Background(), client, ticker, category, changeRate, FormatNewsSummary(newsHeadlines), "")
+	if err != nil {
+		return "", err
+	}
+	return resp.Content, nil
+}
+
+// FormatNewsSummary ニュースをプロンプトに埋め込む形式（箇条書き）にする
+// DailyRanking.NewsSummary にはこの形式で保存する
+func FormatNewsSummary(newsHeadlines []string) string {
+	if len(newsHeadlines) == 0 {
+		return noNewsSummary
+	}
+	newsText := ""
+	for _, h := range newsHeadlines {
+		newsText += "- " + h + "\n"
+	}
+	return newsText
+}
+
+// AnalyzeStockMoveWithSummary FormatNewsSummary の形式のニュース（保存済みの NewsSummary）をもとに値動きの理由を分析する
+// technicalText は indicators.Snapshot.Summary の形式のテクニカル指標（空の場合はプロンプトに含めない）
+// 分析結果（Content）と、使用したモデル・トークン使用量を返す
+func AnalyzeStockMoveWithSummary(ctx context.Context, client llm.LLMClient, ticker string, category string, changeRate float64, newsText string, technicalText string) (*llm.Response, error) {
+	if newsText == "" {
+		newsText = noNewsSummary
+	}
+	if technicalText != "" {
+		newsText += "\nテクニカル指標:\n" + technicalText + "\n"
+	}
+
+	var systemPrompt, userContent string
+	switch category {
+	case models.CategoryTopLosers:
+		systemPrompt = fallSystemPrompt
+		userContent = fmt.Sprintf(
+			"銘柄: %s\n本日の下落率: %.2f%%\n関連ニュース:\n%s\n\nこの下落の理由を分析してください。",
+			ticker, changeRate, newsText,
+		)
+	case models.CategoryMostActivelyTraded:
+		systemPrompt = activeSystemPrompt
+		userContent = fmt.Sprintf(
+			"銘柄: %s\n本日の騰落率: %+.2f%%\n関連ニュース:\n%s\n\n売買が活発になった理由を分析してください。",
+			ticker, changeRate, newsText,
+		)
+	default:
+		systemPrompt = riseSystemPrompt
+		userContent = fmt.Sprintf(
+			"銘柄: %s\n本日の上昇率: +%.2f%%\n関連ニュース:\n%s\n\nこの上昇の理由を分析してください。",
+			ticker, changeRate, newsText,
+		)
+	}
+
+	resp, err := client.Complete(ctx, llm.UserPrompt(systemPrompt, userContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze %s with %s (%s): %w", ticker, client.Name(), client.Model(), err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,103 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	anthropicBaseURL = "https://api.anthropic.com/v1"
+	anthropicVersion = "2023-06-01"
+)
+
+// anthropicClient Anthropic の Messages API のクライアント
+type anthropicClient struct {
+	config    Config
+	baseURL   string
+	transport *transport
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	System      string             `json:"system,omitempty"`
+	Messages    []anthropicMessage `json:"messages"`
+	MaxTokens   int                `json:"max_tokens"` // Messages API では必須
+	Temperature *float64           `json:"temperature,omitempty"`
+}
+
+type anthropicResponse struct {
+	Model   string `json:"model"`
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+	Usage      struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+func newAnthropicClient(config Config) *anthropicClient {
+	return &anthropicClient{
+		config:    config,
+		baseURL:   baseURL(config, anthropicBaseURL),
+		transport: newTransport(ProviderAnthropic, config),
+	}
+}
+
+func (c *anthropicClient) Name() string {
+	return ProviderAnthropic
+}
+
+func (c *anthropicClient) Model() string {
+	return c.config.Model
+}
+
+func (c *anthropicClient) Complete(ctx context.Context, req Request) (*Response, error) {
+	if c.config.APIKey == "" {
+		return nil, fmt.Errorf("%w (ANTHROPIC_API_KEY)", ErrAPIKeyNotSet)
+	}
+
+	body := anthropicRequest{
+		Model:       c.config.Model,
+		System:      req.System,
+		MaxTokens:   maxTokens(req, c.config),
+		Temperature: req.Temperature,
+	}
+	for _, message := range req.Messages {
+		body.Messages = append(body.Messages, anthropicMessage{Role: message.Role, Content: message.Content})
+	}
+
+	var resp anthropicResponse
+	headers := map[string]string{
+		"x-api-key":         c.config.APIKey,
+		"anthropic-version": anthropicVersion,
+	}
+	if err := c.transport.postJSON(ctx, c.baseURL+"/messages", headers, body, &resp); err != nil {
+		return nil, err
+	}
+
+	// content はブロックの配列で返るので、テキストのブロックだけを連結する
+	var content strings.Builder
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			content.WriteString(block.Text)
+		}
+	}
+	if content.Len() == 0 {
+		return nil, fmt.Errorf("%s: %w (stop_reason: %q)", ProviderAnthropic, ErrEmptyResponse, resp.StopReason)
+	}
+
+	return &Response{
+		Content: content.String(),
+		Model:   responseModel(resp.Model, c.config.Model),
+		Usage:   Usage{InputTokens: resp.Usage.InputTokens, OutputTokens: resp.Usage.OutputTokens},
+	}, nil
+}
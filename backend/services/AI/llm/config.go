@@ -0,0 +1,156 @@
+package llm
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 対応しているプロバイダー（LLM_PROVIDER の値）
+const (
+	ProviderOpenAI    = "openai"
+	ProviderGemini    = "gemini"
+	ProviderAnthropic = "anthropic"
+	ProviderFake      = "fake" // APIを呼ばずに決定的な応答を返す（動作確認用）
+)
+
+const (
+	// 1回のリクエストのタイムアウト（再試行の待ち時間は含まない）
+	defaultTimeout = 60 * time.Second
+	// 429・5xx・通信エラーの再試行回数（最初の1回は含まない）
+	defaultMaxRetries = 2
+	// 出力トークンの上限（分析結果は150文字以内なので余裕を持たせた値）
+	defaultMaxTokens = 1024
+)
+
+// defaultModels LLM_MODEL を省略した場合のモデル
+var defaultModels = map[string]string{
+	ProviderOpenAI:    "gpt-4o",
+	ProviderGemini:    "gemini-2.5-flash",
+	ProviderAnthropic: "claude-sonnet-4-5",
+	ProviderFake:      "fake",
+}
+
+// apiKeyEnvs プロバイダーごとのAPIキーの環境変数
+var apiKeyEnvs = map[string]string{
+	ProviderOpenAI:    "OPENAI_API_KEY",
+	ProviderGemini:    "GEMINI_API_KEY",
+	ProviderAnthropic: "ANTHROPIC_API_KEY",
+}
+
+// Config LLMクライアントの設定
+type Config struct {
+	Provider   string        // ProviderOpenAI など（空の場合は ProviderOpenAI）
+	Model      string        // 空の場合はプロバイダーの既定のモデル
+	APIKey     string        // 空の場合は最初のリクエスト時に ErrAPIKeyNotSet を返す
+	BaseURL    string        // 空の場合は各プロバイダーのAPI（互換APIやプロキシを使う場合に指定）
+	Timeout    time.Duration // 0の場合は defaultTimeout
+	MaxRetries int           // 429・5xx・通信エラーの再試行回数（0の場合は再試行しない。ConfigFromEnv の既定は defaultMaxRetries）
+	MaxTokens  int           // 0の場合は defaultMaxTokens
+}
+
+// ConfigFromEnv 環境変数から設定を読み込む
+//   - LLM_PROVIDER: openai / gemini / anthropic / fake（省略時は openai）
+//   - LLM_MODEL: モデル名（省略時はプロバイダーの既定）
+//   - OPENAI_API_KEY / GEMINI_API_KEY / ANTHROPIC_API_KEY: 選択したプロバイダーのAPIキー
+//   - LLM_BASE_URL, LLM_TIMEOUT（例: 90s）, LLM_MAX_RETRIES, LLM_MAX_TOKENS: 省略可
+//
+// 数値・時間の形式が不正な場合は警告を出して既定値を使う
+func ConfigFromEnv() Config {
+	provider := strings.ToLower(strings.TrimSpace(os.Getenv("LLM_PROVIDER")))
+	if provider == "" {
+		provider = ProviderOpenAI
+	}
+
+	config := Config{
+		Provider:   provider,
+		Model:      os.Getenv("LLM_MODEL"),
+		BaseURL:    os.Getenv("LLM_BASE_URL"),
+		Timeout:    defaultTimeout,
+		MaxRetries: defaultMaxRetries,
+		MaxTokens:  defaultMaxTokens,
+	}
+	if name, ok := apiKeyEnvs[provider]; ok {
+		config.APIKey = os.Getenv(name)
+	}
+
+	if value := os.Getenv("LLM_TIMEOUT"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			config.Timeout = parsed
+		} else {
+			log.Printf("Warning: invalid LLM_TIMEOUT %q, using default %s", value, defaultTimeout)
+		}
+	}
+	if value := os.Getenv("LLM_MAX_RETRIES"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+			config.MaxRetries = parsed
+		} else {
+			log.Printf("Warning: invalid LLM_MAX_RETRIES %q, using default %d", value, defaultMaxRetries)
+		}
+	}
+	if value := os.Getenv("LLM_MAX_TOKENS"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			config.MaxTokens = parsed
+		} else {
+			log.Printf("Warning: invalid LLM_MAX_TOKENS %q, using default %d", value, defaultMaxTokens)
+		}
+	}
+	return config
+}
+
+// NewClient 設定のプロバイダーのクライアントを作成する（対応していないプロバイダーの場合は ErrUnknownProvider）
+func NewClient(config Config) (LLMClient, error) {
+	if config.Provider == "" {
+		config.Provider = ProviderOpenAI
+	}
+	if config.Model == "" {
+		config.Model = defaultModels[config.Provider]
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.MaxTokens <= 0 {
+		config.MaxTokens = defaultMaxTokens
+	}
+
+	switch config.Provider {
+	case ProviderOpenAI:
+		return newOpenAIClient(config), nil
+	case ProviderGemini:
+		return newGeminiClient(config), nil
+	case ProviderAnthropic:
+		return newAnthropicClient(config), nil
+	case ProviderFake:
+		return NewFakeClient(), nil
+	}
+	return nil, fmt.Errorf("%w: %q (openai / gemini / anthropic / fake)", ErrUnknownProvider, config.Provider)
+}
+
+// NewClientFromEnv 環境変数の設定でクライアントを作成する
+func NewClientFromEnv() (LLMClient, error) {
+	return NewClient(ConfigFromEnv())
+}
+
+// newTransport プロバイダー共通のHTTP送信（タイムアウト・再試行）を作成する
+func newTransport(provider string, config Config) *transport {
+	return &transport{
+		provider:   provider,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		maxRetries: config.MaxRetries,
+	}
+}
+
+// baseURL config.BaseURL が指定されていればそれを、無ければ defaultURL を返す
+func baseURL(config Config, defaultURL string) string {
+	if config.BaseURL != "" {
+		return strings.TrimSuffix(config.BaseURL, "/")
+	}
+	return defaultURL
+}
@@ -0,0 +1,38 @@
+package llm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrAPIKeyNotSet 選択したプロバイダーのAPIキーが未設定
+var ErrAPIKeyNotSet = errors.New("LLM API key is not set")
+
+// ErrUnknownProvider LLM_PROVIDER に対応していないプロバイダーが指定された
+var ErrUnknownProvider = errors.New("unknown LLM provider")
+
+// ErrEmptyResponse 正常応答だが本文が空（安全性フィルタでブロックされた場合など）
+var ErrEmptyResponse = errors.New("LLM returned an empty response")
+
+// APIError プロバイダーが返したエラー応答
+type APIError struct {
+	Provider   string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: status %d: %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// Retryable 時間を置けば成功する可能性があるエラー（レート制限・過負荷・サーバーエラー）か
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// IsRetryable err が再試行で解消し得る APIError か
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Retryable()
+}
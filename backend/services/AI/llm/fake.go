@@ -0,0 +1,95 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// fakeContentLength 入力から作る応答に含めるユーザーメッセージの最大文字数
+const fakeContentLength = 80
+
+// FakeClient APIを呼ばずに決定的な応答を返す LLMClient（APIキー無しでの動作確認・テスト用）
+//   - NewFakeClient に応答を渡した場合は、呼び出しごとに順に返す（最後まで使ったら先頭に戻る）
+//   - 応答を渡さない場合は、最後のユーザーメッセージの1行目から応答を作る（同じ入力には常に同じ応答）
+//
+// トークン使用量は文字数を疑似的なトークン数として返す。受け取ったリクエストは Requests で参照できる
+type FakeClient struct {
+	mu        sync.Mutex
+	responses []string
+	err       error
+	requests  []Request
+}
+
+func NewFakeClient(responses ...string) *FakeClient {
+	return &FakeClient{responses: responses}
+}
+
+func (f *FakeClient) Name() string {
+	return ProviderFake
+}
+
+func (f *FakeClient) Model() string {
+	return defaultModels[ProviderFake]
+}
+
+// SetError 以降の Complete で err を返す（nil で解除する）
+func (f *FakeClient) SetError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// Requests これまでに受け取ったリクエスト（古い順）
+func (f *FakeClient) Requests() []Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Request(nil), f.requests...)
+}
+
+func (f *FakeClient) Complete(ctx context.Context, req Request) (*Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	var content string
+	if len(f.responses) > 0 {
+		content = f.responses[(len(f.requests)-1)%len(f.responses)]
+	} else {
+		content = fmt.Sprintf("[fake] %s", summarizeUserMessage(req))
+	}
+
+	input := utf8.RuneCountInString(req.System)
+	for _, message := range req.Messages {
+		input += utf8.RuneCountInString(message.Content)
+	}
+	return &Response{
+		Content: content,
+		Model:   f.Model(),
+		Usage:   Usage{InputTokens: input, OutputTokens: utf8.RuneCountInString(content)},
+	}, nil
+}
+
+// summarizeUserMessage 最後のユーザーメッセージの1行目（長い場合は切り詰める）
+func summarizeUserMessage(req Request) string {
+	for i := len(req.Messages) - 1; i >= 0; i-- {
+		if req.Messages[i].Role != RoleUser {
+			continue
+		}
+		line, _, _ := strings.Cut(strings.TrimSpace(req.Messages[i].Content), "\n")
+		if runes := []rune(line); len(runes) > fakeContentLength {
+			line = string(runes[:fakeContentLength]) + "..."
+		}
+		return line
+	}
+	return ""
+}
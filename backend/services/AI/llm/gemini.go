@@ -0,0 +1,122 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const geminiBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+// geminiClient Gemini API（generateContent）のクライアント
+type geminiClient struct {
+	config    Config
+	baseURL   string
+	transport *transport
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiGenerationConfig struct {
+	MaxOutputTokens int      `json:"maxOutputTokens,omitempty"`
+	Temperature     *float64 `json:"temperature,omitempty"`
+}
+
+type geminiRequest struct {
+	SystemInstruction *geminiContent         `json:"systemInstruction,omitempty"`
+	Contents          []geminiContent        `json:"contents"`
+	GenerationConfig  geminiGenerationConfig `json:"generationConfig"`
+}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content      geminiContent `json:"content"`
+		FinishReason string        `json:"finishReason"`
+	} `json:"candidates"`
+	PromptFeedback struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+		ThoughtsTokenCount   int `json:"thoughtsTokenCount"`
+	} `json:"usageMetadata"`
+	ModelVersion string `json:"modelVersion"`
+}
+
+func newGeminiClient(config Config) *geminiClient {
+	return &geminiClient{
+		config:    config,
+		baseURL:   baseURL(config, geminiBaseURL),
+		transport: newTransport(ProviderGemini, config),
+	}
+}
+
+func (c *geminiClient) Name() string {
+	return ProviderGemini
+}
+
+func (c *geminiClient) Model() string {
+	return c.config.Model
+}
+
+func (c *geminiClient) Complete(ctx context.Context, req Request) (*Response, error) {
+	if c.config.APIKey == "" {
+		return nil, fmt.Errorf("%w (GEMINI_API_KEY)", ErrAPIKeyNotSet)
+	}
+
+	body := geminiRequest{
+		GenerationConfig: geminiGenerationConfig{
+			MaxOutputTokens: maxTokens(req, c.config),
+			Temperature:     req.Temperature,
+		},
+	}
+	if req.System != "" {
+		body.SystemInstruction = &geminiContent{Parts: []geminiPart{{Text: req.System}}}
+	}
+	for _, message := range req.Messages {
+		// Gemini ではアシスタントの発言のロールを "model" とする
+		role := message.Role
+		if role == RoleAssistant {
+			role = "model"
+		}
+		body.Contents = append(body.Contents, geminiContent{Role: role, Parts: []geminiPart{{Text: message.Content}}})
+	}
+
+	var resp geminiResponse
+	endpoint := fmt.Sprintf("%s/models/%s:generateContent", c.baseURL, url.PathEscape(c.config.Model))
+	headers := map[string]string{"x-goog-api-key": c.config.APIKey}
+	if err := c.transport.postJSON(ctx, endpoint, headers, body, &resp); err != nil {
+		return nil, err
+	}
+
+	// 安全性フィルタでブロックされた場合は200で candidates が空になる
+	if len(resp.Candidates) == 0 {
+		return nil, fmt.Errorf("%s: %w (blockReason: %q)", ProviderGemini, ErrEmptyResponse, resp.PromptFeedback.BlockReason)
+	}
+	var content strings.Builder
+	for _, part := range resp.Candidates[0].Content.Parts {
+		content.WriteString(part.Text)
+	}
+	if content.Len() == 0 {
+		return nil, fmt.Errorf("%s: %w (finishReason: %q)", ProviderGemini, ErrEmptyResponse, resp.Candidates[0].FinishReason)
+	}
+
+	return &Response{
+		Content: content.String(),
+		Model:   responseModel(resp.ModelVersion, c.config.Model),
+		Usage: Usage{
+			InputTokens: resp.UsageMetadata.PromptTokenCount,
+			// 思考モデルの思考トークンも出力トークンとして課金されるので含める
+			OutputTokens: resp.UsageMetadata.CandidatesTokenCount + resp.UsageMetadata.ThoughtsTokenCount,
+		},
+	}, nil
+}
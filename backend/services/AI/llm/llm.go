@@ -0,0 +1,70 @@
+// Package llm はAI分析で使うLLMのAPI（OpenAI・Gemini・Anthropic）を共通のインターフェースで呼び出す
+//
+// どのプロバイダー・モデルを使うかは Config（環境変数 LLM_PROVIDER / LLM_MODEL など）で切り替える。
+// 429・5xx・通信エラーは指数バックオフで再試行し、レスポンスにはトークン使用量を含めて返す。
+package llm
+
+import (
+	"context"
+	"fmt"
+)
+
+// メッセージの送り手（システムプロンプトは Request.System に指定する）
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Message 会話の1メッセージ
+type Message struct {
+	Role    string // RoleUser / RoleAssistant
+	Content string
+}
+
+// Request LLMへのリクエスト（プロバイダー共通の形式）
+type Request struct {
+	System      string
+	Messages    []Message
+	MaxTokens   int      // 出力トークンの上限（0の場合は Config.MaxTokens）
+	Temperature *float64 // nil の場合はプロバイダーの既定値
+}
+
+// Usage トークン使用量
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// TotalTokens 入力と出力の合計
+func (u Usage) TotalTokens() int {
+	return u.InputTokens + u.OutputTokens
+}
+
+// Add other の使用量を加算する（複数回の呼び出しの合計を集計する場合に使う）
+func (u *Usage) Add(other Usage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+}
+
+func (u Usage) String() string {
+	return fmt.Sprintf("%d tokens (input %d / output %d)", u.TotalTokens(), u.InputTokens, u.OutputTokens)
+}
+
+// Response LLMの応答（プロバイダー共通の形式）
+type Response struct {
+	Content string
+	Model   string // 実際に応答したモデル（プロバイダーが返さない場合はリクエストしたモデル）
+	Usage   Usage
+}
+
+// LLMClient LLMのAPIクライアント
+type LLMClient interface {
+	Name() string  // プロバイダー名（openai / gemini / anthropic / fake）
+	Model() string // リクエストに使うモデル
+	Complete(ctx context.Context, req Request) (*Response, error)
+}
+
+// UserPrompt システムプロンプトとユーザーの1メッセージだけのリクエストを作成する
+func UserPrompt(system string, user string) Request {
+	return Request{System: system, Messages: []Message{{Role: RoleUser, Content: user}}}
+}
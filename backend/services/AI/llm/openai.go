@@ -0,0 +1,111 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+)
+
+const openAIBaseURL = "https://api.openai.com/v1"
+
+// openAIClient OpenAI の Chat Completions API のクライアント
+type openAIClient struct {
+	config    Config
+	baseURL   string
+	transport *transport
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIRequest struct {
+	Model               string          `json:"model"`
+	Messages            []openAIMessage `json:"messages"`
+	MaxCompletionTokens int             `json:"max_completion_tokens,omitempty"`
+	Temperature         *float64        `json:"temperature,omitempty"`
+}
+
+type openAIResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+func newOpenAIClient(config Config) *openAIClient {
+	return &openAIClient{
+		config:    config,
+		baseURL:   baseURL(config, openAIBaseURL),
+		transport: newTransport(ProviderOpenAI, config),
+	}
+}
+
+func (c *openAIClient) Name() string {
+	return ProviderOpenAI
+}
+
+func (c *openAIClient) Model() string {
+	return c.config.Model
+}
+
+func (c *openAIClient) Complete(ctx context.Context, req Request) (*Response, error) {
+	if c.config.APIKey == "" {
+		return nil, fmt.Errorf("%w (OPENAI_API_KEY)", ErrAPIKeyNotSet)
+	}
+
+	// システムプロンプトは先頭の system メッセージとして送る
+	messages := make([]openAIMessage, 0, len(req.Messages)+1)
+	if req.System != "" {
+		messages = append(messages, openAIMessage{Role: "system", Content: req.System})
+	}
+	for _, message := range req.Messages {
+		messages = append(messages, openAIMessage{Role: message.Role, Content: message.Content})
+	}
+	body := openAIRequest{
+		Model:               c.config.Model,
+		Messages:            messages,
+		MaxCompletionTokens: maxTokens(req, c.config),
+		Temperature:         req.Temperature,
+	}
+
+	var resp openAIResponse
+	headers := map[string]string{"Authorization": "Bearer " + c.config.APIKey}
+	if err := c.transport.postJSON(ctx, c.baseURL+"/chat/completions", headers, body, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Choices) == 0 || resp.Choices[0].Message.Content == "" {
+		reason := ""
+		if len(resp.Choices) > 0 {
+			reason = resp.Choices[0].FinishReason
+		}
+		return nil, fmt.Errorf("%s: %w (finish_reason: %q)", ProviderOpenAI, ErrEmptyResponse, reason)
+	}
+
+	return &Response{
+		Content: resp.Choices[0].Message.Content,
+		Model:   responseModel(resp.Model, c.config.Model),
+		Usage:   Usage{InputTokens: resp.Usage.PromptTokens, OutputTokens: resp.Usage.CompletionTokens},
+	}, nil
+}
+
+// maxTokens リクエストで指定された出力トークンの上限（未指定の場合は設定の値）
+func maxTokens(req Request, config Config) int {
+	if req.MaxTokens > 0 {
+		return req.MaxTokens
+	}
+	return config.MaxTokens
+}
+
+// responseModel プロバイダーが返したモデル名（返さない場合はリクエストしたモデル）
+func responseModel(returned string, requested string) string {
+	if returned != "" {
+		return returned
+	}
+	return requested
+}
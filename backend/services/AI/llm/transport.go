@@ -0,0 +1,159 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	retryBaseDelay = 1 * time.Second
+	retryMaxDelay  = 30 * time.Second
+	// エラー応答の本文をエラーメッセージに含める最大の長さ
+	maxErrorBodyLength = 300
+)
+
+// transport JSONをPOSTし、429・5xx・通信エラーの場合は指数バックオフで再試行する
+type transport struct {
+	provider   string
+	httpClient *http.Client
+	maxRetries int
+}
+
+// postJSON body をJSONで送信し、200系の応答を out にデコードする
+// エラー応答は APIError に変換する（Retry-After が返された場合はその時間だけ待って再試行する）
+func (t *transport) postJSON(ctx context.Context, url string, headers map[string]string, body any, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", t.provider, err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := t.post(ctx, url, headers, payload, out)
+		if err == nil {
+			return nil
+		}
+		if attempt >= t.maxRetries || !t.retryable(ctx, err) {
+			return err
+		}
+
+		delay := retryDelay(attempt)
+		if retryAfter > delay {
+			delay = min(retryAfter, retryMaxDelay)
+		}
+		log.Printf("Warning: %s request failed (attempt %d/%d), retrying in %s: %v", t.provider, attempt+1, t.maxRetries+1, delay, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s request canceled while waiting to retry: %w", t.provider, errors.Join(ctx.Err(), err))
+		case <-time.After(delay):
+		}
+	}
+}
+
+// post 1回分の送信。エラー応答の場合は Retry-After の待ち時間も返す
+func (t *transport) post(ctx context.Context, url string, headers map[string]string, payload []byte, out any) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s request: %w", t.provider, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := t.httpClient.Do(req)
+	if err != nil {
+		return 0, &sendError{provider: t.provider, err: err}
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, &sendError{provider: t.provider, err: err}
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return parseRetryAfter(res.Header.Get("Retry-After")), &APIError{
+			Provider:   t.provider,
+			StatusCode: res.StatusCode,
+			Message:    errorMessage(res.StatusCode, respBody),
+		}
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return 0, fmt.Errorf("failed to decode %s response: %w", t.provider, err)
+	}
+	return 0, nil
+}
+
+// sendError 応答を受け取れなかった通信エラー（接続失敗・タイムアウト・切断）
+type sendError struct {
+	provider string
+	err      error
+}
+
+func (e *sendError) Error() string {
+	return fmt.Sprintf("failed to send %s request: %v", e.provider, e.err)
+}
+
+func (e *sendError) Unwrap() error {
+	return e.err
+}
+
+// retryable Retryable な APIError と通信エラーを再試行する（呼び出し元がキャンセルした・期限切れの場合は再試行しない）
+func (t *transport) retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var sendErr *sendError
+	return IsRetryable(err) || errors.As(err, &sendErr)
+}
+
+// retryDelay attempt 回目（0始まり）の失敗後の待ち時間
+func retryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+		if delay >= retryMaxDelay {
+			return retryMaxDelay
+		}
+	}
+	return delay
+}
+
+// parseRetryAfter Retry-After ヘッダー（秒数）を解釈する（無い・日付形式の場合は0）
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// errorMessage エラー応答の本文からメッセージを取り出す
+// OpenAI・Gemini・Anthropic はいずれも {"error": {"message": "..."}} の形で返す（本文が空の場合はステータスの説明）
+func errorMessage(statusCode int, body []byte) string {
+	var parsed struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Error.Message != "" {
+		return parsed.Error.Message
+	}
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		return http.StatusText(statusCode)
+	}
+	if len(message) > maxErrorBodyLength {
+		message = message[:maxErrorBodyLength] + "..."
+	}
+	return message
+}
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"stock-prediction/backend/models"
+	"sort"
 	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/calendar"
 	"stock-prediction/backend/utils"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -17,6 +19,11 @@ type AlphaVantageResponse struct {
 	TopGainers         []TickerData `json:"top_gainers"`          // 今回使うのはこれ
 	TopLosers          []TickerData `json:"top_losers"`           // 一応定義
 	MostActivelyTraded []TickerData `json:"most_actively_traded"` // 一応定義
+
+	// エラー時はHTTP 200のまま以下のいずれかだけが返る
+	Note         string `json:"Note"`          // レート制限（旧形式）
+	Information  string `json:"Information"`   // レート制限・プレミアム限定など
+	ErrorMessage string `json:"Error Message"` // APIキー不正・パラメータ不正など
 }
 
 type TickerData struct {
@@ -38,7 +45,7 @@ func FetchAlphaVantageData(apiKey string) (*AlphaVantageResponse, error) {
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch data: %s", res.Status)
+		return nil, newProviderError("alphavantage", ErrKindUpstreamError, "%s", res.Status)
 	}
 
 	var result AlphaVantageResponse
@@ -46,33 +53,36 @@ func FetchAlphaVantageData(apiKey string) (*AlphaVantageResponse, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode data: %w", err)
 	}
+	if err := alphaVantageError(result.Note, result.Information, result.ErrorMessage); err != nil {
+		return nil, err
+	}
 
 	return &result, nil
 }
 
 func SaveAlphaVantageDatatoDB(alphaData *AlphaVantageResponse, repo repositories.IStockRepository) error {
-	// Alpha Vantage APIのlast_updatedから日付を抽出
-	date, err := extractDateFromLastUpdated(alphaData.LastUpdated)
+	movers, err := alphaData.toMovers()
 	if err != nil {
-		return fmt.Errorf("failed to extract date from last_updated: %w", err)
-	}
-
-	// Top Gainersを保存
-	if err := saveTickerDataToDB(alphaData.TopGainers, "Top Gainers", date, repo); err != nil {
-		return fmt.Errorf("failed to save top gainers: %w", err)
-	}
-
-	// Top Losersを保存
-	if err := saveTickerDataToDB(alphaData.TopLosers, "Top Losers", date, repo); err != nil {
-		return fmt.Errorf("failed to save top losers: %w", err)
+		return err
 	}
+	return SaveMoversToDB(movers, repo)
+}
 
-	// Most Actively Tradedを保存
-	if err := saveTickerDataToDB(alphaData.MostActivelyTraded, "Most Actively Traded", date, repo); err != nil {
-		return fmt.Errorf("failed to save most actively traded: %w", err)
+// toMovers Alpha Vantageのレスポンスをプロバイダー共通の形式に変換する
+func (alphaData *AlphaVantageResponse) toMovers() (*Movers, error) {
+	// Alpha Vantage APIのlast_updatedから日付を抽出
+	date, err := extractDateFromLastUpdated(alphaData.LastUpdated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract date from last_updated: %w", err)
 	}
 
-	return nil
+	return &Movers{
+		Provider:           "alphavantage",
+		Date:               date,
+		TopGainers:         tickerDataToMovers(alphaData.TopGainers),
+		TopLosers:          tickerDataToMovers(alphaData.TopLosers),
+		MostActivelyTraded: tickerDataToMovers(alphaData.MostActivelyTraded),
+	}, nil
 }
 
 // extractDateFromLastUpdated はAlpha Vantage APIのlast_updatedフィールドから日付を抽出します
@@ -86,43 +96,243 @@ func extractDateFromLastUpdated(lastUpdated string) (string, error) {
 
 	dateStr := parts[0] // "2025-11-28"
 
-	// 日付フォーマットの検証（YYYY-MM-DD）
-	_, err := time.Parse("2006-01-02", dateStr)
+	// 日付フォーマットを検証し、休場日（週末に更新された場合など）は直前の取引日に丸める
+	tradingDate, err := calendar.NYSE.SnapDate(dateStr)
 	if err != nil {
 		return "", fmt.Errorf("invalid date format in last_updated '%s': %w", lastUpdated, err)
 	}
 
-	return dateStr, nil
+	return tradingDate, nil
 }
 
-func saveTickerDataToDB(tickerDataList []TickerData, category string, date string, repo repositories.IStockRepository) error {
-	for rank, tickerData := range tickerDataList {
-		stock := &models.Stock{
-			Ticker:   tickerData.Ticker,
-			Name:     "", // Alpha Vantageから取得できないので空文字
-			Sector:   "", // 同上
-			Industry: "", // 同上
-		}
-
-		if err := repo.CreateOrUpdateStock(stock); err != nil {
-			return fmt.Errorf("failed to create/update stock %s: %w", tickerData.Ticker, err)
-		}
-
-		ranking := &models.DailyRanking{
-			StockID:      stock.ID,
-			Date:         date,
-			Rank:         rank + 1, // 1位から始まる
-			Category:     category,
+func tickerDataToMovers(tickerDataList []TickerData) []Mover {
+	movers := make([]Mover, 0, len(tickerDataList))
+	for _, tickerData := range tickerDataList {
+		movers = append(movers, Mover{
+			Ticker:       tickerData.Ticker,
+			Price:        utils.ParseFloat(tickerData.Price),
 			ChangeAmount: utils.ParseFloat(tickerData.ChangeAmount),
 			ChangeRate:   utils.ParsePercentage(tickerData.ChangePercentage),
-			Price:        utils.ParseFloat(tickerData.Price),
-			NewsSummary:  "", // 後で設定
-			AiAnalysis:   "", // 後で設定
-		}
+		})
+	}
+	return movers
+}
+
+// AlphaVantageOverviewResponse OVERVIEWエンドポイントのレスポンス（数値も文字列で返る）
+type AlphaVantageOverviewResponse struct {
+	Symbol               string `json:"Symbol"`
+	Name                 string `json:"Name"`
+	Description          string `json:"Description"`
+	Exchange             string `json:"Exchange"`
+	Country              string `json:"Country"`
+	Sector               string `json:"Sector"`
+	Industry             string `json:"Industry"`
+	OfficialSite         string `json:"OfficialSite"`
+	MarketCapitalization string `json:"MarketCapitalization"`
+	Beta                 string `json:"Beta"`
+	DividendPerShare     string `json:"DividendPerShare"`
+	EPS                  string `json:"EPS"`       // 直近12ヶ月の1株当たり利益
+	BookValue            string `json:"BookValue"` // 1株当たり純資産
+
+	Note         string `json:"Note"`
+	Information  string `json:"Information"`
+	ErrorMessage string `json:"Error Message"`
+}
+
+func FetchAlphaVantageOverview(ticker string, apiKey string) (*AlphaVantageOverviewResponse, error) {
+	url := fmt.Sprintf("https://www.alphavantage.co/query?function=OVERVIEW&symbol=%s&apikey=%s", ticker, apiKey)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch overview: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newProviderError("alphavantage", ErrKindUpstreamError, "overview: %s", res.Status)
+	}
+
+	var result AlphaVantageOverviewResponse
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode overview: %w", err)
+	}
+	if err := alphaVantageError(result.Note, result.Information, result.ErrorMessage); err != nil {
+		return nil, err
+	}
+	// 存在しないtickerの場合は空のオブジェクトが返る
+	if result.Symbol == "" {
+		return nil, newProviderError("alphavantage", ErrKindEmpty, "overview for %s not found", ticker)
+	}
 
-		if err := repo.CreateOrUpdateDailyRanking(ranking); err != nil {
-			return fmt.Errorf("failed to create/update daily ranking for %s: %w", tickerData.Ticker, err)
+	return &result, nil
+}
+
+// AlphaVantageDailyResponse TIME_SERIES_DAILYのレスポンス（数値も文字列で返る）
+type AlphaVantageDailyResponse struct {
+	TimeSeries map[string]AlphaVantageDailyBar `json:"Time Series (Daily)"` // キーは日付（YYYY-MM-DD）
+
+	Note         string `json:"Note"`
+	Information  string `json:"Information"`
+	ErrorMessage string `json:"Error Message"`
+}
+
+type AlphaVantageDailyBar struct {
+	Open   string `json:"1. open"`
+	High   string `json:"2. high"`
+	Low    string `json:"3. low"`
+	Close  string `json:"4. close"`
+	Volume string `json:"5. volume"`
+}
+
+// FetchAlphaVantageDaily 直近100営業日分の日足を取得する
+// 無料プランでは outputsize=full（20年分）とTIME_SERIES_DAILY_ADJUSTEDがプレミアム限定のため compact を使う
+func FetchAlphaVantageDaily(ticker string, apiKey string) (*AlphaVantageDailyResponse, error) {
+	url := fmt.Sprintf("https://www.alphavantage.co/query?function=TIME_SERIES_DAILY&symbol=%s&outputsize=compact&apikey=%s", ticker, apiKey)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch daily time series: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newProviderError("alphavantage", ErrKindUpstreamError, "daily time series: %s", res.Status)
+	}
+
+	var result AlphaVantageDailyResponse
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode daily time series: %w", err)
+	}
+	if err := alphaVantageError(result.Note, result.Information, result.ErrorMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// AlphaVantageProvider Alpha Vantageをデータソースとするプロバイダー
+type AlphaVantageProvider struct {
+	apiKey string
+}
+
+// NewAlphaVantageProvider Alpha Vantageのプロバイダーを作成する（MoversProvider / ProfileProvider / BarsProvider / PerShareProvider を満たす）
+func NewAlphaVantageProvider(apiKey string) *AlphaVantageProvider {
+	return &AlphaVantageProvider{apiKey: apiKey}
+}
+
+func (p *AlphaVantageProvider) Name() string {
+	return "alphavantage"
+}
+
+func (p *AlphaVantageProvider) FetchMovers() (*Movers, error) {
+	if p.apiKey == "" {
+		return nil, newProviderError(p.Name(), ErrKindInvalidKey, "ALPHA_VANTAGE_API_KEY is not set")
+	}
+
+	alphaData, err := FetchAlphaVantageData(p.apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(alphaData.TopGainers) == 0 {
+		return nil, newProviderError(p.Name(), ErrKindEmpty, "no top gainers returned")
+	}
+
+	return alphaData.toMovers()
+}
+
+func (p *AlphaVantageProvider) FetchProfile(ticker string) (*CompanyProfile, error) {
+	if p.apiKey == "" {
+		return nil, newProviderError(p.Name(), ErrKindInvalidKey, "ALPHA_VANTAGE_API_KEY is not set")
+	}
+
+	overview, err := FetchAlphaVantageOverview(ticker, p.apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// OVERVIEWは出来高・従業員数などを返さないため、それらはゼロ値のまま
+	return &CompanyProfile{
+		Ticker:       ticker,
+		Name:         overview.Name,
+		Sector:       overview.Sector,
+		Industry:     overview.Industry,
+		Description:  overview.Description,
+		Website:      overview.OfficialSite,
+		Country:      overview.Country,
+		MarketCap:    utils.ParseFloat(overview.MarketCapitalization),
+		Beta:         utils.ParseFloat(overview.Beta),
+		LastDividend: utils.ParseFloat(overview.DividendPerShare),
+	}, nil
+}
+
+// FetchDailyBars Alpha Vantageは直近100営業日分しか返さないので、from がそれより古い場合は取得できた分だけを返す
+// 無料プランでは調整後の値を取得できないので、調整後の値には調整前と同じ値を入れる
+// （FMPで同期した時に、取得元の違いとして SyncDailyBars が調整後の値で取得し直す）
+func (p *AlphaVantageProvider) FetchDailyBars(ticker string, from string, to string) (*DailyBars, error) {
+	if p.apiKey == "" {
+		return nil, newProviderError(p.Name(), ErrKindInvalidKey, "ALPHA_VANTAGE_API_KEY is not set")
+	}
+
+	daily, err := FetchAlphaVantageDaily(ticker, p.apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	bars := make([]DailyBar, 0, len(daily.TimeSeries))
+	for date, bar := range daily.TimeSeries {
+		if date < from || date > to {
+			continue
+		}
+		dailyBar := DailyBar{
+			Date:   date,
+			Open:   utils.ParseFloat(bar.Open),
+			High:   utils.ParseFloat(bar.High),
+			Low:    utils.ParseFloat(bar.Low),
+			Close:  utils.ParseFloat(bar.Close),
+			Volume: utils.ParseInt(bar.Volume),
 		}
+		dailyBar.AdjustmentOpen = dailyBar.Open
+		dailyBar.AdjustmentHigh = dailyBar.High
+		dailyBar.AdjustmentLow = dailyBar.Low
+		dailyBar.AdjustmentClose = dailyBar.Close
+		dailyBar.AdjustmentVolume = float64(dailyBar.Volume)
+		bars = append(bars, dailyBar)
+	}
+	sort.Slice(bars, func(i, j int) bool {
+		return bars[i].Date < bars[j].Date
+	})
+
+	return &DailyBars{Provider: p.Name(), Ticker: ticker, Bars: bars}, nil
+}
+
+func (p *AlphaVantageProvider) FetchPerShare(ticker string) (*PerShare, error) {
+	if p.apiKey == "" {
+		return nil, newProviderError(p.Name(), ErrKindInvalidKey, "ALPHA_VANTAGE_API_KEY is not set")
+	}
+
+	overview, err := FetchAlphaVantageOverview(ticker, p.apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PerShare{
+		Provider:          p.Name(),
+		Ticker:            ticker,
+		EarningsPerShare:  parseOverviewNumber(overview.EPS),
+		BookValuePerShare: parseOverviewNumber(overview.BookValue),
+		DividendPerShare:  parseOverviewNumber(overview.DividendPerShare),
+	}, nil
+}
+
+// parseOverviewNumber OVERVIEWの数値を変換する（値が無い場合は "None" や "-" が返るので nil）
+func parseOverviewNumber(s string) *float64 {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
 	}
-	return nil
+	return &value
 }
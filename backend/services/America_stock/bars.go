@@ -0,0 +1,190 @@
+package america_stock
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/calendar"
+)
+
+// 初めて日足を取得する銘柄（と調整後の値を取得し直す銘柄）は過去1年分を取得する
+const initialBarsYears = 1
+
+// 保存済みの調整後終値と取得し直した値の相対誤差がこれを超えたら、分割・配当で過去の値が変わったとみなす
+const restatementTolerance = 1e-6
+
+// DailyBar 日足1本分（プロバイダー共通の形式）
+type DailyBar struct {
+	Date   string // 取引日（YYYY-MM-DD, 米国時間）
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume int64
+	// 分割・配当の調整後の値（調整後の値を返さないプロバイダーは調整前と同じ値）
+	AdjustmentOpen   float64
+	AdjustmentHigh   float64
+	AdjustmentLow    float64
+	AdjustmentClose  float64
+	AdjustmentVolume float64
+}
+
+// DailyBars 1銘柄分の日足（プロバイダー共通の形式）
+type DailyBars struct {
+	Provider string
+	Ticker   string
+	Bars     []DailyBar // 日付の古い順
+	Adjusted bool       // Adjustment* に分割・配当の調整後の値が入っているか
+}
+
+// BarsProvider 日足の取得元
+type BarsProvider interface {
+	Name() string
+	// FetchDailyBars from〜to（YYYY-MM-DD, 両端を含む）の日足を返す
+	FetchDailyBars(ticker string, from string, to string) (*DailyBars, error)
+}
+
+// barsFallback 複数のBarsProviderを順に試し、最初に成功した結果を返す
+type barsFallback struct {
+	providers []BarsProvider
+}
+
+// NewBarsFallback 先頭から順に試すBarsProviderを作成する（例: FMP → Alpha Vantage）
+func NewBarsFallback(providers ...BarsProvider) BarsProvider {
+	return &barsFallback{providers: providers}
+}
+
+func (f *barsFallback) Name() string {
+	return "fallback"
+}
+
+func (f *barsFallback) FetchDailyBars(ticker string, from string, to string) (*DailyBars, error) {
+	var errs []error
+	for _, provider := range f.providers {
+		bars, err := provider.FetchDailyBars(ticker, from, to)
+		if err == nil {
+			return bars, nil
+		}
+		log.Printf("Warning: bars provider %s failed for %s, trying next: %v", provider.Name(), ticker, err)
+		errs = append(errs, withProviderName(provider.Name(), err))
+	}
+	return nil, fmt.Errorf("all bars providers failed for %s: %w", ticker, errors.Join(errs...))
+}
+
+// SaveDailyBarsToDB 日足をStockDailyBarに保存する（同じ日付は上書き）
+func SaveDailyBarsToDB(bars *DailyBars, repo repositories.IStockRepository) error {
+	if len(bars.Bars) == 0 {
+		return nil
+	}
+
+	stock, err := repo.FindStockByTicker(bars.Ticker)
+	if err != nil {
+		return fmt.Errorf("stock %s not found in DB: %w", bars.Ticker, err)
+	}
+	return repo.UpsertDailyBars(toStockDailyBars(stock.ID, bars))
+}
+
+func toStockDailyBars(stockID uint, bars *DailyBars) []models.StockDailyBar {
+	records := make([]models.StockDailyBar, 0, len(bars.Bars))
+	for _, bar := range bars.Bars {
+		records = append(records, models.StockDailyBar{
+			StockID:          stockID,
+			Date:             bar.Date,
+			Open:             bar.Open,
+			High:             bar.High,
+			Low:              bar.Low,
+			Close:            bar.Close,
+			Volume:           bar.Volume,
+			AdjustmentOpen:   bar.AdjustmentOpen,
+			AdjustmentHigh:   bar.AdjustmentHigh,
+			AdjustmentLow:    bar.AdjustmentLow,
+			AdjustmentClose:  bar.AdjustmentClose,
+			AdjustmentVolume: bar.AdjustmentVolume,
+			Provider:         bars.Provider,
+		})
+	}
+	return records
+}
+
+// SyncDailyBars 保存済みの最新日から to までの日足を取得して保存する
+// 未保存の銘柄は過去1年分を取得し、to まで保存済みの場合はプロバイダーを呼ばない
+//
+// 分割・配当があると過去の調整後の値が全て変わるので、保存済みの最新日も取得し直して調整後終値を比べる。
+// 値が変わっていた（または調整後の値を返さないプロバイダーで保存した日足だった）場合は過去1年分を取得し直し、保存済みの日足を置き換える
+func SyncDailyBars(ticker string, to string, repo repositories.IStockRepository, provider BarsProvider) error {
+	stock, err := repo.FindStockByTicker(ticker)
+	if err != nil {
+		return fmt.Errorf("stock %s not found in DB: %w", ticker, err)
+	}
+
+	toDate, err := calendar.NYSE.ParseDate(to)
+	if err != nil {
+		return err
+	}
+	from := toDate.AddDate(-initialBarsYears, 0, 0).Format("2006-01-02")
+
+	latest, err := repo.FindLatestDailyBarDate(stock.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find latest bar for %s: %w", ticker, err)
+	}
+	if latest == "" {
+		bars, err := provider.FetchDailyBars(ticker, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to fetch daily bars for %s: %w", ticker, err)
+		}
+		if err := SaveDailyBarsToDB(bars, repo); err != nil {
+			return fmt.Errorf("failed to save daily bars for %s: %w", ticker, err)
+		}
+		return nil
+	}
+	if latest >= to {
+		return nil
+	}
+
+	stored, err := repo.FindDailyBars(ticker, latest, latest)
+	if err != nil {
+		return fmt.Errorf("failed to find latest bar for %s: %w", ticker, err)
+	}
+	bars, err := provider.FetchDailyBars(ticker, latest, to)
+	if err != nil {
+		return fmt.Errorf("failed to fetch daily bars for %s: %w", ticker, err)
+	}
+
+	if len(*stored) == 0 || !restated((*stored)[0], bars) {
+		if err := SaveDailyBarsToDB(bars, repo); err != nil {
+			return fmt.Errorf("failed to save daily bars for %s: %w", ticker, err)
+		}
+		return nil
+	}
+
+	log.Printf("Adjusted daily bars for %s changed since %s (split, dividend or provider change), re-fetching from %s", ticker, latest, from)
+	bars, err = provider.FetchDailyBars(ticker, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to re-fetch daily bars for %s: %w", ticker, err)
+	}
+	if err := repo.ReplaceDailyBars(stock.ID, toStockDailyBars(stock.ID, bars)); err != nil {
+		return fmt.Errorf("failed to replace daily bars for %s: %w", ticker, err)
+	}
+	return nil
+}
+
+// restated 保存済みの日足 stored と、同じ日付を取得し直した bars の調整後終値・取得元が変わったか
+// bars が調整後の値を含まない場合と、bars に同じ日付が無い場合は比べられないので false
+func restated(stored models.StockDailyBar, bars *DailyBars) bool {
+	if !bars.Adjusted {
+		return false
+	}
+	for _, bar := range bars.Bars {
+		if bar.Date != stored.Date {
+			continue
+		}
+		if stored.Provider != bars.Provider || stored.AdjustmentClose == 0 {
+			return true
+		}
+		return math.Abs(bar.AdjustmentClose-stored.AdjustmentClose) > restatementTolerance*math.Abs(stored.AdjustmentClose)
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package america_stock
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ProviderErrorKind プロバイダーが正常なデータを返さなかった理由
+type ProviderErrorKind string
+
+const (
+	ErrKindRateLimited   ProviderErrorKind = "rate_limited"   // 呼び出し回数の上限に達した
+	ErrKindInvalidKey    ProviderErrorKind = "invalid_key"    // APIキーが未設定・無効
+	ErrKindPremiumOnly   ProviderErrorKind = "premium_only"   // 有料プランでのみ利用可能
+	ErrKindEmpty         ProviderErrorKind = "empty"          // 正常応答だがデータが空
+	ErrKindUpstreamError ProviderErrorKind = "upstream_error" // 上記以外のエラー応答
+)
+
+// ProviderError プロバイダーのエラー応答を分類したエラー
+// HTTP 200でエラーメッセージだけを返すAPI（Alpha Vantageなど）もこの形に変換する
+type ProviderError struct {
+	Provider string            `json:"Provider"`
+	Kind     ProviderErrorKind `json:"Kind"`
+	Message  string            `json:"Message"`
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Provider, e.Kind, e.Message)
+}
+
+func newProviderError(provider string, kind ProviderErrorKind, format string, args ...any) *ProviderError {
+	return &ProviderError{Provider: provider, Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// ProviderErrors エラーチェーン（errors.Joinを含む）からProviderErrorを全て取り出す
+// フォールバックで複数のプロバイダーが失敗した場合、それぞれの理由を返す
+func ProviderErrors(err error) []*ProviderError {
+	if err == nil {
+		return nil
+	}
+
+	switch e := err.(type) {
+	case *ProviderError:
+		return []*ProviderError{e}
+	case interface{ Unwrap() []error }:
+		var result []*ProviderError
+		for _, inner := range e.Unwrap() {
+			result = append(result, ProviderErrors(inner)...)
+		}
+		return result
+	default:
+		return ProviderErrors(errors.Unwrap(err))
+	}
+}
+
+// classifyAlphaVantageMessage Alpha Vantageの "Note" / "Information" / "Error Message" を分類する
+// Alpha VantageはいずれもHTTP 200で返すため、本文の内容で判断するしかない
+func classifyAlphaVantageMessage(key string, message string) ProviderErrorKind {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "premium"):
+		return ErrKindPremiumOnly
+	case strings.Contains(lower, "apikey") || (strings.Contains(lower, "api key") && strings.Contains(lower, "invalid")):
+		return ErrKindInvalidKey
+	case key == "Note" || strings.Contains(lower, "rate limit") || strings.Contains(lower, "requests per"):
+		return ErrKindRateLimited
+	case key == "Error Message":
+		return ErrKindUpstreamError
+	default:
+		// "Information" はほとんどがレート制限の通知
+		return ErrKindRateLimited
+	}
+}
+
+// alphaVantageError レスポンスにエラー系のキーが含まれていればProviderErrorを返す
+func alphaVantageError(note string, information string, errorMessage string) error {
+	switch {
+	case errorMessage != "":
+		return newProviderError("alphavantage", classifyAlphaVantageMessage("Error Message", errorMessage), "%s", errorMessage)
+	case information != "":
+		return newProviderError("alphavantage", classifyAlphaVantageMessage("Information", information), "%s", information)
+	case note != "":
+		return newProviderError("alphavantage", classifyAlphaVantageMessage("Note", note), "%s", note)
+	}
+	return nil
+}
+
+// fmpStatusError FMPのHTTPステータスをProviderErrorに変換する
+func fmpStatusError(endpoint string, res *http.Response) error {
+	switch res.StatusCode {
+	case http.StatusUnauthorized:
+		return newProviderError("fmp", ErrKindInvalidKey, "%s: %s", endpoint, res.Status)
+	case http.StatusPaymentRequired, http.StatusForbidden:
+		return newProviderError("fmp", ErrKindPremiumOnly, "%s: %s", endpoint, res.Status)
+	case http.StatusTooManyRequests:
+		return newProviderError("fmp", ErrKindRateLimited, "%s: %s", endpoint, res.Status)
+	}
+	return newProviderError("fmp", ErrKindUpstreamError, "%s: %s", endpoint, res.Status)
+}
+
+// fmpMessageError FMPがJSON配列の代わりに返す {"Error Message": "..."} を分類する
+func fmpMessageError(endpoint string, message string) error {
+	lower := strings.ToLower(message)
+	kind := ErrKindUpstreamError
+	switch {
+	case strings.Contains(lower, "limit reach"):
+		kind = ErrKindRateLimited
+	case strings.Contains(lower, "invalid api key"):
+		kind = ErrKindInvalidKey
+	case strings.Contains(lower, "exclusive endpoint") || strings.Contains(lower, "special endpoint") || strings.Contains(lower, "subscription"):
+		kind = ErrKindPremiumOnly
+	}
+	return newProviderError("fmp", kind, "%s: %s", endpoint, message)
+}
@@ -1,11 +1,14 @@
 package america_stock
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
-	"stock-prediction/backend/models"
+	"sort"
 	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/calendar"
 	"stock-prediction/backend/utils"
 	"time"
 )
@@ -60,75 +63,359 @@ func FetchFMPData(ticker string, apiKey string) (*FMPResponse, error) {
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("データの取得に失敗しました(Status関連で): %s", res.Status)
+		return nil, fmpStatusError("profile", res)
 	}
 
 	var result []FMPResponse
-	err = json.NewDecoder(res.Body).Decode(&result)
-	if err != nil {
-		return nil, fmt.Errorf("データのでコードに失敗しました: %w", err)
+	if err := decodeFMPArray(res, "profile", &result); err != nil {
+		return nil, err
 	}
 	if len(result) == 0 {
-		return nil, fmt.Errorf("ticker: %s のデータが見つかりませんでした", ticker)
+		return nil, newProviderError("fmp", ErrKindEmpty, "ticker: %s のデータが見つかりませんでした", ticker)
 	}
 
 	return &result[0], nil
 }
 
 func SaveFMPDatatoDB(fmpData *FMPResponse, repo repositories.IStockRepository) error {
-	// 既存のStockテーブルのデータを取得
-	stock, err := repo.FindStockByTicker(fmpData.Symbol)
+	return SaveProfileToDB(fmpData.toProfile(), "", repo)
+}
+
+// toProfile FMPのプロファイルをプロバイダー共通の形式に変換する
+func (fmpData *FMPResponse) toProfile() *CompanyProfile {
+	return &CompanyProfile{
+		Ticker:            fmpData.Symbol,
+		Name:              fmpData.CompanyName,
+		Sector:            fmpData.Sector,
+		Industry:          fmpData.Industry,
+		Description:       fmpData.Description,
+		Website:           fmpData.Website,
+		Country:           fmpData.Country,
+		FullTimeEmployees: int(utils.ParseInt(fmpData.FullTimeEmployees)),
+		Image:             fmpData.Image,
+		IpoDate:           fmpData.IpoDate,
+		CEO:               fmpData.CEO,
+		MarketCap:         fmpData.MarketCap,
+		Volume:            fmpData.Volume,
+		AverageVolume:     fmpData.AverageVolume,
+		Beta:              fmpData.Beta,
+		LastDividend:      fmpData.LastDividend,
+	}
+}
+
+// FMPMoverResponse biggest-gainers / biggest-losers / most-actives のレスポンス1件分
+type FMPMoverResponse struct {
+	Symbol            string  `json:"symbol"`
+	Price             float64 `json:"price"`
+	Name              string  `json:"name"`
+	Change            float64 `json:"change"`
+	ChangesPercentage float64 `json:"changesPercentage"`
+	Exchange          string  `json:"exchange"`
+}
+
+// FetchFMPMovers ランキング系エンドポイント（"biggest-gainers" / "biggest-losers" / "most-actives"）を取得する
+func FetchFMPMovers(endpoint string, apiKey string) ([]FMPMoverResponse, error) {
+	url := fmt.Sprintf("https://financialmodelingprep.com/stable/%s?apikey=%s", endpoint, apiKey)
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	res, err := client.Get(url)
 	if err != nil {
-		return fmt.Errorf("stock %s not found in DB: %w", fmpData.Symbol, err)
+		return nil, fmt.Errorf("failed to fetch %s: %w", endpoint, err)
 	}
-	// 静的データをStockテーブルに保存
-	if stock.Name == "" {
-		stock.Name = fmpData.CompanyName
-		stock.Sector = fmpData.Sector
-		stock.Industry = fmpData.Industry
-		stock.Description = fmpData.Description
-		stock.Website = fmpData.Website
-		stock.Country = fmpData.Country
-		stock.FullTimeEmployees = int(utils.ParseInt(fmpData.FullTimeEmployees))
-		stock.Image = fmpData.Image
-		stock.IpoDate = fmpData.IpoDate
-		stock.CEO = fmpData.CEO
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmpStatusError(endpoint, res)
 	}
-	if err := repo.UpdateStock(stock); err != nil {
-		return fmt.Errorf("failed to create/update stock %s: %w", fmpData.Symbol, err)
+
+	var result []FMPMoverResponse
+	if err := decodeFMPArray(res, endpoint, &result); err != nil {
+		return nil, err
 	}
 
-	// 動的データをStockMetricテーブルに保存
-	today := time.Now().Format("2006-01-02")
+	return result, nil
+}
+
+// FMPHistoricalPriceResponse historical-price-eod/full のレスポンス1件分
+type FMPHistoricalPriceResponse struct {
+	Symbol        string  `json:"symbol"`
+	Date          string  `json:"date"`
+	Open          float64 `json:"open"`
+	High          float64 `json:"high"`
+	Low           float64 `json:"low"`
+	Close         float64 `json:"close"`
+	Volume        int64   `json:"volume"`
+	Change        float64 `json:"change"`
+	ChangePercent float64 `json:"changePercent"`
+	Vwap          float64 `json:"vwap"`
+}
 
-	metric := &models.StockMetric{
-		StockID:       stock.ID,
-		Date:          today,
-		MarketCap:     fmpData.MarketCap,
-		Volume:        fmpData.Volume,
-		AverageVolume: fmpData.AverageVolume,
-		Beta:          fmpData.Beta,
-		LastDividend:  fmpData.LastDividend,
+// FMPDividendAdjustedPriceResponse historical-price-eod/dividend-adjusted のレスポンス1件分（分割・配当の調整後の値）
+type FMPDividendAdjustedPriceResponse struct {
+	Symbol   string  `json:"symbol"`
+	Date     string  `json:"date"`
+	AdjOpen  float64 `json:"adjOpen"`
+	AdjHigh  float64 `json:"adjHigh"`
+	AdjLow   float64 `json:"adjLow"`
+	AdjClose float64 `json:"adjClose"`
+	Volume   float64 `json:"volume"`
+}
+
+// FetchFMPDividendAdjustedPrices from〜to（YYYY-MM-DD, 両端を含む）の分割・配当の調整後の日足を取得する
+func FetchFMPDividendAdjustedPrices(ticker string, from string, to string, apiKey string) ([]FMPDividendAdjustedPriceResponse, error) {
+	url := fmt.Sprintf("https://financialmodelingprep.com/stable/historical-price-eod/dividend-adjusted?symbol=%s&from=%s&to=%s&apikey=%s", ticker, from, to, apiKey)
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch dividend adjusted prices: %w", err)
 	}
+	defer res.Body.Close()
 
-	if err := repo.UpdateStockMetric(metric); err != nil {
-		return fmt.Errorf("failed to update stock metric %s: %w", fmpData.Symbol, err)
+	if res.StatusCode != http.StatusOK {
+		return nil, fmpStatusError("historical-price-eod/dividend-adjusted", res)
 	}
 
-	return nil
+	var result []FMPDividendAdjustedPriceResponse
+	if err := decodeFMPArray(res, "historical-price-eod/dividend-adjusted", &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
-func SyncCompanyInfo(ticker string, repo repositories.IStockRepository, apiKey string) error {
-	// FMP APIから企業データを取得
-	fmpData, err := FetchFMPData(ticker, apiKey)
+// FetchFMPHistoricalPrices from〜to（YYYY-MM-DD, 両端を含む）の日足を取得する（新しい日付から順に返る）
+func FetchFMPHistoricalPrices(ticker string, from string, to string, apiKey string) ([]FMPHistoricalPriceResponse, error) {
+	url := fmt.Sprintf("https://financialmodelingprep.com/stable/historical-price-eod/full?symbol=%s&from=%s&to=%s&apikey=%s", ticker, from, to, apiKey)
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	res, err := client.Get(url)
 	if err != nil {
-		return fmt.Errorf("failed to fetch FMP data for %s: %w", ticker, err)
+		return nil, fmt.Errorf("failed to fetch historical prices: %w", err)
 	}
+	defer res.Body.Close()
 
-	// FMP APIから取得した企業データをDBに保存
-	if err := SaveFMPDatatoDB(fmpData, repo); err != nil {
-		return fmt.Errorf("failed to save FMP data for %s: %w", ticker, err)
+	if res.StatusCode != http.StatusOK {
+		return nil, fmpStatusError("historical-price-eod", res)
 	}
 
+	var result []FMPHistoricalPriceResponse
+	if err := decodeFMPArray(res, "historical-price-eod", &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FMPRatiosTTMResponse ratios-ttm のレスポンス（直近12ヶ月の財務比率。必要な項目のみ）
+type FMPRatiosTTMResponse struct {
+	Symbol                  string   `json:"symbol"`
+	NetIncomePerShareTTM    *float64 `json:"netIncomePerShareTTM"`
+	BookValuePerShareTTM    *float64 `json:"bookValuePerShareTTM"`
+	DividendPerShareTTM     *float64 `json:"dividendPerShareTTM"`
+	PriceToEarningsRatioTTM *float64 `json:"priceToEarningsRatioTTM"`
+	PriceToBookRatioTTM     *float64 `json:"priceToBookRatioTTM"`
+	DividendYieldTTM        *float64 `json:"dividendYieldTTM"`
+}
+
+// FetchFMPRatiosTTM 直近12ヶ月の財務比率を取得する
+func FetchFMPRatiosTTM(ticker string, apiKey string) (*FMPRatiosTTMResponse, error) {
+	url := fmt.Sprintf("https://financialmodelingprep.com/stable/ratios-ttm?symbol=%s&apikey=%s", ticker, apiKey)
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ratios-ttm: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmpStatusError("ratios-ttm", res)
+	}
+
+	var result []FMPRatiosTTMResponse
+	if err := decodeFMPArray(res, "ratios-ttm", &result); err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, newProviderError("fmp", ErrKindEmpty, "ratios-ttm for %s not found", ticker)
+	}
+
+	return &result[0], nil
+}
+
+// decodeFMPArray JSON配列をデコードする
+// FMPはエラー時にHTTP 200のまま {"Error Message": "..."} を返すことがあるので、その場合はProviderErrorにする
+func decodeFMPArray(res *http.Response, endpoint string, v any) error {
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", endpoint, err)
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var errResp struct {
+			ErrorMessage string `json:"Error Message"`
+		}
+		if err := json.Unmarshal(trimmed, &errResp); err == nil && errResp.ErrorMessage != "" {
+			return fmpMessageError(endpoint, errResp.ErrorMessage)
+		}
+	}
+
+	if err := json.Unmarshal(trimmed, v); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", endpoint, err)
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+func fmpMoversToMovers(fmpMovers []FMPMoverResponse) []Mover {
+	movers := make([]Mover, 0, len(fmpMovers))
+	for _, m := range fmpMovers {
+		if len(movers) == maxMoversPerCategory {
+			break
+		}
+		movers = append(movers, Mover{
+			Ticker:       m.Symbol,
+			Price:        m.Price,
+			ChangeAmount: m.Change,
+			ChangeRate:   m.ChangesPercentage,
+		})
+	}
+	return movers
+}
+
+// FMPProvider Financial Modeling Prepをデータソースとするプロバイダー
+type FMPProvider struct {
+	apiKey string
+}
+
+// NewFMPProvider FMPのプロバイダーを作成する（MoversProvider / ProfileProvider / BarsProvider / PerShareProvider を満たす）
+func NewFMPProvider(apiKey string) *FMPProvider {
+	return &FMPProvider{apiKey: apiKey}
+}
+
+func (p *FMPProvider) Name() string {
+	return "fmp"
+}
+
+func (p *FMPProvider) FetchMovers() (*Movers, error) {
+	if p.apiKey == "" {
+		return nil, newProviderError(p.Name(), ErrKindInvalidKey, "FMP_API_KEY is not set")
+	}
+
+	gainers, err := FetchFMPMovers("biggest-gainers", p.apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(gainers) == 0 {
+		return nil, newProviderError(p.Name(), ErrKindEmpty, "no biggest gainers returned")
+	}
+	// 返却順が保証されていないので、変化率の大きい順に並べ替える
+	sort.SliceStable(gainers, func(i, j int) bool {
+		return gainers[i].ChangesPercentage > gainers[j].ChangesPercentage
+	})
+
+	losers, err := FetchFMPMovers("biggest-losers", p.apiKey)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(losers, func(i, j int) bool {
+		return losers[i].ChangesPercentage < losers[j].ChangesPercentage
+	})
+
+	actives, err := FetchFMPMovers("most-actives", p.apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// FMPのランキングは日付を返さないので、NYSEの直近の取引日とする（休場日・寄り付き前は前営業日）
+	return &Movers{
+		Provider:           p.Name(),
+		Date:               calendar.NYSE.LatestSessionDate(time.Now()),
+		TopGainers:         fmpMoversToMovers(gainers),
+		TopLosers:          fmpMoversToMovers(losers),
+		MostActivelyTraded: fmpMoversToMovers(actives),
+	}, nil
+}
+
+func (p *FMPProvider) FetchProfile(ticker string) (*CompanyProfile, error) {
+	if p.apiKey == "" {
+		return nil, newProviderError(p.Name(), ErrKindInvalidKey, "FMP_API_KEY is not set")
+	}
+
+	fmpData, err := FetchFMPData(ticker, p.apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return fmpData.toProfile(), nil
+}
+
+func (p *FMPProvider) FetchDailyBars(ticker string, from string, to string) (*DailyBars, error) {
+	if p.apiKey == "" {
+		return nil, newProviderError(p.Name(), ErrKindInvalidKey, "FMP_API_KEY is not set")
+	}
+
+	prices, err := FetchFMPHistoricalPrices(ticker, from, to, p.apiKey)
+	if err != nil {
+		return nil, err
+	}
+	adjustedPrices, err := FetchFMPDividendAdjustedPrices(ticker, from, to, p.apiKey)
+	if err != nil {
+		return nil, err
+	}
+	adjustedByDate := make(map[string]FMPDividendAdjustedPriceResponse, len(adjustedPrices))
+	for _, adjusted := range adjustedPrices {
+		adjustedByDate[adjusted.Date] = adjusted
+	}
+
+	bars := make([]DailyBar, 0, len(prices))
+	for _, price := range prices {
+		// 調整後の値が無い日は調整前の値を入れて保存し、次回の同期で調整後終値の違いとして取得し直す
+		bar := DailyBar{
+			Date:             price.Date,
+			Open:             price.Open,
+			High:             price.High,
+			Low:              price.Low,
+			Close:            price.Close,
+			Volume:           price.Volume,
+			AdjustmentOpen:   price.Open,
+			AdjustmentHigh:   price.High,
+			AdjustmentLow:    price.Low,
+			AdjustmentClose:  price.Close,
+			AdjustmentVolume: float64(price.Volume),
+		}
+		if adjusted, ok := adjustedByDate[price.Date]; ok {
+			bar.AdjustmentOpen = adjusted.AdjOpen
+			bar.AdjustmentHigh = adjusted.AdjHigh
+			bar.AdjustmentLow = adjusted.AdjLow
+			bar.AdjustmentClose = adjusted.AdjClose
+			bar.AdjustmentVolume = adjusted.Volume
+		}
+		bars = append(bars, bar)
+	}
+	sort.Slice(bars, func(i, j int) bool {
+		return bars[i].Date < bars[j].Date
+	})
+
+	return &DailyBars{Provider: p.Name(), Ticker: ticker, Bars: bars, Adjusted: true}, nil
+}
+
+func (p *FMPProvider) FetchPerShare(ticker string) (*PerShare, error) {
+	if p.apiKey == "" {
+		return nil, newProviderError(p.Name(), ErrKindInvalidKey, "FMP_API_KEY is not set")
+	}
+
+	ratios, err := FetchFMPRatiosTTM(ticker, p.apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PerShare{
+		Provider:          p.Name(),
+		Ticker:            ticker,
+		EarningsPerShare:  ratios.NetIncomePerShareTTM,
+		BookValuePerShare: ratios.BookValuePerShareTTM,
+		DividendPerShare:  ratios.DividendPerShareTTM,
+	}, nil
+}
@@ -0,0 +1,240 @@
+package america_stock
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/calendar"
+	"time"
+)
+
+// 1カテゴリあたりに保存する最大件数（Alpha Vantageの返却件数に合わせる）
+const maxMoversPerCategory = 20
+
+// Mover ランキング1件分（プロバイダー共通の形式）
+type Mover struct {
+	Ticker       string
+	Price        float64
+	ChangeAmount float64
+	ChangeRate   float64 // 変化率（%）
+}
+
+// Movers 値上がり・値下がり・出来高上位のランキング（プロバイダー共通の形式）
+type Movers struct {
+	Provider           string
+	Date               string // 取引日（YYYY-MM-DD, 米国東部時間）
+	TopGainers         []Mover
+	TopLosers          []Mover
+	MostActivelyTraded []Mover
+}
+
+// CompanyProfile 企業プロファイル（Stockに保存する静的情報 + StockMetricに保存する動的情報）
+type CompanyProfile struct {
+	Ticker            string
+	Name              string
+	Sector            string
+	Industry          string
+	Description       string
+	Website           string
+	Country           string
+	FullTimeEmployees int
+	Image             string
+	IpoDate           string
+	CEO               string
+
+	MarketCap     float64
+	Volume        int64
+	AverageVolume int64
+	Beta          float64
+	LastDividend  float64
+}
+
+// MoversProvider 値上がり・値下がりランキングの取得元
+type MoversProvider interface {
+	Name() string
+	FetchMovers() (*Movers, error)
+}
+
+// ProfileProvider 企業プロファイルの取得元
+type ProfileProvider interface {
+	Name() string
+	FetchProfile(ticker string) (*CompanyProfile, error)
+}
+
+// moversFallback 複数のMoversProviderを順に試し、最初に成功した結果を返す
+type moversFallback struct {
+	providers []MoversProvider
+}
+
+// NewMoversFallback 先頭から順に試すMoversProviderを作成する（例: Alpha Vantage → FMP）
+func NewMoversFallback(providers ...MoversProvider) MoversProvider {
+	return &moversFallback{providers: providers}
+}
+
+func (f *moversFallback) Name() string {
+	return "fallback"
+}
+
+func (f *moversFallback) FetchMovers() (*Movers, error) {
+	var errs []error
+	for _, provider := range f.providers {
+		movers, err := provider.FetchMovers()
+		if err == nil {
+			return movers, nil
+		}
+		log.Printf("Warning: movers provider %s failed, trying next: %v", provider.Name(), err)
+		errs = append(errs, withProviderName(provider.Name(), err))
+	}
+	return nil, fmt.Errorf("all movers providers failed: %w", errors.Join(errs...))
+}
+
+// profileFallback 複数のProfileProviderを順に試し、最初に成功した結果を返す
+type profileFallback struct {
+	providers []ProfileProvider
+}
+
+// NewProfileFallback 先頭から順に試すProfileProviderを作成する（例: FMP → Alpha Vantage）
+func NewProfileFallback(providers ...ProfileProvider) ProfileProvider {
+	return &profileFallback{providers: providers}
+}
+
+func (f *profileFallback) Name() string {
+	return "fallback"
+}
+
+func (f *profileFallback) FetchProfile(ticker string) (*CompanyProfile, error) {
+	var errs []error
+	for _, provider := range f.providers {
+		profile, err := provider.FetchProfile(ticker)
+		if err == nil {
+			return profile, nil
+		}
+		log.Printf("Warning: profile provider %s failed for %s, trying next: %v", provider.Name(), ticker, err)
+		errs = append(errs, withProviderName(provider.Name(), err))
+	}
+	return nil, fmt.Errorf("all profile providers failed for %s: %w", ticker, errors.Join(errs...))
+}
+
+// withProviderName どのプロバイダーのエラーか分かるよう名前を付ける（ProviderErrorは名前を含むのでそのまま）
+func withProviderName(name string, err error) error {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return err
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
+
+// SaveMoversToDB 3カテゴリのランキングをStock / DailyRankingに保存する
+func SaveMoversToDB(movers *Movers, repo repositories.IStockRepository) error {
+	// Top Gainersを保存
+	if err := saveMoversToDB(movers.TopGainers, models.CategoryTopGainers, movers.Date, repo); err != nil {
+		return fmt.Errorf("failed to save top gainers: %w", err)
+	}
+
+	// Top Losersを保存
+	if err := saveMoversToDB(movers.TopLosers, models.CategoryTopLosers, movers.Date, repo); err != nil {
+		return fmt.Errorf("failed to save top losers: %w", err)
+	}
+
+	// Most Actively Tradedを保存
+	if err := saveMoversToDB(movers.MostActivelyTraded, models.CategoryMostActivelyTraded, movers.Date, repo); err != nil {
+		return fmt.Errorf("failed to save most actively traded: %w", err)
+	}
+
+	return nil
+}
+
+func saveMoversToDB(movers []Mover, category string, date string, repo repositories.IStockRepository) error {
+	for rank, mover := range movers {
+		stock := &models.Stock{
+			Ticker:   mover.Ticker,
+			Name:     "", // ランキングAPIからは取得できないので空文字（企業情報の同期で埋める）
+			Sector:   "", // 同上
+			Industry: "", // 同上
+		}
+
+		if err := repo.CreateOrUpdateStock(stock); err != nil {
+			return fmt.Errorf("failed to create/update stock %s: %w", mover.Ticker, err)
+		}
+
+		ranking := &models.DailyRanking{
+			StockID:      stock.ID,
+			Date:         date,
+			Rank:         rank + 1, // 1位から始まる
+			Category:     category,
+			ChangeAmount: mover.ChangeAmount,
+			ChangeRate:   mover.ChangeRate,
+			Price:        mover.Price,
+			NewsSummary:  "", // 後で設定
+			AiAnalysis:   "", // 後で設定
+		}
+
+		if err := repo.CreateOrUpdateDailyRanking(ranking); err != nil {
+			return fmt.Errorf("failed to create/update daily ranking for %s: %w", mover.Ticker, err)
+		}
+	}
+	return nil
+}
+
+// SaveProfileToDB 企業プロファイルを保存する
+// 静的情報（Stock）は未登録（Nameが空）の場合のみ埋め、動的情報（StockMetric）は毎回 date の値として保存する
+// date が空の場合はNYSEの直近の取引日とする
+func SaveProfileToDB(profile *CompanyProfile, date string, repo repositories.IStockRepository) error {
+	// 既存のStockテーブルのデータを取得
+	stock, err := repo.FindStockByTicker(profile.Ticker)
+	if err != nil {
+		return fmt.Errorf("stock %s not found in DB: %w", profile.Ticker, err)
+	}
+	// 静的データをStockテーブルに保存
+	if stock.Name == "" {
+		stock.Name = profile.Name
+		stock.Sector = profile.Sector
+		stock.Industry = profile.Industry
+		stock.Description = profile.Description
+		stock.Website = profile.Website
+		stock.Country = profile.Country
+		stock.FullTimeEmployees = profile.FullTimeEmployees
+		stock.Image = profile.Image
+		stock.IpoDate = profile.IpoDate
+		stock.CEO = profile.CEO
+	}
+	if err := repo.UpdateStock(stock); err != nil {
+		return fmt.Errorf("failed to create/update stock %s: %w", profile.Ticker, err)
+	}
+
+	// 動的データをStockMetricテーブルに保存（日付はサーバー時刻ではなくNYSEの取引日）
+	if date == "" {
+		date = calendar.NYSE.LatestSessionDate(time.Now())
+	}
+	metric := &models.StockMetric{
+		StockID:       stock.ID,
+		Date:          date,
+		MarketCap:     profile.MarketCap,
+		Volume:        profile.Volume,
+		AverageVolume: profile.AverageVolume,
+		Beta:          profile.Beta,
+		LastDividend:  profile.LastDividend,
+	}
+
+	if err := repo.UpdateStockMetric(metric); err != nil {
+		return fmt.Errorf("failed to update stock metric %s: %w", profile.Ticker, err)
+	}
+
+	return nil
+}
+
+// SyncCompanyInfo 企業プロファイルを取得してDBに保存する（date は StockMetric の日付。空の場合はNYSEの直近の取引日）
+func SyncCompanyInfo(ticker string, date string, repo repositories.IStockRepository, provider ProfileProvider) error {
+	profile, err := provider.FetchProfile(ticker)
+	if err != nil {
+		return fmt.Errorf("failed to fetch profile for %s: %w", ticker, err)
+	}
+
+	if err := SaveProfileToDB(profile, date, repo); err != nil {
+		return fmt.Errorf("failed to save profile for %s: %w", ticker, err)
+	}
+
+	return nil
+}
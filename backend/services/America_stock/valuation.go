@@ -0,0 +1,104 @@
+package america_stock
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/calendar"
+	"stock-prediction/backend/services/indicators"
+	"stock-prediction/backend/services/valuation"
+)
+
+// 前日比・RSIの計算に使う日足の期間（暦日）
+const valuationBarsLookbackDays = 120
+
+// PerShare 直近12ヶ月（TTM）の1株あたりの指標（プロバイダー共通の形式。取得できない項目は nil）
+type PerShare struct {
+	Provider          string
+	Ticker            string
+	EarningsPerShare  *float64
+	BookValuePerShare *float64
+	DividendPerShare  *float64
+}
+
+// PerShareProvider 1株あたりの指標の取得元
+type PerShareProvider interface {
+	Name() string
+	FetchPerShare(ticker string) (*PerShare, error)
+}
+
+// perShareFallback 複数のPerShareProviderを順に試し、最初に成功した結果を返す
+type perShareFallback struct {
+	providers []PerShareProvider
+}
+
+// NewPerShareFallback 先頭から順に試すPerShareProviderを作成する（例: FMP → Alpha Vantage）
+func NewPerShareFallback(providers ...PerShareProvider) PerShareProvider {
+	return &perShareFallback{providers: providers}
+}
+
+func (f *perShareFallback) Name() string {
+	return "fallback"
+}
+
+func (f *perShareFallback) FetchPerShare(ticker string) (*PerShare, error) {
+	var errs []error
+	for _, provider := range f.providers {
+		perShare, err := provider.FetchPerShare(ticker)
+		if err == nil {
+			return perShare, nil
+		}
+		log.Printf("Warning: per-share provider %s failed for %s, trying next: %v", provider.Name(), ticker, err)
+		errs = append(errs, withProviderName(provider.Name(), err))
+	}
+	return nil, fmt.Errorf("all per-share providers failed for %s: %w", ticker, errors.Join(errs...))
+}
+
+// SyncValuation date（YYYY-MM-DD）時点のバリュエーションを計算して StockValuation に保存する
+// 終値は保存済みの日足（SyncDailyBars で同期したもの）の date 以前の直近の値を使い、その日足の日付で保存する
+// 前日比・RSIも同じ日足から計算する
+func SyncValuation(ticker string, date string, repo repositories.IStockRepository, provider PerShareProvider) error {
+	stock, err := repo.FindStockByTicker(ticker)
+	if err != nil {
+		return fmt.Errorf("stock %s not found in DB: %w", ticker, err)
+	}
+
+	toDate, err := calendar.NYSE.ParseDate(date)
+	if err != nil {
+		return err
+	}
+	from := toDate.AddDate(0, 0, -valuationBarsLookbackDays).Format("2006-01-02")
+	bars, err := repo.FindDailyBars(ticker, from, date)
+	if err != nil {
+		return fmt.Errorf("failed to find daily bars for %s: %w", ticker, err)
+	}
+	if len(*bars) == 0 {
+		return fmt.Errorf("no daily bars for %s between %s and %s", ticker, from, date)
+	}
+	latest := (*bars)[len(*bars)-1]
+	// 前日比・RSIは分割・配当の影響を受けないよう調整後の終値で計算する（調整後の値が無い日足は除外される）
+	priceBars := indicators.FromStockDailyBars(*bars)
+	signals := make(map[string]models.PriceSignals, len(priceBars))
+	for i, signal := range valuation.ComputePriceSignals(priceBars) {
+		signals[priceBars[i].Date] = signal
+	}
+
+	perShare, err := provider.FetchPerShare(ticker)
+	if err != nil {
+		return fmt.Errorf("failed to fetch per-share data for %s: %w", ticker, err)
+	}
+
+	stockValuation := &models.StockValuation{
+		StockID:         stock.ID,
+		Date:            latest.Date,
+		ValuationRatios: valuation.Compute(latest.Close, perShare.EarningsPerShare, perShare.BookValuePerShare, perShare.DividendPerShare),
+		PriceSignals:    signals[latest.Date],
+		Provider:        perShare.Provider,
+	}
+	if err := repo.UpsertStockValuation(stockValuation); err != nil {
+		return fmt.Errorf("failed to save valuation for %s: %w", ticker, err)
+	}
+	return nil
+}
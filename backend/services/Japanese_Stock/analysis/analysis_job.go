@@ -0,0 +1,290 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/proto/analysispb"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/indicators"
+	"sync"
+	"time"
+)
+
+const (
+	// Pythonに渡す株価データの期間（過去6ヶ月分）
+	priceHistoryMonths = 6
+	// 分析サービスへの同時リクエスト数
+	analyzeConcurrency = 4
+)
+
+// CompanyAnalysisOutcome 1社分の分析ジョブの結果
+type CompanyAnalysisOutcome struct {
+	Code             string `json:"Code"`
+	AnalysisResultID uint   `json:"AnalysisResultID,omitempty"`
+	Sentiment        string `json:"Sentiment,omitempty"`
+	Error            string `json:"Error,omitempty"`
+}
+
+type IAnalysisJob interface {
+	AnalyzeCompany(ctx context.Context, code string, analyzedAt time.Time) (*models.AnalysisResult, error)
+	AnalyzeCompanies(ctx context.Context, codes []string, analyzedAt time.Time) []CompanyAnalysisOutcome
+}
+
+type analysisJob struct {
+	repository repositories.IJapaneseStockRepository
+	client     *Client
+}
+
+func NewAnalysisJob(repository repositories.IJapaneseStockRepository, client *Client) IAnalysisJob {
+	return &analysisJob{repository: repository, client: client}
+}
+
+// AnalyzeCompanies 複数銘柄のPhase 1 → Phase 2を並列で実行する
+// analyzedAt は同じ分析セッションの銘柄で共通の値を渡す（セクター比較で同じ分析日の結果を集めるため）
+func (j *analysisJob) AnalyzeCompanies(ctx context.Context, codes []string, analyzedAt time.Time) []CompanyAnalysisOutcome {
+	outcomes := make([]CompanyAnalysisOutcome, len(codes))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, analyzeConcurrency)
+	for i, code := range codes {
+		wg.Add(1)
+		go func(i int, code string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			outcome := CompanyAnalysisOutcome{Code: code}
+			if err := ctx.Err(); err != nil {
+				outcome.Error = err.Error()
+				outcomes[i] = outcome
+				return
+			}
+			result, err := j.AnalyzeCompany(ctx, code, analyzedAt)
+			if err != nil {
+				log.Printf("Warning: Failed to analyze %s: %v", code, err)
+				outcome.Error = err.Error()
+			}
+			if result != nil {
+				outcome.AnalysisResultID = result.ID
+				outcome.Sentiment = result.Sentiment
+			}
+			outcomes[i] = outcome
+		}(i, code)
+	}
+	wg.Wait()
+
+	return outcomes
+}
+
+// AnalyzeCompany 1銘柄分のデータをDBから集めて分析サービスに送り、結果をAnalysisResultに保存する
+// Phase 1の結果を保存した後にPhase 2を実行するため、Phase 2が失敗した場合もPhase 1の結果は残る
+func (j *analysisJob) AnalyzeCompany(ctx context.Context, code string, analyzedAt time.Time) (*models.AnalysisResult, error) {
+	// DBのtimestamp精度に合わせて丸める（CodeとAnalyzedAtで同じ分析セッションを識別するため）
+	analyzedAt = analyzedAt.Truncate(time.Second)
+
+	company, err := j.repository.FindCompanyByCode(code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find company %s: %w", code, err)
+	}
+	companyInfo := toCompanyInfo(company)
+
+	// ニュースは無くても分析は実行する
+	var qualitativeInfo []string
+	var newsSearchID *uint
+	newsSearch, err := j.repository.FindNewsByCode(code)
+	if err != nil {
+		log.Printf("Warning: No news found for %s, analyzing without qualitative info: %v", code, err)
+	} else {
+		qualitativeInfo = []string{newsSearch.CombinedContent}
+		newsSearchID = &newsSearch.ID
+	}
+
+	priceFrom := analyzedAt.AddDate(0, -priceHistoryMonths, 0).Format("2006-01-02")
+	priceTo := analyzedAt.Format("2006-01-02")
+
+	// Phase 1: 戦略策定
+	strategy, err := j.client.AnalyzeStrategy(ctx, &analysispb.StrategyRequest{
+		Ticker:          code,
+		CompanyInfo:     companyInfo,
+		QualitativeInfo: qualitativeInfo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	analysisResult := &models.AnalysisResult{
+		Code:          code,
+		AnalyzedAt:    analyzedAt,
+		BusinessModel: strategy.GetBusinessModel(),
+		KPI:           strategy.GetKpi(),
+		ThoughtLog:    "## Phase 1\n" + strategy.GetThoughtLog(),
+		PriceDataFrom: priceFrom,
+		PriceDataTo:   priceTo,
+		NewsSearchID:  newsSearchID,
+	}
+	if err := j.repository.CreateOrUpdateAnalysisResult(analysisResult); err != nil {
+		return nil, fmt.Errorf("failed to save phase 1 result for %s: %w", code, err)
+	}
+
+	// Phase 2: 分析実行
+	dailyQuotes, err := j.repository.FindDailyQuotesByCode(code, priceFrom, priceTo)
+	if err != nil {
+		return analysisResult, fmt.Errorf("failed to find daily quotes for %s: %w", code, err)
+	}
+	// 200日移動平均・52週高値/安値は6ヶ月分の株価では足りないので、指標用に長めの期間を取得する
+	indicatorFrom := analyzedAt.AddDate(0, 0, -indicators.LookbackDays).Format("2006-01-02")
+	indicatorQuotes, err := j.repository.FindDailyQuotesByCode(code, indicatorFrom, priceTo)
+	if err != nil {
+		return analysisResult, fmt.Errorf("failed to find daily quotes for indicators of %s: %w", code, err)
+	}
+	statements, err := j.repository.FindFinancialStatementsByCode(code)
+	if err != nil {
+		return analysisResult, fmt.Errorf("failed to find financial statements for %s: %w", code, err)
+	}
+	statementsJSON, err := financialStatementsToJSON(statements)
+	if err != nil {
+		return analysisResult, fmt.Errorf("failed to build financial statements JSON for %s: %w", code, err)
+	}
+
+	execution, err := j.client.AnalyzeExecution(ctx, &analysispb.ExecutionRequest{
+		Ticker:                  code,
+		CompanyInfo:             companyInfo,
+		BusinessModel:           strategy.GetBusinessModel(),
+		Kpi:                     strategy.GetKpi(),
+		FinancialStatementsJson: statementsJSON,
+		StockPrices:             toStockPrices(dailyQuotes),
+		QualitativeInfo:         qualitativeInfo,
+		TechnicalIndicators:     toTechnicalIndicators(indicators.Compute(indicators.FromDailyQuotes(indicatorQuotes))),
+	})
+	if err != nil {
+		return analysisResult, err
+	}
+
+	analysisResult.Sentiment = SentimentToString(execution.GetSentiment())
+	analysisResult.SummaryReasoning = execution.GetSummaryReasoning()
+	analysisResult.StockSummary = execution.GetStockSummary()
+	analysisResult.FinancialSummary = execution.GetFinancialSummary()
+	analysisResult.ThoughtLog += "\n\n## Phase 2\n" + execution.GetThoughtLog()
+	if err := j.repository.CreateOrUpdateAnalysisResult(analysisResult); err != nil {
+		return analysisResult, fmt.Errorf("failed to save phase 2 result for %s: %w", code, err)
+	}
+
+	return analysisResult, nil
+}
+
+// SentimentToString gRPCのSentimentをAnalysisResult.Sentimentの表記に変換する
+func SentimentToString(sentiment analysispb.Sentiment) string {
+	switch sentiment {
+	case analysispb.Sentiment_SENTIMENT_STRONG_BUY:
+		return "Strong Buy"
+	case analysispb.Sentiment_SENTIMENT_BUY:
+		return "Buy"
+	case analysispb.Sentiment_SENTIMENT_HOLD:
+		return "Hold"
+	case analysispb.Sentiment_SENTIMENT_SELL:
+		return "Sell"
+	default:
+		return ""
+	}
+}
+
+// SentimentFromString AnalysisResult.Sentimentの表記をgRPCのSentimentに変換する
+func SentimentFromString(sentiment string) analysispb.Sentiment {
+	switch sentiment {
+	case "Strong Buy":
+		return analysispb.Sentiment_SENTIMENT_STRONG_BUY
+	case "Buy":
+		return analysispb.Sentiment_SENTIMENT_BUY
+	case "Hold":
+		return analysispb.Sentiment_SENTIMENT_HOLD
+	case "Sell":
+		return analysispb.Sentiment_SENTIMENT_SELL
+	default:
+		return analysispb.Sentiment_SENTIMENT_UNSPECIFIED
+	}
+}
+
+func toCompanyInfo(company *models.Company) *analysispb.CompanyInfo {
+	return &analysispb.CompanyInfo{
+		Code:               company.Code,
+		CompanyName:        company.CompanyName,
+		CompanyNameEnglish: company.CompanyNameEnglish,
+		Sector17Code:       company.Sector17Code,
+		Sector17CodeName:   company.Sector17CodeName,
+		Sector33Code:       company.Sector33Code,
+		Sector33CodeName:   company.Sector33CodeName,
+		ScaleCategory:      company.ScaleCategory,
+		MarketCode:         company.MarketCode,
+		MarketCodeName:     company.MarketCodeName,
+	}
+}
+
+func toStockPrices(dailyQuotes []models.DailyQuote) []*analysispb.StockPrice {
+	prices := make([]*analysispb.StockPrice, 0, len(dailyQuotes))
+	for _, quote := range dailyQuotes {
+		prices = append(prices, &analysispb.StockPrice{
+			Date:             quote.Date,
+			Open:             quote.Open,
+			High:             quote.High,
+			Low:              quote.Low,
+			Close:            quote.Close,
+			Volume:           quote.Volume,
+			TurnoverValue:    quote.TurnoverValue,
+			AdjustmentFactor: quote.AdjustmentFactor,
+			AdjustmentOpen:   quote.AdjustmentOpen,
+			AdjustmentHigh:   quote.AdjustmentHigh,
+			AdjustmentLow:    quote.AdjustmentLow,
+			AdjustmentClose:  quote.AdjustmentClose,
+			AdjustmentVolume: quote.AdjustmentVolume,
+		})
+	}
+	return prices
+}
+
+func toTechnicalIndicators(snapshot *indicators.Snapshot) *analysispb.TechnicalIndicators {
+	if snapshot == nil {
+		return nil
+	}
+	return &analysispb.TechnicalIndicators{
+		Date:                 snapshot.Date,
+		Close:                snapshot.Close,
+		Bars:                 int32(snapshot.Bars),
+		Sma20:                snapshot.SMA20,
+		Sma50:                snapshot.SMA50,
+		Sma200:               snapshot.SMA200,
+		Ema12:                snapshot.EMA12,
+		Ema26:                snapshot.EMA26,
+		Rsi14:                snapshot.RSI14,
+		Macd:                 snapshot.MACD,
+		MacdSignal:           snapshot.MACDSignal,
+		MacdHistogram:        snapshot.MACDHistogram,
+		BollingerUpper:       snapshot.BollingerUpper,
+		BollingerMiddle:      snapshot.BollingerMiddle,
+		BollingerLower:       snapshot.BollingerLower,
+		BollingerPercentB:    snapshot.BollingerPercentB,
+		Atr14:                snapshot.ATR14,
+		Atr14Percent:         snapshot.ATR14Percent,
+		VolumeZScore20:       snapshot.VolumeZScore20,
+		YearHigh:             snapshot.High52Week,
+		YearLow:              snapshot.Low52Week,
+		DistanceFromYearHigh: snapshot.DistanceFromHigh52Week,
+		DistanceFromYearLow:  snapshot.DistanceFromLow52Week,
+	}
+}
+
+// financialStatementsToJSON 各財務諸表のRawJSONをそのまま並べたJSON配列を作る（Python側でpandasに読み込む）
+func financialStatementsToJSON(statements []models.FinancialStatement) (string, error) {
+	raws := make([]json.RawMessage, 0, len(statements))
+	for _, statement := range statements {
+		raws = append(raws, json.RawMessage(statement.RawJSON))
+	}
+
+	data, err := json.Marshal(raws)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,108 @@
+// Package analysistest はPython分析サービスの代わりにインプロセスで動くgRPCサーバーを提供する
+// （net/http/httptest と同じ位置付け。Pythonサービスを起動せずにGo側の疎通確認を行うために使う）
+package analysistest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"stock-prediction/backend/proto/analysispb"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// FakeServer 受け取ったリクエストを記録し、決まったレスポンスを返す分析サービス
+type FakeServer struct {
+	analysispb.UnimplementedStockAnalysisServiceServer
+
+	// Sentiment Phase 2で返す投資判断（未設定ならHold）
+	Sentiment analysispb.Sentiment
+
+	mu                 sync.Mutex
+	StrategyRequests   []*analysispb.StrategyRequest
+	ExecutionRequests  []*analysispb.ExecutionRequest
+	ComparisonRequests []*analysispb.SectorComparisonRequest
+}
+
+func (s *FakeServer) AnalyzeStrategy(_ context.Context, req *analysispb.StrategyRequest) (*analysispb.StrategyResponse, error) {
+	s.mu.Lock()
+	s.StrategyRequests = append(s.StrategyRequests, req)
+	s.mu.Unlock()
+
+	return &analysispb.StrategyResponse{
+		BusinessModel: fmt.Sprintf("%s のビジネスモデル（fake）", req.GetCompanyInfo().GetCompanyName()),
+		Kpi:           "売上高成長率, 営業利益率",
+		ThoughtLog:    fmt.Sprintf("qualitative_info: %d件", len(req.GetQualitativeInfo())),
+	}, nil
+}
+
+func (s *FakeServer) AnalyzeExecution(_ context.Context, req *analysispb.ExecutionRequest) (*analysispb.ExecutionResponse, error) {
+	s.mu.Lock()
+	s.ExecutionRequests = append(s.ExecutionRequests, req)
+	s.mu.Unlock()
+
+	sentiment := s.Sentiment
+	if sentiment == analysispb.Sentiment_SENTIMENT_UNSPECIFIED {
+		sentiment = analysispb.Sentiment_SENTIMENT_HOLD
+	}
+
+	return &analysispb.ExecutionResponse{
+		Sentiment:        sentiment,
+		SummaryReasoning: fmt.Sprintf("# %s\nKPI: %s", req.GetTicker(), req.GetKpi()),
+		StockSummary:     fmt.Sprintf("株価データ: %d件", len(req.GetStockPrices())),
+		FinancialSummary: fmt.Sprintf("財務データ: %dバイト", len(req.GetFinancialStatementsJson())),
+		ThoughtLog:       "fake execution",
+	}, nil
+}
+
+// CompareSector 受け取った順に最大3銘柄をTop3として返す
+func (s *FakeServer) CompareSector(_ context.Context, req *analysispb.SectorComparisonRequest) (*analysispb.SectorComparisonResponse, error) {
+	s.mu.Lock()
+	s.ComparisonRequests = append(s.ComparisonRequests, req)
+	s.mu.Unlock()
+
+	resp := &analysispb.SectorComparisonResponse{
+		OverallSummary: fmt.Sprintf("%s: %d銘柄を比較（fake）", req.GetSectorName(), len(req.GetAnalyses())),
+		ComparisonLog:  "fake comparison",
+	}
+	for i, a := range req.GetAnalyses() {
+		if i >= 3 {
+			break
+		}
+		resp.TopPicks = append(resp.TopPicks, &analysispb.RankedPick{
+			Rank:      int32(i + 1),
+			Ticker:    a.GetTicker(),
+			Reasoning: fmt.Sprintf("%s は %d位（fake）", a.GetTicker(), i+1),
+		})
+	}
+	return resp, nil
+}
+
+// Start FakeServerをインメモリのリスナー（bufconn）で起動し、接続済みのコネクションを返す
+// 戻り値のstop関数でコネクションとサーバーを停止する
+func Start(server *FakeServer) (*grpc.ClientConn, func(), error) {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	analysispb.RegisterStockAnalysisServiceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		grpcServer.Stop()
+		return nil, nil, fmt.Errorf("failed to connect to fake server: %w", err)
+	}
+
+	stop := func() {
+		conn.Close()
+		grpcServer.Stop()
+	}
+	return conn, stop, nil
+}
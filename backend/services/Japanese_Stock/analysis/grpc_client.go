@@ -0,0 +1,95 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"stock-prediction/backend/proto/analysispb"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// LLMの推論を待つため、1回のRPCのタイムアウトは長めに取る
+const defaultRPCTimeout = 5 * time.Minute
+
+// Client Python (LangGraph) 分析サービスへのgRPCクライアント
+type Client struct {
+	conn    *grpc.ClientConn
+	stub    analysispb.StockAnalysisServiceClient
+	timeout time.Duration
+}
+
+// NewClient 分析サービス（例: "localhost:50051"）に接続するクライアントを作成する
+// 接続は最初のRPC呼び出し時に確立される
+func NewClient(target string, opts ...grpc.DialOption) (*Client, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", target, err)
+	}
+
+	return &Client{conn: conn, stub: analysispb.NewStockAnalysisServiceClient(conn), timeout: defaultRPCTimeout}, nil
+}
+
+// NewClientFromEnv ANALYSIS_GRPC_ADDR で指定された分析サービスに接続するクライアントを作成する
+func NewClientFromEnv() (*Client, error) {
+	target := os.Getenv("ANALYSIS_GRPC_ADDR")
+	if target == "" {
+		return nil, fmt.Errorf("ANALYSIS_GRPC_ADDR is not set")
+	}
+	return NewClient(target)
+}
+
+// NewClientWithConn 既存のコネクションからクライアントを作成する（インプロセスのテスト用サーバーへの接続など）
+func NewClientWithConn(conn grpc.ClientConnInterface) *Client {
+	return &Client{stub: analysispb.NewStockAnalysisServiceClient(conn), timeout: defaultRPCTimeout}
+}
+
+// AnalyzeStrategy Phase 1（戦略策定）を実行する
+func (c *Client) AnalyzeStrategy(ctx context.Context, req *analysispb.StrategyRequest) (*analysispb.StrategyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.stub.AnalyzeStrategy(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("AnalyzeStrategy RPC failed for %s: %w", req.GetTicker(), err)
+	}
+	return resp, nil
+}
+
+// AnalyzeExecution Phase 2（分析実行）を実行する
+func (c *Client) AnalyzeExecution(ctx context.Context, req *analysispb.ExecutionRequest) (*analysispb.ExecutionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.stub.AnalyzeExecution(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("AnalyzeExecution RPC failed for %s: %w", req.GetTicker(), err)
+	}
+	return resp, nil
+}
+
+// CompareSector Phase 3（セクター内比較）を実行する
+func (c *Client) CompareSector(ctx context.Context, req *analysispb.SectorComparisonRequest) (*analysispb.SectorComparisonResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.stub.CompareSector(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("CompareSector RPC failed for sector %s: %w", req.GetSectorCode(), err)
+	}
+	return resp, nil
+}
+
+// Close コネクションを閉じる（NewClientWithConnで作成した場合は何もしない）
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
@@ -0,0 +1,173 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/proto/analysispb"
+	"stock-prediction/backend/repositories"
+	"time"
+)
+
+// セクター比較で選出する銘柄数
+const topPickCount = 3
+
+type ISectorComparisonJob interface {
+	CompareSector(ctx context.Context, sector33Code string, analysisDate string) (*models.SectorAnalysisResult, error)
+}
+
+type sectorComparisonJob struct {
+	repository repositories.IJapaneseStockRepository
+	client     *Client
+}
+
+func NewSectorComparisonJob(repository repositories.IJapaneseStockRepository, client *Client) ISectorComparisonJob {
+	return &sectorComparisonJob{repository: repository, client: client}
+}
+
+// CompareSector 指定した分析日（YYYY-MM-DD, 日本時間）のセクター内の分析結果を集めてPhase 3を実行し、
+// Top3をSectorAnalysisResultに保存して、比較対象の各AnalysisResultを紐付ける
+func (j *sectorComparisonJob) CompareSector(ctx context.Context, sector33Code string, analysisDate string) (*models.SectorAnalysisResult, error) {
+	from, err := time.ParseInLocation("2006-01-02", analysisDate, tokyoLocation())
+	if err != nil {
+		return nil, fmt.Errorf("invalid analysis date %q: %w", analysisDate, err)
+	}
+
+	results, err := j.repository.FindCompletedAnalysisResultsBySector(sector33Code, from, from.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find analysis results for sector %s: %w", sector33Code, err)
+	}
+
+	// 同じ日に複数回分析した銘柄は最新の結果のみを比較対象にする（新しい順に取得済み）
+	latest := make([]models.AnalysisResult, 0, len(results))
+	seen := make(map[string]bool)
+	for _, result := range results {
+		if seen[result.Code] {
+			continue
+		}
+		seen[result.Code] = true
+		latest = append(latest, result)
+	}
+	if len(latest) == 0 {
+		return nil, fmt.Errorf("no completed analysis results for sector %s on %s", sector33Code, analysisDate)
+	}
+
+	req := &analysispb.SectorComparisonRequest{SectorCode: sector33Code, AnalysisDate: analysisDate}
+	var sessionAt time.Time
+	for _, result := range latest {
+		companyName := ""
+		if company, err := j.repository.FindCompanyByCode(result.Code); err == nil {
+			companyName = company.CompanyName
+			req.SectorName = company.Sector33CodeName
+		}
+
+		req.Analyses = append(req.Analyses, &analysispb.StockAnalysisSummary{
+			Ticker:           result.Code,
+			CompanyName:      companyName,
+			Sentiment:        SentimentFromString(result.Sentiment),
+			SummaryReasoning: result.SummaryReasoning,
+			StockSummary:     result.StockSummary,
+			FinancialSummary: result.FinancialSummary,
+			BusinessModel:    result.BusinessModel,
+			Kpi:              result.KPI,
+		})
+
+		if result.AnalyzedAt.After(sessionAt) {
+			sessionAt = result.AnalyzedAt
+		}
+	}
+
+	log.Printf("Starting sector comparison for %s on %s (%d stocks)...", sector33Code, analysisDate, len(latest))
+
+	resp, err := j.client.CompareSector(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sectorResult, err := buildSectorAnalysisResult(sector33Code, sessionAt, resp, seen)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同じ分析セッション（最新のAnalyzedAt）で再実行した場合は既存のレコードを更新する
+	if err := j.repository.CreateOrUpdateSectorAnalysisResult(sectorResult); err != nil {
+		return nil, fmt.Errorf("failed to save sector analysis result: %w", err)
+	}
+
+	analysisResultIDs := make([]uint, 0, len(latest))
+	for _, result := range latest {
+		analysisResultIDs = append(analysisResultIDs, result.ID)
+	}
+	if err := j.repository.LinkAnalysisResultsToSector(sectorResult.ID, analysisResultIDs); err != nil {
+		return nil, fmt.Errorf("failed to link analysis results to sector analysis result: %w", err)
+	}
+
+	log.Printf("Completed sector comparison for %s: top picks %s, %s, %s", sector33Code, sectorResult.Top1Code, sectorResult.Top2Code, sectorResult.Top3Code)
+	return sectorResult, nil
+}
+
+// buildSectorAnalysisResult Phase 3のレスポンスをSectorAnalysisResultに変換する
+// 比較対象に含まれない銘柄・範囲外の順位・重複した順位や銘柄・1から連続していない順位が返ってきた場合はエラーにする
+func buildSectorAnalysisResult(sector33Code string, analyzedAt time.Time, resp *analysispb.SectorComparisonResponse, candidates map[string]bool) (*models.SectorAnalysisResult, error) {
+	sectorResult := &models.SectorAnalysisResult{SectorCode: sector33Code, AnalyzedAt: analyzedAt}
+
+	seenRanks := make(map[int32]bool)
+	seenTickers := make(map[string]bool)
+	for _, pick := range resp.GetTopPicks() {
+		if !candidates[pick.GetTicker()] {
+			return nil, fmt.Errorf("comparison returned unknown ticker %q", pick.GetTicker())
+		}
+		if seenRanks[pick.GetRank()] {
+			return nil, fmt.Errorf("comparison returned rank %d more than once", pick.GetRank())
+		}
+		if seenTickers[pick.GetTicker()] {
+			return nil, fmt.Errorf("comparison returned ticker %q more than once", pick.GetTicker())
+		}
+		seenRanks[pick.GetRank()] = true
+		seenTickers[pick.GetTicker()] = true
+
+		switch pick.GetRank() {
+		case 1:
+			sectorResult.Top1Code, sectorResult.Top1Reasoning = pick.GetTicker(), pick.GetReasoning()
+		case 2:
+			sectorResult.Top2Code, sectorResult.Top2Reasoning = pick.GetTicker(), pick.GetReasoning()
+		case 3:
+			sectorResult.Top3Code, sectorResult.Top3Reasoning = pick.GetTicker(), pick.GetReasoning()
+		default:
+			return nil, fmt.Errorf("comparison returned rank %d, expected 1-%d", pick.GetRank(), topPickCount)
+		}
+	}
+	if len(seenRanks) == 0 {
+		return nil, fmt.Errorf("comparison returned no top pick")
+	}
+	// 順位は1から連続していること（例: 1位と3位だけで2位が無い結果は保存しない）
+	for rank := int32(1); rank <= int32(len(seenRanks)); rank++ {
+		if !seenRanks[rank] {
+			return nil, fmt.Errorf("comparison skipped rank %d", rank)
+		}
+	}
+
+	if comparisonLog := resp.GetComparisonLog(); comparisonLog != "" {
+		sectorResult.ComparisonLog = &comparisonLog
+	}
+	if summary := resp.GetOverallSummary(); summary != "" {
+		sectorResult.OverallSummary = &summary
+	}
+
+	return sectorResult, nil
+}
+
+// TodayInTokyo 日本時間の今日の日付（YYYY-MM-DD）を返す
+func TodayInTokyo() string {
+	return time.Now().In(tokyoLocation()).Format("2006-01-02")
+}
+
+// tokyoLocation 分析日の区切りに使う日本時間（タイムゾーンDBが無い環境ではUTC+9固定）
+func tokyoLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		return time.FixedZone("JST", 9*60*60)
+	}
+	return loc
+}
@@ -4,9 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
 	"stock-prediction/backend/models"
 	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/Japanese_Stock/jquants"
 )
 
 // CompanyInfo J-Quants /listed/info のレスポンス構造
@@ -29,19 +29,11 @@ type ListedInfoResponse struct {
 }
 
 // 東証市場の銘柄マスタを取得する
-func FetchJQuantsCompanies(idToken string) (*ListedInfoResponse, error) {
+func FetchJQuantsCompanies(client *jquants.Client) (*ListedInfoResponse, error) {
 	url := "https://api.jquants.com/v1/listed/info"
-	client := &http.Client{Timeout: 30 * time.Second}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// IDトークンをヘッダーに付与
-	req.Header.Set("Authorization", "Bearer " +idToken)
-
-	resp, err := client.Do(req)
+	// IDトークンの付与・401時の再取得はクライアント側で行う
+	resp, err := client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -60,11 +52,12 @@ func FetchJQuantsCompanies(idToken string) (*ListedInfoResponse, error) {
 	return &result, nil
 }
 
-// 東証市場の銘柄マスタをDBに保存する
+// 東証市場の銘柄マスタをDBに保存する（全銘柄をまとめて upsert する）
 func SaveJQuantsCompaniesToDB(companies *ListedInfoResponse, repository repositories.IJapaneseStockRepository) error {
+	records := make([]models.Company, 0, len(companies.Info))
 	for _, company := range companies.Info {
 		// CompanyInfo -> models.Companyに変換する
-		company := &models.Company{
+		records = append(records, models.Company{
 			Code:               company.Code,
 			CompanyName:        company.CompanyName,
 			CompanyNameEnglish: company.CompanyNameEnglish,
@@ -75,13 +68,12 @@ func SaveJQuantsCompaniesToDB(companies *ListedInfoResponse, repository reposito
 			ScaleCategory:      company.ScaleCategory,
 			MarketCode:         company.MarketCode,
 			MarketCodeName:     company.MarketCodeName,
-		}
+		})
+	}
 
-		// 既存なら更新、新規なら保存する
-		err := repository.CreateOrUpdateCompany(company)
-		if err != nil {
-			return fmt.Errorf("failed to create/update company %s: %w", company.Code, err)
-		}
+	// 既存なら更新、新規なら保存する
+	if err := repository.UpsertCompanies(records); err != nil {
+		return fmt.Errorf("failed to upsert %d companies: %w", len(records), err)
 	}
 
 	return nil
@@ -6,8 +6,8 @@ import (
 	"net/http"
 	"stock-prediction/backend/models"
 	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/Japanese_Stock/jquants"
 	"stock-prediction/backend/utils"
-	"time"
 )
 
 // FinancialStatementResponse J-Quants APIレスポンス用の型（DBモデルとは別）
@@ -19,19 +19,11 @@ type ListedFinancialStatementsResponse struct {
 }
 
 // FetchJQuantsFinancialStatements 財務諸表データを取得する
-func FetchJQuantsFinancialStatements(idToken string, code string) (*ListedFinancialStatementsResponse, error) {
+func FetchJQuantsFinancialStatements(client *jquants.Client, code string) (*ListedFinancialStatementsResponse, error) {
 	url := fmt.Sprintf("https://api.jquants.com/v1/fins/statements?code=%s", code)
-	client := &http.Client{Timeout: 30 * time.Second}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// IDトークンをヘッダーに付与
-	req.Header.Set("Authorization", "Bearer "+idToken)
-
-	resp, err := client.Do(req)
+	// IDトークンの付与・401時の再取得はクライアント側で行う
+	resp, err := client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -90,9 +82,9 @@ func SaveJQuantsFinancialStatementsToDB(financialStatements *ListedFinancialStat
 	return nil
 }
 
-func SyncJQuantsFinancialStatements(idToken string, code string, repository repositories.IJapaneseStockRepository) ([]models.FinancialStatement, error) {
+func SyncJQuantsFinancialStatements(client *jquants.Client, code string, repository repositories.IJapaneseStockRepository) ([]models.FinancialStatement, error) {
 	// JQuants APIから財務諸表データを取得
-	financialStatements, err := FetchJQuantsFinancialStatements(idToken, code)
+	financialStatements, err := FetchJQuantsFinancialStatements(client, code)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch JQuants financial statements: %w", err)
 	}
@@ -103,6 +95,11 @@ func SyncJQuantsFinancialStatements(idToken string, code string, repository repo
 		return nil, fmt.Errorf("failed to save JQuants financial statements to DB: %w", err)
 	}
 
+	// 財務指標を作り直す
+	if _, err := RebuildFinancialMetrics(code, repository); err != nil {
+		return nil, fmt.Errorf("failed to rebuild financial metrics: %w", err)
+	}
+
 	// 取得し、保存した財務諸表データを返す（型の整合性とデータの正確性を保証）
 	savedStatements, err := repository.FindFinancialStatementsByCode(code)
 	if err != nil {
@@ -111,3 +108,15 @@ func SyncJQuantsFinancialStatements(idToken string, code string, repository repo
 
 	return savedStatements, nil
 }
+
+// FilterFinancialStatementsSince 開示日（DisclosedDate）がfrom以降の財務諸表のみを残す
+// fromは "2006-01-02" 形式。J-Quantsは取得可能な全期間を返すため、保存期間（過去5年分）の絞り込みに使用する
+func FilterFinancialStatementsSince(financialStatements *ListedFinancialStatementsResponse, from string) *ListedFinancialStatementsResponse {
+	filtered := &ListedFinancialStatementsResponse{}
+	for _, fs := range financialStatements.FinancialInfo {
+		if utils.GetStringFromMap(fs, "DisclosedDate") >= from {
+			filtered.FinancialInfo = append(filtered.FinancialInfo, fs)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,273 @@
+package japanese_Stock
+
+import (
+	"encoding/json"
+	"fmt"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	"strconv"
+	"time"
+)
+
+// periodQuarters TypeOfCurrentPeriod を四半期の番号に変換する（FYは第4四半期までの累計）
+var periodQuarters = map[string]int{"1Q": 1, "2Q": 2, "3Q": 3, "FY": 4}
+
+// ParseFinancialMetric 財務諸表の RawJSON から主要な数値を取り出す（成長率・利益率は ComputeFinancialGrowth で計算する）
+// J-Quants は数値を文字列で返し、開示されていない項目は空文字になるので null にする
+func ParseFinancialMetric(statement *models.FinancialStatement) (*models.FinancialMetric, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(statement.RawJSON), &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal raw JSON of %s: %w", statement.DisclosureNumber, err)
+	}
+
+	return &models.FinancialMetric{
+		FinancialStatementID:       statement.ID,
+		Code:                       statement.Code,
+		DisclosureNumber:           statement.DisclosureNumber,
+		DisclosedDate:              statement.DisclosedDate,
+		TypeOfDocument:             statement.TypeOfDocument,
+		TypeOfCurrentPeriod:        statement.TypeOfCurrentPeriod,
+		CurrentPeriodEndDate:       stringValue(raw, "CurrentPeriodEndDate"),
+		CurrentFiscalYearStartDate: statement.CurrentFiscalYearStartDate,
+		CurrentFiscalYearEndDate:   statement.CurrentFiscalYearEndDate,
+
+		NetSales:           numberValue(raw, "NetSales"),
+		OperatingProfit:    numberValue(raw, "OperatingProfit"),
+		OrdinaryProfit:     numberValue(raw, "OrdinaryProfit"),
+		Profit:             numberValue(raw, "Profit"),
+		EarningsPerShare:   numberValue(raw, "EarningsPerShare"),
+		Equity:             numberValue(raw, "Equity"),
+		TotalAssets:        numberValue(raw, "TotalAssets"),
+		EquityToAssetRatio: percentValue(raw, "EquityToAssetRatio"),
+		BookValuePerShare:  numberValue(raw, "BookValuePerShare"),
+
+		IssuedShares:   numberValue(raw, "NumberOfIssuedAndOutstandingSharesAtTheEndOfFiscalYearIncludingTreasuryStock"),
+		TreasuryShares: numberValue(raw, "NumberOfTreasuryStockAtTheEndOfFiscalYear"),
+
+		DividendPerShare:                 numberValue(raw, "ResultDividendPerShareAnnual"),
+		ForecastDividendPerShare:         numberValue(raw, "ForecastDividendPerShareAnnual"),
+		NextYearForecastDividendPerShare: numberValue(raw, "NextYearForecastDividendPerShareAnnual"),
+
+		ForecastNetSales:         numberValue(raw, "ForecastNetSales"),
+		ForecastOperatingProfit:  numberValue(raw, "ForecastOperatingProfit"),
+		ForecastOrdinaryProfit:   numberValue(raw, "ForecastOrdinaryProfit"),
+		ForecastProfit:           numberValue(raw, "ForecastProfit"),
+		ForecastEarningsPerShare: numberValue(raw, "ForecastEarningsPerShare"),
+
+		NextYearForecastNetSales:         numberValue(raw, "NextYearForecastNetSales"),
+		NextYearForecastOperatingProfit:  numberValue(raw, "NextYearForecastOperatingProfit"),
+		NextYearForecastOrdinaryProfit:   numberValue(raw, "NextYearForecastOrdinaryProfit"),
+		NextYearForecastProfit:           numberValue(raw, "NextYearForecastProfit"),
+		NextYearForecastEarningsPerShare: numberValue(raw, "NextYearForecastEarningsPerShare"),
+	}, nil
+}
+
+// ComputeFinancialGrowth 同じ銘柄の財務指標について、利益率・四半期単独の値・前年同期比・前四半期比を計算する
+// 同じ会計年度・期（TypeOfCurrentPeriod）の実績が複数ある場合（訂正開示など）は、開示日が最も新しいものを比較対象にする
+func ComputeFinancialGrowth(metrics []models.FinancialMetric) {
+	// 会計年度・期ごとの比較対象の実績
+	actuals := make(map[PeriodKey]*models.FinancialMetric)
+	for i := range metrics {
+		metric := &metrics[i]
+		key, ok := NewPeriodKey(metric.CurrentFiscalYearEndDate, metric.TypeOfCurrentPeriod)
+		if !ok || metric.NetSales == nil {
+			continue
+		}
+		if current, exists := actuals[key]; !exists || LaterDisclosure(metric, current) {
+			actuals[key] = metric
+		}
+	}
+
+	for i := range metrics {
+		metric := &metrics[i]
+		metric.OperatingMargin = ratio(metric.OperatingProfit, metric.NetSales)
+		metric.OrdinaryMargin = ratio(metric.OrdinaryProfit, metric.NetSales)
+		metric.NetMargin = ratio(metric.Profit, metric.NetSales)
+
+		key, ok := NewPeriodKey(metric.CurrentFiscalYearEndDate, metric.TypeOfCurrentPeriod)
+		if !ok || metric.NetSales == nil {
+			continue
+		}
+
+		// 四半期単独の値（累計 - 前の四半期までの累計）
+		metric.QuarterNetSales, metric.QuarterOperatingProfit, metric.QuarterProfit = metric.NetSales, metric.OperatingProfit, metric.Profit
+		if key.quarter > 1 {
+			previous := actuals[key.PreviousQuarter()]
+			if previous == nil {
+				metric.QuarterNetSales, metric.QuarterOperatingProfit, metric.QuarterProfit = nil, nil, nil
+			} else {
+				metric.QuarterNetSales = difference(metric.NetSales, previous.NetSales)
+				metric.QuarterOperatingProfit = difference(metric.OperatingProfit, previous.OperatingProfit)
+				metric.QuarterProfit = difference(metric.Profit, previous.Profit)
+			}
+		}
+
+		// 前年同期比
+		if lastYear := actuals[key.LastYear()]; lastYear != nil {
+			metric.NetSalesYoY = growth(metric.NetSales, lastYear.NetSales)
+			metric.OperatingProfitYoY = growth(metric.OperatingProfit, lastYear.OperatingProfit)
+			metric.OrdinaryProfitYoY = growth(metric.OrdinaryProfit, lastYear.OrdinaryProfit)
+			metric.ProfitYoY = growth(metric.Profit, lastYear.Profit)
+			metric.EarningsPerShareYoY = growth(metric.EarningsPerShare, lastYear.EarningsPerShare)
+		}
+	}
+
+	// 前四半期比は四半期単独の値が出揃ってから計算する
+	for i := range metrics {
+		metric := &metrics[i]
+		key, ok := NewPeriodKey(metric.CurrentFiscalYearEndDate, metric.TypeOfCurrentPeriod)
+		if !ok || metric.NetSales == nil {
+			continue
+		}
+		previous := actuals[key.PreviousQuarter()]
+		if previous == nil {
+			continue
+		}
+		metric.NetSalesQoQ = growth(metric.QuarterNetSales, previous.QuarterNetSales)
+		metric.OperatingProfitQoQ = growth(metric.QuarterOperatingProfit, previous.QuarterOperatingProfit)
+		metric.ProfitQoQ = growth(metric.QuarterProfit, previous.QuarterProfit)
+	}
+}
+
+// RebuildFinancialMetrics 保存済みの財務諸表から銘柄の財務指標を作り直して保存する（保存した件数を返す）
+func RebuildFinancialMetrics(code string, repository repositories.IJapaneseStockRepository) (int, error) {
+	statements, err := repository.FindFinancialStatementsByCode(code)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find financial statements by code: %w", err)
+	}
+
+	metrics := make([]models.FinancialMetric, 0, len(statements))
+	for i := range statements {
+		metric, err := ParseFinancialMetric(&statements[i])
+		if err != nil {
+			return 0, err
+		}
+		metrics = append(metrics, *metric)
+	}
+
+	ComputeFinancialGrowth(metrics)
+
+	if err := repository.UpsertFinancialMetrics(metrics); err != nil {
+		return 0, fmt.Errorf("failed to upsert financial metrics for %s: %w", code, err)
+	}
+	return len(metrics), nil
+}
+
+// PeriodKey 会計年度（期末の年月）と四半期の番号
+// 財務指標の前年同期比・前四半期比と、バリュエーションの直近12ヶ月のEPS（valuation パッケージ）で同じ期の対応付けに使う
+type PeriodKey struct {
+	fiscalYearEnd time.Time
+	quarter       int
+}
+
+// NewPeriodKey 会計年度の期末日（YYYY-MM-DD）と TypeOfCurrentPeriod（1Q / 2Q / 3Q / FY）から PeriodKey を作る
+// TypeOfCurrentPeriod が四半期・通期以外、または期末日が不正な場合は false を返す
+func NewPeriodKey(fiscalYearEndDate string, typeOfCurrentPeriod string) (PeriodKey, bool) {
+	quarter, ok := periodQuarters[typeOfCurrentPeriod]
+	if !ok {
+		return PeriodKey{}, false
+	}
+	// 期末日は月末なので年月だけで比較する（うるう年の2月末なども同じ年月として扱う）
+	fiscalYearEnd, err := time.Parse("2006-01", firstN(fiscalYearEndDate, 7))
+	if err != nil {
+		return PeriodKey{}, false
+	}
+	return PeriodKey{fiscalYearEnd: fiscalYearEnd, quarter: quarter}, true
+}
+
+// IsFullYear 通期（FY）か
+func (k PeriodKey) IsFullYear() bool {
+	return k.quarter == 4
+}
+
+// LastYear 前年同期
+func (k PeriodKey) LastYear() PeriodKey {
+	return PeriodKey{fiscalYearEnd: k.fiscalYearEnd.AddDate(-1, 0, 0), quarter: k.quarter}
+}
+
+// LastFiscalYear 前期の通期（FY）
+func (k PeriodKey) LastFiscalYear() PeriodKey {
+	return PeriodKey{fiscalYearEnd: k.fiscalYearEnd.AddDate(-1, 0, 0), quarter: 4}
+}
+
+// PreviousQuarter 前の四半期（第1四半期の場合は前期の通期）
+func (k PeriodKey) PreviousQuarter() PeriodKey {
+	if k.quarter == 1 {
+		return k.LastFiscalYear()
+	}
+	return PeriodKey{fiscalYearEnd: k.fiscalYearEnd, quarter: k.quarter - 1}
+}
+
+// LaterDisclosure a が b より新しい開示か（開示日、同じ日の場合は開示番号で比較する。訂正開示を優先するため）
+func LaterDisclosure(a *models.FinancialMetric, b *models.FinancialMetric) bool {
+	if a.DisclosedDate != b.DisclosedDate {
+		return a.DisclosedDate > b.DisclosedDate
+	}
+	return a.DisclosureNumber > b.DisclosureNumber
+}
+
+// ratio numerator / denominator（%）
+func ratio(numerator *float64, denominator *float64) *float64 {
+	if numerator == nil || denominator == nil || *denominator == 0 {
+		return nil
+	}
+	value := *numerator / *denominator * 100
+	return &value
+}
+
+// growth 基準値からの増減率（%）。基準値が0以下（赤字など）の場合は計算しない
+func growth(current *float64, base *float64) *float64 {
+	if current == nil || base == nil || *base <= 0 {
+		return nil
+	}
+	value := (*current / *base - 1) * 100
+	return &value
+}
+
+func difference(a *float64, b *float64) *float64 {
+	if a == nil || b == nil {
+		return nil
+	}
+	value := *a - *b
+	return &value
+}
+
+func stringValue(raw map[string]interface{}, key string) string {
+	if value, ok := raw[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// numberValue 数値の項目を取り出す（空文字・不正な値は null）
+func numberValue(raw map[string]interface{}, key string) *float64 {
+	switch value := raw[key].(type) {
+	case float64:
+		return &value
+	case string:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil
+		}
+		return &parsed
+	default:
+		return nil
+	}
+}
+
+// percentValue J-Quants の比率（0.523 = 52.3%）を%に変換する
+func percentValue(raw map[string]interface{}, key string) *float64 {
+	value := numberValue(raw, key)
+	if value == nil {
+		return nil
+	}
+	percent := *value * 100
+	return &percent
+}
+
+func firstN(s string, n int) string {
+	if len(s) < n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,243 @@
+package jquants
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	authUserURL    = "https://api.jquants.com/v1/token/auth_user"
+	authRefreshURL = "https://api.jquants.com/v1/token/auth_refresh"
+
+	// IDトークンの有効期限は24時間。期限切れ直前のリクエストが失敗しないよう余裕を持って更新する
+	idTokenTTL          = 24 * time.Hour
+	idTokenRefreshSlack = 10 * time.Minute
+)
+
+// ErrCredentialsNotSet J-Quantsの認証情報（メールアドレス/パスワード、またはリフレッシュトークン）が未設定
+var ErrCredentialsNotSet = errors.New("J-Quants credentials are not set (JQUANTS_MAIL_ADDRESS/JQUANTS_PASSWORD or JQUANTS_REFRESH_TOKEN)")
+
+// Client J-Quants APIクライアント
+// メールアドレス/パスワードまたはリフレッシュトークンからIDトークンを取得してキャッシュし、
+// 有効期限切れや401応答の際には自動で再取得する
+type Client struct {
+	mailAddress  string
+	password     string
+	refreshToken string
+
+	idToken          string
+	idTokenExpiresAt time.Time
+
+	httpClient *http.Client
+	mu         sync.Mutex
+}
+
+type authUserRequest struct {
+	MailAddress string `json:"mailaddress"`
+	Password    string `json:"password"`
+}
+
+type authUserResponse struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type authRefreshResponse struct {
+	IDToken string `json:"idToken"`
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// NewClient メールアドレスとパスワードでログインするクライアントを作成する
+func NewClient(mailAddress string, password string) *Client {
+	return &Client{
+		mailAddress: mailAddress,
+		password:    password,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// NewClientWithRefreshToken 取得済みのリフレッシュトークンからIDトークンを取得するクライアントを作成する
+func NewClientWithRefreshToken(refreshToken string) *Client {
+	return &Client{
+		refreshToken: refreshToken,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// NewClientFromEnv 環境変数から認証情報を読み込んでクライアントを作成する
+// JQUANTS_REFRESH_TOKEN が優先され、失効した場合は JQUANTS_MAIL_ADDRESS / JQUANTS_PASSWORD で再ログインする
+// 認証情報が無くてもクライアントは作成され、最初のリクエスト時に ErrCredentialsNotSet を返す
+func NewClientFromEnv() *Client {
+	return &Client{
+		mailAddress:  os.Getenv("JQUANTS_MAIL_ADDRESS"),
+		password:     os.Getenv("JQUANTS_PASSWORD"),
+		refreshToken: os.Getenv("JQUANTS_REFRESH_TOKEN"),
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// IDToken 有効なIDトークンを返す（キャッシュが有効ならそれを使い、無ければ再取得する）
+func (c *Client) IDToken() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.idToken != "" && time.Now().Before(c.idTokenExpiresAt) {
+		return c.idToken, nil
+	}
+	return c.renewIDTokenLocked()
+}
+
+// Get IDトークンをヘッダーに付与してGETリクエストを送信する
+// 401が返った場合はIDトークンを再取得して1回だけリトライする
+func (c *Client) Get(rawURL string) (*http.Response, error) {
+	idToken, err := c.IDToken()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(rawURL, idToken)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	// IDトークンが失効している（または無効化された）ので再取得してリトライ
+	idToken, err = c.forceRenewIDToken(idToken)
+	if err != nil {
+		return nil, err
+	}
+	return c.get(rawURL, idToken)
+}
+
+func (c *Client) get(rawURL string, idToken string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// IDトークンをヘッダーに付与
+	req.Header.Set("Authorization", "Bearer "+idToken)
+
+	return c.httpClient.Do(req)
+}
+
+// forceRenewIDToken 401を受け取ったIDトークンを破棄して再取得する
+// 並行リクエストが既に更新済みであれば、その新しいトークンをそのまま返す
+func (c *Client) forceRenewIDToken(staleToken string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.idToken != "" && c.idToken != staleToken && time.Now().Before(c.idTokenExpiresAt) {
+		return c.idToken, nil
+	}
+	c.idToken = ""
+	return c.renewIDTokenLocked()
+}
+
+// renewIDTokenLocked リフレッシュトークンからIDトークンを取得する（呼び出し側でロックを保持すること）
+// リフレッシュトークンが無い・失効している場合は、メールアドレス/パスワードで再ログインしてから取得する
+func (c *Client) renewIDTokenLocked() (string, error) {
+	if c.refreshToken == "" {
+		if err := c.loginLocked(); err != nil {
+			return "", err
+		}
+	}
+
+	idToken, err := c.requestIDToken()
+	if err != nil {
+		// リフレッシュトークン（有効期限1週間）が失効している可能性があるので、ログイン可能なら再ログインする
+		if c.mailAddress == "" || c.password == "" {
+			return "", err
+		}
+		if loginErr := c.loginLocked(); loginErr != nil {
+			return "", fmt.Errorf("%v; re-login also failed: %w", err, loginErr)
+		}
+		idToken, err = c.requestIDToken()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	c.idToken = idToken
+	c.idTokenExpiresAt = time.Now().Add(idTokenTTL - idTokenRefreshSlack)
+	return c.idToken, nil
+}
+
+// loginLocked メールアドレス/パスワードでログインしてリフレッシュトークンを取得する
+func (c *Client) loginLocked() error {
+	if c.mailAddress == "" || c.password == "" {
+		return ErrCredentialsNotSet
+	}
+
+	body, err := json.Marshal(authUserRequest{MailAddress: c.mailAddress, Password: c.password})
+	if err != nil {
+		return fmt.Errorf("failed to marshal auth request: %w", err)
+	}
+
+	resp, err := c.httpClient.Post(authUserURL, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to call auth_user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get refresh token: %s", describeError(resp))
+	}
+
+	var result authUserResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode auth_user response: %w", err)
+	}
+	if result.RefreshToken == "" {
+		return errors.New("auth_user response did not contain refreshToken")
+	}
+
+	c.refreshToken = result.RefreshToken
+	return nil
+}
+
+// requestIDToken リフレッシュトークンをIDトークンに交換する
+func (c *Client) requestIDToken() (string, error) {
+	rawURL := authRefreshURL + "?refreshtoken=" + url.QueryEscape(c.refreshToken)
+
+	resp, err := c.httpClient.Post(rawURL, "application/json", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to call auth_refresh: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get id token: %s", describeError(resp))
+	}
+
+	var result authRefreshResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode auth_refresh response: %w", err)
+	}
+	if result.IDToken == "" {
+		return "", errors.New("auth_refresh response did not contain idToken")
+	}
+
+	return result.IDToken, nil
+}
+
+// describeError J-Quantsのエラーレスポンス（{"message": "..."}）をステータスと合わせて文字列化する
+func describeError(resp *http.Response) string {
+	var errResp errorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil && errResp.Message != "" {
+		return fmt.Sprintf("status %d: %s", resp.StatusCode, errResp.Message)
+	}
+	return fmt.Sprintf("status %d", resp.StatusCode)
+}
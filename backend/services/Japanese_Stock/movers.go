@@ -0,0 +1,309 @@
+package japanesestock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"stock-prediction/backend/dto"
+	"stock-prediction/backend/models"
+	companies "stock-prediction/backend/services/Japanese_Stock/companies"
+	stockdata "stock-prediction/backend/services/Japanese_Stock/stock_data"
+	"stock-prediction/backend/services/calendar"
+	"strconv"
+	"time"
+)
+
+const (
+	// 市場区分・カテゴリごとに保存する順位の数
+	moversRankingSize = 20
+	// 売買代金の下限のデフォルト（円）。薄商いの銘柄が値上がり率・値下がり率の上位を占めないようにする
+	defaultMoversMinTurnover = 100_000_000
+	// 保存済みの前営業日の日足が当日の件数のこの割合に満たない場合は、J-Quantsから全銘柄分を取得し直す
+	// （セクター同期で一部の銘柄だけ保存されている場合のため）
+	previousQuotesCoverage = 0.9
+)
+
+// moversSegments ランキングを計算する市場区分
+var moversSegments = []string{
+	models.MarketSegmentAll,
+	models.MarketSegmentPrime,
+	models.MarketSegmentStandard,
+	models.MarketSegmentGrowth,
+}
+
+// ErrRankingNotFound 指定した日・カテゴリ・市場区分のランキングが保存されていない
+var ErrRankingNotFound = errors.New("ranking not found")
+
+// MoversFilter ランキングの計算条件
+type MoversFilter struct {
+	Date        string   `json:"Date"`        // 取引日（YYYY-MM-DD）。省略時は東証の直近の取引日、休場日の場合は直前の取引日
+	MinTurnover *float64 `json:"MinTurnover"` // 売買代金の下限（円）。省略時は JP_MOVERS_MIN_TURNOVER、未設定の場合は1億円
+}
+
+// MoversReport ランキング計算の結果
+type MoversReport struct {
+	Date         string  `json:"Date"`
+	PreviousDate string  `json:"PreviousDate"`
+	MinTurnover  float64 `json:"MinTurnover"`
+	Quotes       int     `json:"Quotes"`     // 当日の日足の件数
+	Candidates   int     `json:"Candidates"` // 前日比を計算でき、売買代金の下限を満たした銘柄数
+	Rankings     int     `json:"Rankings"`   // 保存したランキングの行数（全市場区分・全カテゴリの合計）
+}
+
+// mover ランキング候補の1銘柄
+type mover struct {
+	quote         models.DailyQuote
+	company       models.Company
+	segment       string
+	previousClose float64
+	changeAmount  float64
+	changeRate    float64
+}
+
+// SyncMovers 全銘柄の日足から値上がり率・値下がり率・売買代金のランキングを市場区分ごとに計算して保存する
+// 銘柄マスタ → 当日の全銘柄の日足 → 前営業日の日足（保存済みで足りない場合のみ取得） の順に同期してから計算する
+// 同じ日付のランキングは計算し直した内容で置き換える。ctx がキャンセルされた場合は次の段階に進まずに ctx のエラーを返す
+func (s *japanesestockservice) SyncMovers(ctx context.Context, filter MoversFilter) (*MoversReport, error) {
+	date := filter.Date
+	if date == "" {
+		date = calendar.TSE.LatestSessionDate(time.Now())
+	}
+	date, err := calendar.TSE.SnapDate(date)
+	if err != nil {
+		return nil, err
+	}
+	tradingDate, _ := calendar.TSE.ParseDate(date)
+
+	report := &MoversReport{
+		Date:         date,
+		PreviousDate: calendar.TSE.PreviousTradingDay(tradingDate).Format("2006-01-02"),
+		MinTurnover:  moversMinTurnover(filter.MinTurnover),
+	}
+
+	// 1. 銘柄マスタ（市場区分の判定に使うので新規上場・市場変更を反映しておく）
+	listedInfo, err := companies.FetchJQuantsCompanies(s.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch J-Quants companies: %w", err)
+	}
+	if err := companies.SaveJQuantsCompaniesToDB(listedInfo, s.repository); err != nil {
+		return nil, fmt.Errorf("failed to save companies to DB: %w", err)
+	}
+	listedCompanies, err := s.repository.FindCompaniesBySector("", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find companies: %w", err)
+	}
+
+	// 2. 当日の全銘柄の日足
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	quotes, err := stockdata.SyncJQuantsDailyQuotesByDate(s.client, report.Date, s.repository)
+	if err != nil {
+		return nil, err
+	}
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("no daily quotes for %s (J-Quants may not have published them yet)", report.Date)
+	}
+	report.Quotes = len(quotes)
+
+	// 3. 前営業日の日足
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	previousQuotes, err := s.repository.FindDailyQuotesByDate(report.PreviousDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find daily quotes for %s: %w", report.PreviousDate, err)
+	}
+	if float64(len(previousQuotes)) < float64(len(quotes))*previousQuotesCoverage {
+		previousQuotes, err = stockdata.SyncJQuantsDailyQuotesByDate(s.client, report.PreviousDate, s.repository)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 4. ランキングを計算して保存
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	movers := findMovers(quotes, previousQuotes, listedCompanies, report.MinTurnover)
+	report.Candidates = len(movers)
+	rankings := rankMovers(report.Date, movers)
+	if err := s.repository.ReplaceJapaneseDailyRankings(report.Date, rankings); err != nil {
+		return nil, fmt.Errorf("failed to save rankings for %s: %w", report.Date, err)
+	}
+	report.Rankings = len(rankings)
+
+	log.Printf("Computed J-Quants movers for %s: %d quotes, %d candidates, %d rankings", report.Date, report.Quotes, report.Candidates, report.Rankings)
+	return report, nil
+}
+
+// findMovers 当日と前営業日の日足から、ランキングの候補（前日比を計算でき、売買代金が下限以上の銘柄）を取り出す
+// プライム・スタンダード・グロース以外の銘柄（ETF・REITなど）と、どちらかの日に売買が無かった銘柄は除外する
+func findMovers(quotes []models.DailyQuote, previousQuotes []models.DailyQuote, listedCompanies []models.Company, minTurnover float64) []mover {
+	companiesByCode := make(map[string]models.Company, len(listedCompanies))
+	for _, company := range listedCompanies {
+		companiesByCode[company.Code] = company
+	}
+	previousCloses := make(map[string]float64, len(previousQuotes))
+	for _, quote := range previousQuotes {
+		previousCloses[quote.Code] = quote.Close
+	}
+
+	var movers []mover
+	for _, quote := range quotes {
+		company, ok := companiesByCode[quote.Code]
+		if !ok {
+			continue
+		}
+		segment, ok := models.MarketSegmentsByCode[company.MarketCode]
+		if !ok {
+			continue
+		}
+		previousClose := previousCloses[quote.Code]
+		if quote.Close <= 0 || previousClose <= 0 || quote.TurnoverValue < minTurnover {
+			continue
+		}
+
+		// 株式分割・併合の当日は調整係数（例: 1:2分割なら0.5）を掛けて前日の終値を当日の株数に揃える
+		if quote.AdjustmentFactor > 0 {
+			previousClose *= quote.AdjustmentFactor
+		}
+		changeAmount := quote.Close - previousClose
+		movers = append(movers, mover{
+			quote:         quote,
+			company:       company,
+			segment:       segment,
+			previousClose: previousClose,
+			changeAmount:  changeAmount,
+			changeRate:    changeAmount / previousClose * 100,
+		})
+	}
+	return movers
+}
+
+// rankMovers 市場区分・カテゴリごとに上位 moversRankingSize 件を順位付けする
+//   - Top Gainers: 値上がり率の高い順（値上がりした銘柄のみ）
+//   - Top Losers: 値下がり率の高い順（値下がりした銘柄のみ）
+//   - Most Actively Traded: 売買代金の多い順
+func rankMovers(date string, movers []mover) []models.JapaneseDailyRanking {
+	categories := []struct {
+		name    string
+		include func(m mover) bool
+		less    func(a, b mover) bool
+	}{
+		{
+			name:    models.CategoryTopGainers,
+			include: func(m mover) bool { return m.changeRate > 0 },
+			less:    func(a, b mover) bool { return a.changeRate > b.changeRate },
+		},
+		{
+			name:    models.CategoryTopLosers,
+			include: func(m mover) bool { return m.changeRate < 0 },
+			less:    func(a, b mover) bool { return a.changeRate < b.changeRate },
+		},
+		{
+			name:    models.CategoryMostActivelyTraded,
+			include: func(m mover) bool { return true },
+			less:    func(a, b mover) bool { return a.quote.TurnoverValue > b.quote.TurnoverValue },
+		},
+	}
+
+	var rankings []models.JapaneseDailyRanking
+	for _, segment := range moversSegments {
+		for _, category := range categories {
+			var candidates []mover
+			for _, m := range movers {
+				if (segment == models.MarketSegmentAll || m.segment == segment) && category.include(m) {
+					candidates = append(candidates, m)
+				}
+			}
+			// 同じ値の場合は銘柄コード順にして、再計算しても順位が変わらないようにする
+			sort.SliceStable(candidates, func(i, j int) bool {
+				if category.less(candidates[i], candidates[j]) {
+					return true
+				}
+				if category.less(candidates[j], candidates[i]) {
+					return false
+				}
+				return candidates[i].quote.Code < candidates[j].quote.Code
+			})
+			if len(candidates) > moversRankingSize {
+				candidates = candidates[:moversRankingSize]
+			}
+
+			for i, m := range candidates {
+				rankings = append(rankings, models.JapaneseDailyRanking{
+					Date:             date,
+					Segment:          segment,
+					Category:         category.name,
+					Rank:             i + 1,
+					Code:             m.quote.Code,
+					CompanyName:      m.company.CompanyName,
+					MarketCode:       m.company.MarketCode,
+					MarketCodeName:   m.company.MarketCodeName,
+					Sector33CodeName: m.company.Sector33CodeName,
+					Price:            m.quote.Close,
+					PreviousClose:    m.previousClose,
+					ChangeAmount:     m.changeAmount,
+					ChangeRate:       m.changeRate,
+					Volume:           m.quote.Volume,
+					TurnoverValue:    m.quote.TurnoverValue,
+				})
+			}
+		}
+	}
+	return rankings
+}
+
+// moversMinTurnover 売買代金の下限を決める（指定 → JP_MOVERS_MIN_TURNOVER → デフォルトの順）
+func moversMinTurnover(minTurnover *float64) float64 {
+	if minTurnover != nil {
+		return *minTurnover
+	}
+	if value := os.Getenv("JP_MOVERS_MIN_TURNOVER"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err == nil && parsed >= 0 {
+			return parsed
+		}
+		log.Printf("Warning: invalid JP_MOVERS_MIN_TURNOVER %q, using default %d", value, defaultMoversMinTurnover)
+	}
+	return defaultMoversMinTurnover
+}
+
+// FindLatestRanking 日本株の最新のランキングの1〜limit位を返す
+// category は DailyRanking.Category の値、segment は JapaneseDailyRanking.Segment の値。ランキングが無い場合は ErrRankingNotFound を返す
+func (s *japanesestockservice) FindLatestRanking(category string, segment string, limit int) ([]dto.JapaneseDailyRanking, error) {
+	latestDate, err := s.repository.FindLatestJapaneseRankingDate(category, segment)
+	if err != nil {
+		return nil, err
+	}
+	if latestDate == "" {
+		return nil, ErrRankingNotFound
+	}
+	rankings, err := s.repository.FindJapaneseDailyRanking(latestDate, category, segment, limit)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewJapaneseDailyRankings(rankings), nil
+}
+
+// FindDailyRanking 日本株の指定日のランキングの1〜limit位を返す
+// 休場日が指定された場合は、その日以前の直近の取引日のランキングを返す
+// 日付の形式が不正な場合は calendar.ErrInvalidDate、ランキングが無い場合は ErrRankingNotFound を返す
+func (s *japanesestockservice) FindDailyRanking(date string, category string, segment string, limit int) ([]dto.JapaneseDailyRanking, error) {
+	tradingDate, err := calendar.TSE.SnapDate(date)
+	if err != nil {
+		return nil, err
+	}
+	rankings, err := s.repository.FindJapaneseDailyRanking(tradingDate, category, segment, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(rankings) == 0 {
+		return nil, ErrRankingNotFound
+	}
+	return dto.NewJapaneseDailyRankings(rankings), nil
+}
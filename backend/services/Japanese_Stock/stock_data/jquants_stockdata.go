@@ -4,9 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"stock-prediction/backend/models"
 	"stock-prediction/backend/repositories"
-	"time"
+	"stock-prediction/backend/services/Japanese_Stock/jquants"
 )
 
 // DailyQuoteResponse J-Quants APIレスポンス用の型（DBモデルとは別）
@@ -28,22 +29,15 @@ type DailyQuoteResponse struct {
 }
 
 type ListedDailyQuoteResponse struct {
-	DailyQuotes []DailyQuoteResponse `json:"daily_quotes"`
+	DailyQuotes   []DailyQuoteResponse `json:"daily_quotes"`
+	PaginationKey string               `json:"pagination_key"` // 続きがある場合のみ返る
 }
 
-func FetchJQuantsStockData(idToken string, code string, from string, to string) (*ListedDailyQuoteResponse, error) {
+func FetchJQuantsStockData(client *jquants.Client, code string, from string, to string) (*ListedDailyQuoteResponse, error) {
 	url := fmt.Sprintf("https://api.jquants.com/v1/prices/daily_quotes?code=%s&from=%s&to=%s", code, from, to)
-	client := &http.Client{Timeout: 30 * time.Second}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// IDトークンをヘッダーに付与
-	req.Header.Set("Authorization", "Bearer "+idToken)
-
-	resp, err := client.Do(req)
+	// IDトークンの付与・401時の再取得はクライアント側で行う
+	resp, err := client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -62,40 +56,78 @@ func FetchJQuantsStockData(idToken string, code string, from string, to string)
 	return &result, nil
 }
 
-// SaveJQuantsStockDataToDB 日足株価データをDBに保存する
-func SaveJQuantsStockDataToDB(stockData *ListedDailyQuoteResponse, repository repositories.IJapaneseStockRepository) error {
-	for _, quote := range stockData.DailyQuotes {
-		// DailyQuoteResponse -> models.DailyQuoteに変換する
-		dailyQuote := &models.DailyQuote{
-			Code:             quote.Code,
-			Date:             quote.Date,
-			Open:             quote.Open,
-			High:             quote.High,
-			Low:              quote.Low,
-			Close:            quote.Close,
-			Volume:           quote.Volume,
-			TurnoverValue:    quote.TurnoverValue,
-			AdjustmentFactor: quote.AdjustmentFactor,
-			AdjustmentOpen:   quote.AdjustmentOpen,
-			AdjustmentHigh:   quote.AdjustmentHigh,
-			AdjustmentLow:    quote.AdjustmentLow,
-			AdjustmentClose:  quote.AdjustmentClose,
-			AdjustmentVolume: quote.AdjustmentVolume,
+// FetchJQuantsDailyQuotesByDate 指定日（YYYY-MM-DD）の全銘柄の日足を取得する
+// 全銘柄分はレスポンスが分割されるので、pagination_key が返らなくなるまで続けて取得する
+func FetchJQuantsDailyQuotesByDate(client *jquants.Client, date string) (*ListedDailyQuoteResponse, error) {
+	var result ListedDailyQuoteResponse
+	paginationKey := ""
+	for {
+		endpoint := fmt.Sprintf("https://api.jquants.com/v1/prices/daily_quotes?date=%s", date)
+		if paginationKey != "" {
+			endpoint += "&pagination_key=" + url.QueryEscape(paginationKey)
 		}
 
-		// 既存なら更新、新規なら保存する
-		err := repository.CreateOrUpdateDailyQuote(dailyQuote)
+		// IDトークンの付与・401時の再取得はクライアント側で行う
+		resp, err := client.Get(endpoint)
 		if err != nil {
-			return fmt.Errorf("failed to create/update daily quote %s %s: %w", quote.Code, quote.Date, err)
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+
+		var page ListedDailyQuoteResponse
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to fetch daily quotes for %s: status %d", date, resp.StatusCode)
 		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		result.DailyQuotes = append(result.DailyQuotes, page.DailyQuotes...)
+		if page.PaginationKey == "" {
+			return &result, nil
+		}
+		paginationKey = page.PaginationKey
+	}
+}
+
+// SyncJQuantsDailyQuotesByDate 指定日の全銘柄の日足を取得してDBに保存し、保存した日足を返す
+// 売買が無かった銘柄も（終値0で）保存する
+func SyncJQuantsDailyQuotesByDate(client *jquants.Client, date string, repository repositories.IJapaneseStockRepository) ([]models.DailyQuote, error) {
+	stockData, err := FetchJQuantsDailyQuotesByDate(client, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JQuants daily quotes: %w", err)
+	}
+
+	dailyQuotes := make([]models.DailyQuote, 0, len(stockData.DailyQuotes))
+	for _, quote := range stockData.DailyQuotes {
+		dailyQuotes = append(dailyQuotes, toDailyQuote(quote))
+	}
+	if err := repository.UpsertDailyQuotes(dailyQuotes); err != nil {
+		return nil, fmt.Errorf("failed to save daily quotes for %s: %w", date, err)
+	}
+	return dailyQuotes, nil
+}
+
+// SaveJQuantsStockDataToDB 日足株価データをDBに保存する（まとめて upsert する）
+func SaveJQuantsStockDataToDB(stockData *ListedDailyQuoteResponse, repository repositories.IJapaneseStockRepository) error {
+	dailyQuotes := make([]models.DailyQuote, 0, len(stockData.DailyQuotes))
+	for _, quote := range stockData.DailyQuotes {
+		dailyQuotes = append(dailyQuotes, toDailyQuote(quote))
+	}
+
+	// 既存なら更新、新規なら保存する
+	if err := repository.UpsertDailyQuotes(dailyQuotes); err != nil {
+		return fmt.Errorf("failed to upsert %d daily quotes: %w", len(dailyQuotes), err)
 	}
 
 	return nil
 }
 
-func SyncJQuantsStockData(idToken string, code string, from string, to string, repository repositories.IJapaneseStockRepository)([]models.DailyQuote, error) {
+func SyncJQuantsStockData(client *jquants.Client, code string, from string, to string, repository repositories.IJapaneseStockRepository)([]models.DailyQuote, error) {
 	// JQuants APIから株価データを取得
-	stockData, err := FetchJQuantsStockData(idToken, code, from, to)
+	stockData, err := FetchJQuantsStockData(client, code, from, to)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch JQuants stock data: %w", err)
 	}
@@ -112,4 +144,24 @@ func SyncJQuantsStockData(idToken string, code string, from string, to string, r
 		return nil, fmt.Errorf("failed to find daily quotes by code: %w", err)
 	}
 	return dailyQuotes, nil
-}
\ No newline at end of file
+}
+
+// toDailyQuote DailyQuoteResponse -> models.DailyQuoteに変換する
+func toDailyQuote(quote DailyQuoteResponse) models.DailyQuote {
+	return models.DailyQuote{
+		Code:             quote.Code,
+		Date:             quote.Date,
+		Open:             quote.Open,
+		High:             quote.High,
+		Low:              quote.Low,
+		Close:            quote.Close,
+		Volume:           quote.Volume,
+		TurnoverValue:    quote.TurnoverValue,
+		AdjustmentFactor: quote.AdjustmentFactor,
+		AdjustmentOpen:   quote.AdjustmentOpen,
+		AdjustmentHigh:   quote.AdjustmentHigh,
+		AdjustmentLow:    quote.AdjustmentLow,
+		AdjustmentClose:  quote.AdjustmentClose,
+		AdjustmentVolume: quote.AdjustmentVolume,
+	}
+}
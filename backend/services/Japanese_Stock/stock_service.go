@@ -1 +1,325 @@
 package japanesestock
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"stock-prediction/backend/dto"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/Japanese_Stock/analysis"
+	companies "stock-prediction/backend/services/Japanese_Stock/companies"
+	fundamentals "stock-prediction/backend/services/Japanese_Stock/fandamentals"
+	"stock-prediction/backend/services/Japanese_Stock/jquants"
+	jpnews "stock-prediction/backend/services/Japanese_Stock/news"
+	stockdata "stock-prediction/backend/services/Japanese_Stock/stock_data"
+	"stock-prediction/backend/services/progress"
+	"stock-prediction/backend/services/valuation"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// 株価データは過去6ヶ月分、財務諸表は過去5年分を取得する
+	priceHistoryMonths     = 6
+	statementHistoryYears  = 5
+	syncCompanyConcurrency = 4
+)
+
+// SectorFilter 同期対象のセクター条件
+// コード（例: "5250"）と名称（例: "情報・通信業"）のどちらでも指定できる
+type SectorFilter struct {
+	Sector33 string `json:"Sector33"` // 33業種コードまたは名称
+	Sector17 string `json:"Sector17"` // 17業種コードまたは名称
+}
+
+// CompanySyncResult 1社分の同期結果
+type CompanySyncResult struct {
+	Code                string   `json:"Code"`
+	CompanyName         string   `json:"CompanyName"`
+	Success             bool     `json:"Success"`
+	DailyQuotes         int      `json:"DailyQuotes"`         // 保存した日足の件数
+	FinancialStatements int      `json:"FinancialStatements"` // 保存した財務諸表の件数
+	NewsSearchID        uint     `json:"NewsSearchID"`        // 保存したニュース検索バッチのID
+	Errors              []string `json:"Errors,omitempty"`
+}
+
+// SyncReport セクター同期全体の結果
+type SyncReport struct {
+	Sector33       string              `json:"Sector33"`
+	Sector17       string              `json:"Sector17"`
+	PriceFrom      string              `json:"PriceFrom"`
+	PriceTo        string              `json:"PriceTo"`
+	StatementsFrom string              `json:"StatementsFrom"`
+	Total          int                 `json:"Total"`
+	Succeeded      int                 `json:"Succeeded"`
+	Failed         int                 `json:"Failed"`
+	Companies      []CompanySyncResult `json:"Companies"`
+}
+
+// AnalysisReport セクター分析（Phase 1 → Phase 2）全体の結果
+type AnalysisReport struct {
+	Sector33   string                            `json:"Sector33"`
+	Sector17   string                            `json:"Sector17"`
+	AnalyzedAt time.Time                         `json:"AnalyzedAt"`
+	Total      int                               `json:"Total"`
+	Succeeded  int                               `json:"Succeeded"`
+	Failed     int                               `json:"Failed"`
+	Companies  []analysis.CompanyAnalysisOutcome `json:"Companies"`
+}
+
+type IJapaneseStockService interface {
+	SyncSector(ctx context.Context, filter SectorFilter, reporter progress.Reporter) (*SyncReport, error)
+	AnalyzeSector(ctx context.Context, filter SectorFilter) (*AnalysisReport, error)
+	CompareSector(ctx context.Context, sector33 string, analysisDate string) (*models.SectorAnalysisResult, error)
+	SyncMovers(ctx context.Context, filter MoversFilter) (*MoversReport, error)
+	FindLatestRanking(category string, segment string, limit int) ([]dto.JapaneseDailyRanking, error)
+	FindDailyRanking(date string, category string, segment string, limit int) ([]dto.JapaneseDailyRanking, error)
+}
+
+type japanesestockservice struct {
+	repository    repositories.IJapaneseStockRepository
+	client        *jquants.Client
+	analysisJob   analysis.IAnalysisJob
+	comparisonJob analysis.ISectorComparisonJob
+}
+
+// NewJapaneseStockService analysisJob / comparisonJob は分析サービス（gRPC）が未設定の場合nilでもよい
+// （その場合 AnalyzeSector / CompareSector がエラーを返す）
+func NewJapaneseStockService(repository repositories.IJapaneseStockRepository, client *jquants.Client, analysisJob analysis.IAnalysisJob, comparisonJob analysis.ISectorComparisonJob) IJapaneseStockService {
+	return &japanesestockservice{repository: repository, client: client, analysisJob: analysisJob, comparisonJob: comparisonJob}
+}
+
+// SyncSector 銘柄マスタ → 日足株価（6ヶ月） → 財務諸表（5年） → ニュース の順に同期する
+// 銘柄マスタの取得に失敗した場合はエラーを返し、それ以降の個社の失敗はレポートに記録して続行する
+// 個社ごとの進捗は reporter に銘柄コード単位で通知する
+// ctx がキャンセルされた場合は未着手の銘柄を同期せずに ctx のエラーを返す
+func (s *japanesestockservice) SyncSector(ctx context.Context, filter SectorFilter, reporter progress.Reporter) (*SyncReport, error) {
+	if filter.Sector33 == "" && filter.Sector17 == "" {
+		return nil, fmt.Errorf("sector33 or sector17 is required")
+	}
+
+	tavilyApiKey := os.Getenv("TAVILY_API_KEY")
+	if tavilyApiKey == "" {
+		return nil, fmt.Errorf("TAVILY_API_KEY is not set")
+	}
+
+	// 1. 銘柄マスタを取得してDBに保存
+	listedInfo, err := companies.FetchJQuantsCompanies(s.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch J-Quants companies: %w", err)
+	}
+	if err := companies.SaveJQuantsCompaniesToDB(listedInfo, s.repository); err != nil {
+		return nil, fmt.Errorf("failed to save companies to DB: %w", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// 2. セクター条件で対象銘柄を絞り込む
+	targets, err := s.repository.FindCompaniesBySector(filter.Sector33, filter.Sector17)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find companies by sector: %w", err)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no companies found for sector33=%q sector17=%q", filter.Sector33, filter.Sector17)
+	}
+
+	now := time.Now()
+	report := &SyncReport{
+		Sector33:       filter.Sector33,
+		Sector17:       filter.Sector17,
+		PriceFrom:      now.AddDate(0, -priceHistoryMonths, 0).Format("2006-01-02"),
+		PriceTo:        now.Format("2006-01-02"),
+		StatementsFrom: now.AddDate(-statementHistoryYears, 0, 0).Format("2006-01-02"),
+		Total:          len(targets),
+		Companies:      make([]CompanySyncResult, len(targets)),
+	}
+
+	log.Printf("Starting J-Quants sync for %d companies (sector33=%q sector17=%q)...", len(targets), filter.Sector33, filter.Sector17)
+
+	// 3. 各社の株価・財務・ニュースを並列で同期する（API負荷を考慮して同時実行数を制限）
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, syncCompanyConcurrency)
+	for i, company := range targets {
+		wg.Add(1)
+		go func(i int, c models.Company) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			if ctx.Err() != nil {
+				return
+			}
+
+			reporter.Report(c.Code, progress.StatusRunning, "")
+			result := s.syncCompany(ctx, c, report, tavilyApiKey)
+			if result.Success {
+				reporter.Report(c.Code, progress.StatusSucceeded, "")
+			} else {
+				reporter.Report(c.Code, progress.StatusFailed, strings.Join(result.Errors, "; "))
+			}
+			report.Companies[i] = result
+		}(i, company)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("J-Quants sync was canceled: %w", err)
+	}
+
+	for _, result := range report.Companies {
+		if result.Success {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+
+	log.Printf("Completed J-Quants sync: %d/%d companies succeeded", report.Succeeded, report.Total)
+	return report, nil
+}
+
+// syncCompany 1社分の日足株価・財務諸表・ニュースを同期する
+// 途中の処理が失敗しても残りの処理は続行し、エラーを結果に記録する（ctx がキャンセルされた場合は残りの処理を行わない）
+func (s *japanesestockservice) syncCompany(ctx context.Context, company models.Company, report *SyncReport, tavilyApiKey string) CompanySyncResult {
+	result := CompanySyncResult{Code: company.Code, CompanyName: company.CompanyName}
+
+	// 日足株価（過去6ヶ月分）
+	dailyQuotes, err := stockdata.SyncJQuantsStockData(s.client, company.Code, report.PriceFrom, report.PriceTo, s.repository)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("daily quotes: %v", err))
+	} else {
+		result.DailyQuotes = len(dailyQuotes)
+	}
+
+	// 財務諸表（過去5年分）
+	if err := ctx.Err(); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+	statements, err := s.syncFinancialStatements(company.Code, report.StatementsFrom)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("financial statements: %v", err))
+	} else {
+		result.FinancialStatements = statements
+	}
+
+	// ニュース（Tavily）
+	if err := ctx.Err(); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+	newsSearch, err := jpnews.SyncJapaneseStockNews(company.CompanyName, company.Code, tavilyApiKey, s.repository)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("news: %v", err))
+	} else {
+		result.NewsSearchID = newsSearch.ID
+	}
+
+	result.Success = len(result.Errors) == 0
+	if !result.Success {
+		log.Printf("Warning: Failed to fully sync %s (%s): %v", company.CompanyName, company.Code, result.Errors)
+	}
+	return result
+}
+
+// syncFinancialStatements 財務諸表を取得し、from以降に開示されたものだけを保存する
+// 保存後に財務指標（FinancialMetric）とバリュエーション（JapaneseStockValuation）を作り直す
+func (s *japanesestockservice) syncFinancialStatements(code string, from string) (int, error) {
+	financialStatements, err := fundamentals.FetchJQuantsFinancialStatements(s.client, code)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch JQuants financial statements: %w", err)
+	}
+
+	filtered := fundamentals.FilterFinancialStatementsSince(financialStatements, from)
+	if err := fundamentals.SaveJQuantsFinancialStatementsToDB(filtered, s.repository); err != nil {
+		return 0, fmt.Errorf("failed to save JQuants financial statements to DB: %w", err)
+	}
+
+	// 前年同期比の計算には過去の開示も使うので、保存済みの財務諸表すべてから作り直す
+	if _, err := fundamentals.RebuildFinancialMetrics(code, s.repository); err != nil {
+		return 0, fmt.Errorf("failed to rebuild financial metrics: %w", err)
+	}
+	// 日足（先に同期済み）と作り直した財務指標から日次のバリュエーションを計算する
+	if _, err := valuation.SyncJapaneseValuations(code, s.repository); err != nil {
+		return 0, fmt.Errorf("failed to sync valuations: %w", err)
+	}
+
+	return len(filtered.FinancialInfo), nil
+}
+
+// AnalyzeSector 同期済みのデータを使って、セクター内の全銘柄をPython分析サービスで分析する
+// 全銘柄で共通のAnalyzedAtを使い、結果はAnalysisResultに保存される
+// ctx がキャンセルされた場合は未着手の銘柄を分析せずに ctx のエラーを返す
+func (s *japanesestockservice) AnalyzeSector(ctx context.Context, filter SectorFilter) (*AnalysisReport, error) {
+	if s.analysisJob == nil {
+		return nil, fmt.Errorf("analysis service is not configured (ANALYSIS_GRPC_ADDR is not set)")
+	}
+	if filter.Sector33 == "" && filter.Sector17 == "" {
+		return nil, fmt.Errorf("sector33 or sector17 is required")
+	}
+
+	targets, err := s.repository.FindCompaniesBySector(filter.Sector33, filter.Sector17)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find companies by sector: %w", err)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no companies found for sector33=%q sector17=%q", filter.Sector33, filter.Sector17)
+	}
+
+	codes := make([]string, 0, len(targets))
+	for _, company := range targets {
+		codes = append(codes, company.Code)
+	}
+
+	report := &AnalysisReport{
+		Sector33:   filter.Sector33,
+		Sector17:   filter.Sector17,
+		AnalyzedAt: time.Now().Truncate(time.Second),
+		Total:      len(codes),
+	}
+
+	log.Printf("Starting analysis for %d companies (sector33=%q sector17=%q)...", len(codes), filter.Sector33, filter.Sector17)
+	report.Companies = s.analysisJob.AnalyzeCompanies(ctx, codes, report.AnalyzedAt)
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("analysis was canceled: %w", err)
+	}
+
+	for _, outcome := range report.Companies {
+		if outcome.Error == "" {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+
+	log.Printf("Completed analysis: %d/%d companies succeeded", report.Succeeded, report.Total)
+	return report, nil
+}
+
+// CompareSector 指定した分析日のセクター内の分析結果を比較してTop3を選出する
+// sector33 は33業種コード・名称のどちらでもよく、analysisDate が空の場合は日本時間の今日とする
+func (s *japanesestockservice) CompareSector(ctx context.Context, sector33 string, analysisDate string) (*models.SectorAnalysisResult, error) {
+	if s.comparisonJob == nil {
+		return nil, fmt.Errorf("analysis service is not configured (ANALYSIS_GRPC_ADDR is not set)")
+	}
+	if sector33 == "" {
+		return nil, fmt.Errorf("sector33 is required")
+	}
+	if analysisDate == "" {
+		analysisDate = analysis.TodayInTokyo()
+	}
+
+	// 名称で指定された場合に備えて、銘柄マスタから33業種コードを解決する
+	targets, err := s.repository.FindCompaniesBySector(sector33, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find companies by sector: %w", err)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no companies found for sector33=%q", sector33)
+	}
+
+	return s.comparisonJob.CompareSector(ctx, targets[0].Sector33Code, analysisDate)
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"errors"
+	"stock-prediction/backend/dto"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/calendar"
+	"stock-prediction/backend/services/indicators"
+	"time"
+)
+
+// StockAnalysis 銘柄ごとの分析結果（最新 + 過去の履歴）
+type StockAnalysis struct {
+	Code        string               `json:"Code"`
+	CompanyName string               `json:"CompanyName"`
+	Latest      dto.AnalysisResult   `json:"Latest"`
+	History     []dto.AnalysisResult `json:"History"` // Latestより前の分析結果（新しい順）
+}
+
+// SectorTopPick セクター比較で選ばれた銘柄と、その銘柄の分析結果
+type SectorTopPick struct {
+	Rank        int                 `json:"Rank"`
+	Code        string              `json:"Code"`
+	CompanyName string              `json:"CompanyName"`
+	Reasoning   string              `json:"Reasoning"`
+	Analysis    *dto.AnalysisResult `json:"Analysis,omitempty"`
+}
+
+// SectorTopPicks セクターの最新の比較分析結果
+type SectorTopPicks struct {
+	SectorCode     string          `json:"SectorCode"`
+	SectorName     string          `json:"SectorName"`
+	AnalyzedAt     time.Time       `json:"AnalyzedAt"`
+	OverallSummary *string         `json:"OverallSummary"`
+	TopPicks       []SectorTopPick `json:"TopPicks"`
+}
+
+// JapaneseStockValuationHistory 日本株のバリュエーションの推移
+type JapaneseStockValuationHistory struct {
+	Code    string                       `json:"Code"`
+	Latest  dto.JapaneseStockValuation   `json:"Latest"`
+	History []dto.JapaneseStockValuation `json:"History"` // from〜to の日次の値（日付の古い順、Latestを含む）
+}
+
+// ErrAnalysisNotFound 銘柄の分析結果が保存されていない
+var ErrAnalysisNotFound = errors.New("analysis result not found")
+
+type IAnalysisService interface {
+	FindStockAnalysis(code string) (*StockAnalysis, error)
+	FindLatestSectorTopPicks(sectorCode string) (*SectorTopPicks, error)
+	FindIndicators(code string, date string) (*indicators.Snapshot, error)
+	FindFinancialMetrics(code string) ([]models.FinancialMetric, error)
+	FindValuation(code string, from string, to string) (*JapaneseStockValuationHistory, error)
+}
+
+type analysisservice struct {
+	repository repositories.IJapaneseStockRepository
+}
+
+func NewAnalysisService(repository repositories.IJapaneseStockRepository) IAnalysisService {
+	return &analysisservice{repository: repository}
+}
+
+// FindStockAnalysis 銘柄の最新の分析結果と過去の履歴を返す。分析結果が無い場合は ErrAnalysisNotFound を返す
+func (s *analysisservice) FindStockAnalysis(code string) (*StockAnalysis, error) {
+	analysisResults, err := s.repository.FindAnalysisResultsByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	if len(analysisResults) == 0 {
+		return nil, ErrAnalysisNotFound
+	}
+
+	stockAnalysis := &StockAnalysis{
+		Code:    code,
+		Latest:  dto.NewAnalysisResult(analysisResults[0]),
+		History: dto.NewAnalysisResults(analysisResults[1:]),
+	}
+	if company, err := s.repository.FindCompanyByCode(code); err == nil {
+		stockAnalysis.CompanyName = company.CompanyName
+	}
+
+	return stockAnalysis, nil
+}
+
+// FindLatestSectorTopPicks セクターの最新の比較分析結果を返す。無い場合は repositories.ErrNotFound を返す
+func (s *analysisservice) FindLatestSectorTopPicks(sectorCode string) (*SectorTopPicks, error) {
+	sectorResult, err := s.repository.FindLatestSectorAnalysisResult(sectorCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// 紐付いている分析結果を銘柄コードで引けるようにする
+	analysisByCode := make(map[string]*dto.AnalysisResult)
+	for _, result := range sectorResult.AnalysisResults {
+		analysisResult := dto.NewAnalysisResult(result)
+		analysisByCode[result.Code] = &analysisResult
+	}
+
+	topPicks := &SectorTopPicks{
+		SectorCode:     sectorResult.SectorCode,
+		AnalyzedAt:     sectorResult.AnalyzedAt,
+		OverallSummary: sectorResult.OverallSummary,
+	}
+
+	picks := []struct {
+		code      string
+		reasoning string
+	}{
+		{sectorResult.Top1Code, sectorResult.Top1Reasoning},
+		{sectorResult.Top2Code, sectorResult.Top2Reasoning},
+		{sectorResult.Top3Code, sectorResult.Top3Reasoning},
+	}
+	for i, pick := range picks {
+		if pick.code == "" {
+			continue
+		}
+
+		topPick := SectorTopPick{
+			Rank:      i + 1,
+			Code:      pick.code,
+			Reasoning: pick.reasoning,
+			Analysis:  analysisByCode[pick.code],
+		}
+		if company, err := s.repository.FindCompanyByCode(pick.code); err == nil {
+			topPick.CompanyName = company.CompanyName
+			topPicks.SectorName = company.Sector33CodeName
+		}
+		topPicks.TopPicks = append(topPicks.TopPicks, topPick)
+	}
+
+	return topPicks, nil
+}
+
+// FindIndicators 銘柄の date 時点（省略時は東証の直近の取引日）のテクニカル指標を保存済みの日足（調整後の値）から計算する
+// 日付の形式が不正な場合は calendar.ErrInvalidDate、日足が無い場合は ErrNoDailyBars を返す
+func (s *analysisservice) FindIndicators(code string, date string) (*indicators.Snapshot, error) {
+	if date == "" {
+		date = calendar.TSE.LatestSessionDate(time.Now())
+	}
+	toDate, err := calendar.TSE.ParseDate(date)
+	if err != nil {
+		return nil, err
+	}
+	from := toDate.AddDate(0, 0, -indicators.LookbackDays).Format("2006-01-02")
+
+	dailyQuotes, err := s.repository.FindDailyQuotesByCode(code, from, date)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := indicators.Compute(indicators.FromDailyQuotes(dailyQuotes))
+	if snapshot == nil {
+		return nil, ErrNoDailyBars
+	}
+	return snapshot, nil
+}
+
+// FindFinancialMetrics 銘柄の財務指標（売上高・利益・成長率・利益率など）を開示日の新しい順に返す
+func (s *analysisservice) FindFinancialMetrics(code string) ([]models.FinancialMetric, error) {
+	return s.repository.FindFinancialMetricsByCode(code)
+}
+
+// FindValuation 銘柄の from〜to（YYYY-MM-DD, 両端を含む）のバリュエーションの推移と最新の値を返す
+// to を省略した場合は東証の直近の取引日、from を省略した場合は to の1年前とする
+// 日付の形式が不正な場合は calendar.ErrInvalidDate、from が to より後の場合は ErrInvalidDateRange、期間内に値が無い場合は ErrValuationNotFound を返す
+func (s *analysisservice) FindValuation(code string, from string, to string) (*JapaneseStockValuationHistory, error) {
+	from, to, err := resolveDateRange(calendar.TSE, from, to, defaultValuationDays)
+	if err != nil {
+		return nil, err
+	}
+
+	valuations, err := s.repository.FindJapaneseStockValuations(code, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(valuations) == 0 {
+		return nil, ErrValuationNotFound
+	}
+	return &JapaneseStockValuationHistory{
+		Code:    code,
+		Latest:  dto.NewJapaneseStockValuation(valuations[len(valuations)-1]),
+		History: dto.NewJapaneseStockValuations(valuations),
+	}, nil
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"stock-prediction/backend/dto"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	"time"
+)
+
+const (
+	// 発行するAPIキーの形式: "spk_" + ランダム32バイトのhex
+	apiKeyPrefix      = "spk_"
+	apiKeyRandomBytes = 32
+	// 一覧で識別できるよう保存するキー先頭の文字数
+	apiKeyDisplayPrefixLength = 12
+
+	// ブートストラップキー（ADMIN_API_KEY）で認証した場合のActor名
+	bootstrapActor = "bootstrap"
+)
+
+var (
+	ErrUnauthorized   = errors.New("invalid or missing api key")
+	ErrAPIKeyRevoked  = errors.New("api key has been revoked")
+	ErrInvalidRole    = errors.New("invalid role. use 'admin', 'operator', or 'viewer'")
+	ErrAPIKeyNotFound = errors.New("api key not found")
+)
+
+// roleLevels ロールの強さ（大きいほど権限が広い）
+var roleLevels = map[string]int{
+	models.RoleViewer:   1,
+	models.RoleOperator: 2,
+	models.RoleAdmin:    3,
+}
+
+// HasRole actual のロールが required 以上の権限を持つか
+func HasRole(actual string, required string) bool {
+	return roleLevels[actual] > 0 && roleLevels[actual] >= roleLevels[required]
+}
+
+// IssuedAPIKey 発行したAPIキー（Keyは発行時のレスポンスでのみ返す）
+type IssuedAPIKey struct {
+	Key    string     `json:"Key"`
+	APIKey dto.APIKey `json:"APIKey"`
+}
+
+type IAuthService interface {
+	Authenticate(rawKey string) (*models.APIKey, error)
+	IssueAPIKey(name string, role string, createdBy string) (*IssuedAPIKey, error)
+	RevokeAPIKey(id uint) (*dto.APIKey, error)
+	FindAPIKeys() ([]dto.APIKey, error)
+	RecordAudit(entry *models.AuditLog)
+	FindAuditLogs(actor string, limit int) ([]dto.AuditLog, error)
+}
+
+type authservice struct {
+	repository   repositories.IAuthRepository
+	bootstrapKey string
+}
+
+// NewAuthService ADMIN_API_KEY が設定されている場合、そのキーをadminロールとして常に受け付ける
+// （最初のAPIキーを発行するため、およびDB障害時の緊急用）
+func NewAuthService(repository repositories.IAuthRepository) IAuthService {
+	bootstrapKey := os.Getenv("ADMIN_API_KEY")
+	if bootstrapKey == "" {
+		log.Println("Warning: ADMIN_API_KEY is not set, only API keys stored in DB can access /api/admin")
+	}
+	return &authservice{repository: repository, bootstrapKey: bootstrapKey}
+}
+
+// Authenticate APIキーを検証し、対応するAPIKeyを返す
+func (s *authservice) Authenticate(rawKey string) (*models.APIKey, error) {
+	if rawKey == "" {
+		return nil, ErrUnauthorized
+	}
+
+	if s.bootstrapKey != "" && subtle.ConstantTimeCompare([]byte(rawKey), []byte(s.bootstrapKey)) == 1 {
+		return &models.APIKey{Name: bootstrapActor, Role: models.RoleAdmin}, nil
+	}
+
+	key, err := s.repository.FindAPIKeyByHash(hashAPIKey(rawKey))
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	if key.RevokedAt != nil {
+		return nil, ErrAPIKeyRevoked
+	}
+
+	if err := s.repository.TouchAPIKey(key.ID, time.Now()); err != nil {
+		log.Printf("Warning: Failed to update last_used_at for api key %s: %v", key.Name, err)
+	}
+
+	return key, nil
+}
+
+// IssueAPIKey 新しいAPIキーを発行する（キー本体は戻り値でのみ返し、DBにはハッシュを保存する）
+func (s *authservice) IssueAPIKey(name string, role string, createdBy string) (*IssuedAPIKey, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if roleLevels[role] == 0 {
+		return nil, ErrInvalidRole
+	}
+
+	random := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(random); err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	rawKey := apiKeyPrefix + hex.EncodeToString(random)
+
+	key := &models.APIKey{
+		Name:      name,
+		Prefix:    rawKey[:apiKeyDisplayPrefixLength],
+		KeyHash:   hashAPIKey(rawKey),
+		Role:      role,
+		CreatedBy: createdBy,
+	}
+	if err := s.repository.CreateAPIKey(key); err != nil {
+		return nil, fmt.Errorf("failed to save api key: %w", err)
+	}
+
+	return &IssuedAPIKey{Key: rawKey, APIKey: dto.NewAPIKey(*key)}, nil
+}
+
+// RevokeAPIKey APIキーを失効させる（履歴を残すため削除はしない）
+func (s *authservice) RevokeAPIKey(id uint) (*dto.APIKey, error) {
+	key, err := s.repository.FindAPIKeyByID(id)
+	if err != nil {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	if key.RevokedAt == nil {
+		now := time.Now()
+		key.RevokedAt = &now
+		if err := s.repository.UpdateAPIKey(key); err != nil {
+			return nil, fmt.Errorf("failed to revoke api key: %w", err)
+		}
+	}
+	revoked := dto.NewAPIKey(*key)
+	return &revoked, nil
+}
+
+func (s *authservice) FindAPIKeys() ([]dto.APIKey, error) {
+	keys, err := s.repository.FindAPIKeys()
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewAPIKeys(*keys), nil
+}
+
+// RecordAudit 監査ログを保存する（保存に失敗しても元のリクエストは失敗させない）
+func (s *authservice) RecordAudit(entry *models.AuditLog) {
+	if err := s.repository.CreateAuditLog(entry); err != nil {
+		log.Printf("Warning: Failed to save audit log (%s %s by %s): %v", entry.Method, entry.Path, entry.Actor, err)
+	}
+}
+
+func (s *authservice) FindAuditLogs(actor string, limit int) ([]dto.AuditLog, error) {
+	entries, err := s.repository.FindAuditLogs(actor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewAuditLogs(*entries), nil
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,245 @@
+// Package calendar は取引所（NYSE・東証）の営業日カレンダーを提供する
+//
+// 休場日は年ごとに祝日の規則から計算してキャッシュする。臨時休場（国葬・システム障害など）や
+// 過去の特例（2020・2021年の五輪に伴う祝日移動など）は holidayOverrides で個別に追加する。
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	// 取引所のタイムゾーンデータを埋め込む（tzdataの無いコンテナでも動かすため）
+	_ "time/tzdata"
+)
+
+// ErrInvalidDate 日付の形式が不正（YYYY-MM-DDではない）
+var ErrInvalidDate = errors.New("invalid date format. use YYYY-MM-DD")
+
+const dateLayout = "2006-01-02"
+
+// clock 取引所の現地時刻（時・分）
+type clock struct {
+	hour   int
+	minute int
+}
+
+func (c clock) on(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), c.hour, c.minute, 0, 0, date.Location())
+}
+
+// session 1日の取引時間（昼休みがある場合は複数）
+type session struct {
+	open  clock
+	close clock
+}
+
+// Calendar 1つの取引所の営業日カレンダー
+type Calendar struct {
+	name       string
+	location   *time.Location
+	sessions   []session
+	earlyClose clock // 短縮取引日の終了時刻（短縮取引が無い取引所はゼロ値）
+
+	holidayRules func(year int) map[string]string // 年ごとの休場日（日付 → 名称）
+	halfDayRules func(year int, c *Calendar) map[string]string
+	overrides    map[string]string // 規則で表せない休場日
+	holidayCache map[int]map[string]string
+	halfDayCache map[int]map[string]string
+	mu           sync.Mutex
+}
+
+// Name 取引所名（"NYSE" / "TSE"）
+func (c *Calendar) Name() string {
+	return c.name
+}
+
+// Location 取引所のタイムゾーン
+func (c *Calendar) Location() *time.Location {
+	return c.location
+}
+
+// ParseDate 取引所の現地日付として "YYYY-MM-DD" を解釈する
+func (c *Calendar) ParseDate(date string) (time.Time, error) {
+	t, err := time.ParseInLocation(dateLayout, date, c.location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, date)
+	}
+	return t, nil
+}
+
+// Holiday t（取引所の現地日付で判定）が休場日であればその名称を返す（土日は "weekend"）
+func (c *Calendar) Holiday(t time.Time) (string, bool) {
+	t = t.In(c.location)
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return "weekend", true
+	}
+
+	name, ok := c.holidays(t.Year())[t.Format(dateLayout)]
+	return name, ok
+}
+
+// IsTradingDay 取引所の現地日付で営業日かどうか
+func (c *Calendar) IsTradingDay(t time.Time) bool {
+	_, closed := c.Holiday(t)
+	return !closed
+}
+
+// IsHalfDay 短縮取引日（NYSEの独立記念日前日・感謝祭翌日・クリスマスイブ）かどうか
+func (c *Calendar) IsHalfDay(t time.Time) bool {
+	t = t.In(c.location)
+	if !c.IsTradingDay(t) {
+		return false
+	}
+	_, ok := c.halfDays(t.Year())[t.Format(dateLayout)]
+	return ok
+}
+
+// IsOpen t の時点で取引時間中かどうか（昼休み・短縮取引を考慮する）
+func (c *Calendar) IsOpen(t time.Time) bool {
+	t = t.In(c.location)
+	if !c.IsTradingDay(t) {
+		return false
+	}
+
+	halfDay := c.IsHalfDay(t)
+	for _, s := range c.sessions {
+		closeAt := s.close.on(t)
+		if halfDay && c.earlyClose.on(t).Before(closeAt) {
+			closeAt = c.earlyClose.on(t)
+		}
+		if !t.Before(s.open.on(t)) && t.Before(closeAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// CloseTime その営業日の取引終了時刻（短縮取引日は短縮後の時刻）
+func (c *Calendar) CloseTime(t time.Time) time.Time {
+	t = t.In(c.location)
+	if c.IsHalfDay(t) {
+		return c.earlyClose.on(t)
+	}
+	return c.sessions[len(c.sessions)-1].close.on(t)
+}
+
+// PreviousTradingDay t より前の直近の営業日（現地日付の0時）
+func (c *Calendar) PreviousTradingDay(t time.Time) time.Time {
+	day := c.startOfDay(t).AddDate(0, 0, -1)
+	for !c.IsTradingDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// NextTradingDay t より後の直近の営業日（現地日付の0時）
+func (c *Calendar) NextTradingDay(t time.Time) time.Time {
+	day := c.startOfDay(t).AddDate(0, 0, 1)
+	for !c.IsTradingDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// LastTradingDay t の日付が営業日ならその日、休場日ならその前の直近の営業日
+func (c *Calendar) LastTradingDay(t time.Time) time.Time {
+	day := c.startOfDay(t)
+	if c.IsTradingDay(day) {
+		return day
+	}
+	return c.PreviousTradingDay(day)
+}
+
+// LatestSessionDate t の時点で始まっている直近の取引日（YYYY-MM-DD）
+// 営業日でも寄り付き前であれば前営業日を返す（例: NYSEの月曜8時 → 前週金曜）
+func (c *Calendar) LatestSessionDate(t time.Time) string {
+	t = t.In(c.location)
+	if c.IsTradingDay(t) && !t.Before(c.sessions[0].open.on(t)) {
+		return t.Format(dateLayout)
+	}
+	return c.PreviousTradingDay(t).Format(dateLayout)
+}
+
+// SnapDate "YYYY-MM-DD" を、その日以前の直近の営業日に丸める（休場日を指定された場合のため）
+func (c *Calendar) SnapDate(date string) (string, error) {
+	t, err := c.ParseDate(date)
+	if err != nil {
+		return "", err
+	}
+	return c.LastTradingDay(t).Format(dateLayout), nil
+}
+
+func (c *Calendar) startOfDay(t time.Time) time.Time {
+	t = t.In(c.location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, c.location)
+}
+
+func (c *Calendar) holidays(year int) map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if holidays, ok := c.holidayCache[year]; ok {
+		return holidays
+	}
+
+	holidays := c.holidayRules(year)
+	for date, name := range c.overrides {
+		if t, err := time.Parse(dateLayout, date); err == nil && t.Year() == year {
+			holidays[date] = name
+		}
+	}
+	c.holidayCache[year] = holidays
+	return holidays
+}
+
+func (c *Calendar) halfDays(year int) map[string]string {
+	if c.halfDayRules == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	if halfDays, ok := c.halfDayCache[year]; ok {
+		c.mu.Unlock()
+		return halfDays
+	}
+	c.mu.Unlock()
+
+	// 短縮取引日の判定に休場日を使うので、ロックを外してから計算する
+	halfDays := c.halfDayRules(year, c)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.halfDayCache[year] = halfDays
+	return halfDays
+}
+
+func newCalendar(name string, locationName string, offsetHours int) *Calendar {
+	location, err := time.LoadLocation(locationName)
+	if err != nil {
+		location = time.FixedZone(locationName, offsetHours*60*60)
+	}
+	return &Calendar{
+		name:         name,
+		location:     location,
+		overrides:    map[string]string{},
+		holidayCache: map[int]map[string]string{},
+		halfDayCache: map[int]map[string]string{},
+	}
+}
+
+// nthWeekday year年month月の第n weekday（n=-1 で最終）
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	if n < 0 {
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		return last.AddDate(0, 0, -((int(last.Weekday()) - int(weekday) + 7) % 7))
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+7*(n-1))
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
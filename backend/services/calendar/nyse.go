@@ -0,0 +1,92 @@
+package calendar
+
+import "time"
+
+// NYSE ニューヨーク証券取引所（9:30〜16:00 米国東部時間、短縮取引日は13:00まで）
+var NYSE = newNYSE()
+
+func newNYSE() *Calendar {
+	c := newCalendar("NYSE", "America/New_York", -5)
+	c.sessions = []session{{open: clock{9, 30}, close: clock{16, 0}}}
+	c.earlyClose = clock{13, 0}
+	c.holidayRules = nyseHolidays
+	c.halfDayRules = nyseHalfDays
+	c.overrides = map[string]string{
+		"2018-12-05": "National Day of Mourning (George H.W. Bush)",
+		"2025-01-09": "National Day of Mourning (Jimmy Carter)",
+	}
+	return c
+}
+
+// nyseHolidays NYSEの休場日
+// 土曜の祝日は前の金曜、日曜の祝日は翌月曜に振り替える（ただし元日が土曜の場合は前年12/31を休場にしない）
+func nyseHolidays(year int) map[string]string {
+	holidays := map[string]string{}
+	add := func(t time.Time, name string) {
+		holidays[t.Format(dateLayout)] = name
+	}
+	addObserved := func(t time.Time, name string) {
+		switch t.Weekday() {
+		case time.Saturday:
+			if t.Month() == time.January && t.Day() == 1 {
+				return
+			}
+			add(t.AddDate(0, 0, -1), name+" (observed)")
+		case time.Sunday:
+			add(t.AddDate(0, 0, 1), name+" (observed)")
+		default:
+			add(t, name)
+		}
+	}
+
+	addObserved(date(year, time.January, 1), "New Year's Day")
+	add(nthWeekday(year, time.January, time.Monday, 3), "Martin Luther King Jr. Day")
+	add(nthWeekday(year, time.February, time.Monday, 3), "Washington's Birthday")
+	add(easterSunday(year).AddDate(0, 0, -2), "Good Friday")
+	add(nthWeekday(year, time.May, time.Monday, -1), "Memorial Day")
+	if year >= 2022 {
+		addObserved(date(year, time.June, 19), "Juneteenth National Independence Day")
+	}
+	addObserved(date(year, time.July, 4), "Independence Day")
+	add(nthWeekday(year, time.September, time.Monday, 1), "Labor Day")
+	add(nthWeekday(year, time.November, time.Thursday, 4), "Thanksgiving Day")
+	addObserved(date(year, time.December, 25), "Christmas Day")
+
+	return holidays
+}
+
+// nyseHalfDays 13:00で取引終了となる日（営業日の場合のみ）
+func nyseHalfDays(year int, c *Calendar) map[string]string {
+	halfDays := map[string]string{}
+	add := func(t time.Time, name string) {
+		local := time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, c.location)
+		if c.IsTradingDay(local) {
+			halfDays[t.Format(dateLayout)] = name
+		}
+	}
+
+	add(date(year, time.July, 3), "Independence Day Eve")
+	add(nthWeekday(year, time.November, time.Thursday, 4).AddDate(0, 0, 1), "Day after Thanksgiving")
+	add(date(year, time.December, 24), "Christmas Eve")
+
+	return halfDays
+}
+
+// easterSunday 復活祭（グレゴリオ暦、Anonymous Gregorian algorithm）
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
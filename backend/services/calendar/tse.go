@@ -0,0 +1,130 @@
+package calendar
+
+import (
+	"sort"
+	"time"
+)
+
+// TSE 東京証券取引所（前場9:00〜11:30、後場12:30〜15:30 日本時間）
+// 2024年11月5日の取引時間延伸前（15:00終了）の日付についても15:30として扱う
+var TSE = newTSE()
+
+func newTSE() *Calendar {
+	c := newCalendar("TSE", "Asia/Tokyo", 9)
+	c.sessions = []session{
+		{open: clock{9, 0}, close: clock{11, 30}},
+		{open: clock{12, 30}, close: clock{15, 30}},
+	}
+	c.holidayRules = tseHolidays
+	c.overrides = map[string]string{
+		"2019-04-30": "国民の休日",
+		"2019-05-01": "天皇の即位の日",
+		"2019-05-02": "国民の休日",
+		"2019-10-22": "即位礼正殿の儀の行われる日",
+		"2020-07-23": "海の日",
+		"2020-07-24": "スポーツの日",
+		"2020-08-10": "山の日",
+		"2021-07-22": "海の日",
+		"2021-07-23": "スポーツの日",
+		"2021-08-09": "山の日（振替休日）",
+	}
+	return c
+}
+
+// tseHolidays 東証の休場日（国民の祝日・振替休日・国民の休日・年末年始）
+// 2020・2021年の五輪に伴う祝日移動は overrides で追加し、規則で求めた本来の日付は取り除く
+func tseHolidays(year int) map[string]string {
+	holidays := nationalHolidays(year)
+
+	// 年末年始の休業日
+	holidays[date(year, time.January, 2).Format(dateLayout)] = "年始休業日"
+	holidays[date(year, time.January, 3).Format(dateLayout)] = "年始休業日"
+	holidays[date(year, time.December, 31).Format(dateLayout)] = "年末休業日"
+
+	switch year {
+	case 2020:
+		delete(holidays, "2020-07-20")
+		delete(holidays, "2020-08-11")
+		delete(holidays, "2020-10-12")
+	case 2021:
+		delete(holidays, "2021-07-19")
+		delete(holidays, "2021-08-11")
+		delete(holidays, "2021-10-11")
+	}
+
+	return holidays
+}
+
+// nationalHolidays 国民の祝日に関する法律に基づく祝日（2020年以降の規則）
+func nationalHolidays(year int) map[string]string {
+	holidays := map[string]string{}
+	add := func(t time.Time, name string) {
+		holidays[t.Format(dateLayout)] = name
+	}
+
+	add(date(year, time.January, 1), "元日")
+	add(nthWeekday(year, time.January, time.Monday, 2), "成人の日")
+	add(date(year, time.February, 11), "建国記念の日")
+	add(date(year, time.February, 23), "天皇誕生日")
+	add(date(year, time.March, vernalEquinoxDay(year)), "春分の日")
+	add(date(year, time.April, 29), "昭和の日")
+	add(date(year, time.May, 3), "憲法記念日")
+	add(date(year, time.May, 4), "みどりの日")
+	add(date(year, time.May, 5), "こどもの日")
+	add(nthWeekday(year, time.July, time.Monday, 3), "海の日")
+	add(date(year, time.August, 11), "山の日")
+	add(nthWeekday(year, time.September, time.Monday, 3), "敬老の日")
+	add(date(year, time.September, autumnalEquinoxDay(year)), "秋分の日")
+	add(nthWeekday(year, time.October, time.Monday, 2), "スポーツの日")
+	add(date(year, time.November, 3), "文化の日")
+	add(date(year, time.November, 23), "勤労感謝の日")
+
+	isHoliday := func(t time.Time) bool {
+		_, ok := holidays[t.Format(dateLayout)]
+		return ok
+	}
+
+	// 国民の休日: 前日と翌日が祝日である平日（例: 敬老の日と秋分の日に挟まれた日）
+	for day := date(year, time.January, 2); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		if !isHoliday(day) && day.Weekday() != time.Sunday && isHoliday(day.AddDate(0, 0, -1)) && isHoliday(day.AddDate(0, 0, 1)) {
+			add(day, "国民の休日")
+		}
+	}
+
+	// 振替休日: 日曜の祝日の後、最初の祝日でない日
+	for _, t := range sortedDates(holidays) {
+		if t.Weekday() != time.Sunday {
+			continue
+		}
+		substitute := t.AddDate(0, 0, 1)
+		for isHoliday(substitute) {
+			substitute = substitute.AddDate(0, 0, 1)
+		}
+		if substitute.Year() == year {
+			add(substitute, "振替休日")
+		}
+	}
+
+	return holidays
+}
+
+// vernalEquinoxDay 春分日（1980〜2099年に有効な近似式）
+func vernalEquinoxDay(year int) int {
+	return int(20.8431+0.242194*float64(year-1980)) - (year-1980)/4
+}
+
+// autumnalEquinoxDay 秋分日（1980〜2099年に有効な近似式）
+func autumnalEquinoxDay(year int) int {
+	return int(23.2488+0.242194*float64(year-1980)) - (year-1980)/4
+}
+
+func sortedDates(holidays map[string]string) []time.Time {
+	dates := make([]time.Time, 0, len(holidays))
+	for d := range holidays {
+		if t, err := time.Parse(dateLayout, d); err == nil {
+			dates = append(dates, t)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates
+}
@@ -0,0 +1,285 @@
+// Package indicators は保存済みの日足（DailyQuote / StockDailyBar）からテクニカル指標を計算する
+//
+// 各指標の系列関数は入力と同じ長さのスライスを返し、計算に必要な本数に満たない先頭部分は NaN とする。
+// API・AI分析では Compute で最新日の値だけをまとめた Snapshot を使う。
+package indicators
+
+import (
+	"math"
+	"stock-prediction/backend/models"
+)
+
+// LookbackDays 指標（200日移動平均・52週高値/安値）の計算に使う日足の期間（暦日）
+const LookbackDays = 400
+
+// Bar 指標の計算に使う日足1本分（調整後の値を入れる）
+type Bar struct {
+	Date   string
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// FromDailyQuotes 日本株の日足を分割・併合の調整後の値（Adjustment*）で変換する
+func FromDailyQuotes(quotes []models.DailyQuote) []Bar {
+	bars := make([]Bar, 0, len(quotes))
+	for _, quote := range quotes {
+		// 売買が無かった日は四本値が0で返るので除外する
+		if quote.AdjustmentClose == 0 {
+			continue
+		}
+		bars = append(bars, Bar{
+			Date:   quote.Date,
+			Open:   quote.AdjustmentOpen,
+			High:   quote.AdjustmentHigh,
+			Low:    quote.AdjustmentLow,
+			Close:  quote.AdjustmentClose,
+			Volume: quote.AdjustmentVolume,
+		})
+	}
+	return bars
+}
+
+// FromStockDailyBars 米国株の日足を分割・配当の調整後の値（Adjustment*）で変換する
+func FromStockDailyBars(stockBars []models.StockDailyBar) []Bar {
+	bars := make([]Bar, 0, len(stockBars))
+	for _, bar := range stockBars {
+		// 調整後の値が無い日足（取得し直す前のもの）と、四本値が0で返った日は除外する
+		if bar.AdjustmentClose == 0 {
+			continue
+		}
+		bars = append(bars, Bar{
+			Date:   bar.Date,
+			Open:   bar.AdjustmentOpen,
+			High:   bar.AdjustmentHigh,
+			Low:    bar.AdjustmentLow,
+			Close:  bar.AdjustmentClose,
+			Volume: bar.AdjustmentVolume,
+		})
+	}
+	return bars
+}
+
+// Closes 終値の系列
+func Closes(bars []Bar) []float64 {
+	values := make([]float64, len(bars))
+	for i, bar := range bars {
+		values[i] = bar.Close
+	}
+	return values
+}
+
+// Volumes 出来高の系列
+func Volumes(bars []Bar) []float64 {
+	values := make([]float64, len(bars))
+	for i, bar := range bars {
+		values[i] = bar.Volume
+	}
+	return values
+}
+
+// SMA 単純移動平均
+func SMA(values []float64, period int) []float64 {
+	result := nanSeries(len(values))
+	if period <= 0 {
+		return result
+	}
+
+	sum := 0.0
+	for i, value := range values {
+		sum += value
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			result[i] = sum / float64(period)
+		}
+	}
+	return result
+}
+
+// EMA 指数移動平均（最初の period 本の単純平均を初期値とする）
+func EMA(values []float64, period int) []float64 {
+	return ema(values, period, 2/float64(period+1))
+}
+
+// RSI 相対力指数（Wilderの平滑化）
+func RSI(values []float64, period int) []float64 {
+	result := nanSeries(len(values))
+	if period <= 0 || len(values) <= period {
+		return result
+	}
+
+	gain, loss := 0.0, 0.0
+	for i := 1; i <= period; i++ {
+		change := values[i] - values[i-1]
+		if change > 0 {
+			gain += change
+		} else {
+			loss -= change
+		}
+	}
+	gain /= float64(period)
+	loss /= float64(period)
+	result[period] = rsi(gain, loss)
+
+	for i := period + 1; i < len(values); i++ {
+		change := values[i] - values[i-1]
+		currentGain, currentLoss := 0.0, 0.0
+		if change > 0 {
+			currentGain = change
+		} else {
+			currentLoss = -change
+		}
+		gain = (gain*float64(period-1) + currentGain) / float64(period)
+		loss = (loss*float64(period-1) + currentLoss) / float64(period)
+		result[i] = rsi(gain, loss)
+	}
+	return result
+}
+
+// MACD MACD線（短期EMA - 長期EMA）・シグナル線（MACD線のEMA）・ヒストグラム（MACD線 - シグナル線）
+func MACD(values []float64, fast int, slow int, signal int) (macd []float64, signalLine []float64, histogram []float64) {
+	fastEMA := EMA(values, fast)
+	slowEMA := EMA(values, slow)
+
+	macd = nanSeries(len(values))
+	for i := range values {
+		macd[i] = fastEMA[i] - slowEMA[i]
+	}
+
+	// MACD線は長期EMAが出揃ってから計算できるので、そこからシグナル線を計算する
+	signalLine = nanSeries(len(values))
+	start := firstValid(macd)
+	if start >= 0 {
+		copy(signalLine[start:], EMA(macd[start:], signal))
+	}
+
+	histogram = nanSeries(len(values))
+	for i := range values {
+		histogram[i] = macd[i] - signalLine[i]
+	}
+	return macd, signalLine, histogram
+}
+
+// BollingerBands ボリンジャーバンド（中心線 = SMA, 上下 = 中心線 ± k × 標準偏差）
+func BollingerBands(values []float64, period int, k float64) (middle []float64, upper []float64, lower []float64) {
+	middle = SMA(values, period)
+	upper = nanSeries(len(values))
+	lower = nanSeries(len(values))
+
+	for i := range values {
+		if math.IsNaN(middle[i]) {
+			continue
+		}
+		deviation := stddev(values[i-period+1:i+1], middle[i])
+		upper[i] = middle[i] + k*deviation
+		lower[i] = middle[i] - k*deviation
+	}
+	return middle, upper, lower
+}
+
+// ATR アベレージ・トゥルー・レンジ（Wilderの平滑化）
+func ATR(bars []Bar, period int) []float64 {
+	result := nanSeries(len(bars))
+	if period <= 0 || len(bars) <= period {
+		return result
+	}
+
+	trueRanges := make([]float64, len(bars))
+	for i := 1; i < len(bars); i++ {
+		previousClose := bars[i-1].Close
+		trueRanges[i] = math.Max(bars[i].High-bars[i].Low,
+			math.Max(math.Abs(bars[i].High-previousClose), math.Abs(bars[i].Low-previousClose)))
+	}
+
+	atr := 0.0
+	for i := 1; i <= period; i++ {
+		atr += trueRanges[i]
+	}
+	atr /= float64(period)
+	result[period] = atr
+
+	for i := period + 1; i < len(bars); i++ {
+		atr = (atr*float64(period-1) + trueRanges[i]) / float64(period)
+		result[i] = atr
+	}
+	return result
+}
+
+// ZScore 直近 period 本の平均・標準偏差に対する値の偏差（出来高の急増の検出に使う）
+func ZScore(values []float64, period int) []float64 {
+	result := nanSeries(len(values))
+	mean := SMA(values, period)
+
+	for i := range values {
+		if math.IsNaN(mean[i]) {
+			continue
+		}
+		deviation := stddev(values[i-period+1:i+1], mean[i])
+		if deviation == 0 {
+			result[i] = 0
+			continue
+		}
+		result[i] = (values[i] - mean[i]) / deviation
+	}
+	return result
+}
+
+func ema(values []float64, period int, alpha float64) []float64 {
+	result := nanSeries(len(values))
+	if period <= 0 || len(values) < period {
+		return result
+	}
+
+	sum := 0.0
+	for i := 0; i < period; i++ {
+		sum += values[i]
+	}
+	current := sum / float64(period)
+	result[period-1] = current
+
+	for i := period; i < len(values); i++ {
+		current = alpha*values[i] + (1-alpha)*current
+		result[i] = current
+	}
+	return result
+}
+
+func rsi(gain float64, loss float64) float64 {
+	if loss == 0 {
+		if gain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+gain/loss)
+}
+
+// stddev 母標準偏差
+func stddev(values []float64, mean float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+func nanSeries(length int) []float64 {
+	series := make([]float64, length)
+	for i := range series {
+		series[i] = math.NaN()
+	}
+	return series
+}
+
+func firstValid(values []float64) int {
+	for i, value := range values {
+		if !math.IsNaN(value) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,162 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// 指標のパラメータ（一般的な既定値）
+const (
+	rsiPeriod          = 14
+	macdFast           = 12
+	macdSlow           = 26
+	macdSignal         = 9
+	bollingerPeriod    = 20
+	bollingerK         = 2.0
+	atrPeriod          = 14
+	volumeZScorePeriod = 20
+	// 52週 ≒ 252営業日
+	yearTradingDays = 252
+)
+
+// Snapshot 最新日のテクニカル指標
+// 本数が足りず計算できない指標は null になる
+type Snapshot struct {
+	Date  string  `json:"Date"`  // 最新の日足の日付
+	Close float64 `json:"Close"` // 最新の終値
+	Bars  int     `json:"Bars"`  // 計算に使った日足の本数
+
+	SMA20  *float64 `json:"SMA20"`
+	SMA50  *float64 `json:"SMA50"`
+	SMA200 *float64 `json:"SMA200"`
+	EMA12  *float64 `json:"EMA12"`
+	EMA26  *float64 `json:"EMA26"`
+
+	RSI14 *float64 `json:"RSI14"`
+
+	MACD          *float64 `json:"MACD"`
+	MACDSignal    *float64 `json:"MACDSignal"`
+	MACDHistogram *float64 `json:"MACDHistogram"`
+
+	BollingerUpper    *float64 `json:"BollingerUpper"`
+	BollingerMiddle   *float64 `json:"BollingerMiddle"`
+	BollingerLower    *float64 `json:"BollingerLower"`
+	BollingerPercentB *float64 `json:"BollingerPercentB"` // バンド内の位置（0 = 下限, 1 = 上限）
+
+	ATR14        *float64 `json:"ATR14"`
+	ATR14Percent *float64 `json:"ATR14Percent"` // 終値に対するATRの割合（%）
+
+	VolumeZScore20 *float64 `json:"VolumeZScore20"` // 直近20日の出来高に対する最新日の出来高の偏差
+
+	// 52週（最大252本。1年分に満たない場合は取得できた期間）の高値・安値と、終値との距離（%）
+	High52Week             float64 `json:"High52Week"`
+	Low52Week              float64 `json:"Low52Week"`
+	DistanceFromHigh52Week float64 `json:"DistanceFromHigh52Week"` // 0以下（高値からの下落率）
+	DistanceFromLow52Week  float64 `json:"DistanceFromLow52Week"`  // 0以上（安値からの上昇率）
+}
+
+// Compute 日付の古い順に並んだ日足から最新日の指標を計算する（日足が無い場合は nil）
+func Compute(bars []Bar) *Snapshot {
+	if len(bars) == 0 {
+		return nil
+	}
+
+	closes := Closes(bars)
+	latest := bars[len(bars)-1]
+	snapshot := &Snapshot{
+		Date:  latest.Date,
+		Close: latest.Close,
+		Bars:  len(bars),
+	}
+
+	snapshot.SMA20 = last(SMA(closes, 20))
+	snapshot.SMA50 = last(SMA(closes, 50))
+	snapshot.SMA200 = last(SMA(closes, 200))
+	snapshot.EMA12 = last(EMA(closes, 12))
+	snapshot.EMA26 = last(EMA(closes, 26))
+	snapshot.RSI14 = last(RSI(closes, rsiPeriod))
+
+	macd, signal, histogram := MACD(closes, macdFast, macdSlow, macdSignal)
+	snapshot.MACD = last(macd)
+	snapshot.MACDSignal = last(signal)
+	snapshot.MACDHistogram = last(histogram)
+
+	middle, upper, lower := BollingerBands(closes, bollingerPeriod, bollingerK)
+	snapshot.BollingerMiddle = last(middle)
+	snapshot.BollingerUpper = last(upper)
+	snapshot.BollingerLower = last(lower)
+	if snapshot.BollingerUpper != nil && *snapshot.BollingerUpper != *snapshot.BollingerLower {
+		percentB := (latest.Close - *snapshot.BollingerLower) / (*snapshot.BollingerUpper - *snapshot.BollingerLower)
+		snapshot.BollingerPercentB = &percentB
+	}
+
+	snapshot.ATR14 = last(ATR(bars, atrPeriod))
+	if snapshot.ATR14 != nil && latest.Close != 0 {
+		atrPercent := *snapshot.ATR14 / latest.Close * 100
+		snapshot.ATR14Percent = &atrPercent
+	}
+
+	snapshot.VolumeZScore20 = last(ZScore(Volumes(bars), volumeZScorePeriod))
+
+	year := bars
+	if len(year) > yearTradingDays {
+		year = year[len(year)-yearTradingDays:]
+	}
+	snapshot.High52Week, snapshot.Low52Week = year[0].High, year[0].Low
+	for _, bar := range year {
+		snapshot.High52Week = math.Max(snapshot.High52Week, bar.High)
+		snapshot.Low52Week = math.Min(snapshot.Low52Week, bar.Low)
+	}
+	if snapshot.High52Week != 0 {
+		snapshot.DistanceFromHigh52Week = (latest.Close/snapshot.High52Week - 1) * 100
+	}
+	if snapshot.Low52Week != 0 {
+		snapshot.DistanceFromLow52Week = (latest.Close/snapshot.Low52Week - 1) * 100
+	}
+
+	return snapshot
+}
+
+// Summary AI分析のプロンプトに埋め込むための要約（計算できなかった指標は省略する）
+func (s *Snapshot) Summary() string {
+	if s == nil {
+		return ""
+	}
+
+	var lines []string
+	add := func(format string, value *float64) {
+		if value != nil {
+			lines = append(lines, fmt.Sprintf(format, *value))
+		}
+	}
+
+	lines = append(lines, fmt.Sprintf("- 基準日: %s（終値 %.2f）", s.Date, s.Close))
+	add("- 20日移動平均: %.2f", s.SMA20)
+	add("- 50日移動平均: %.2f", s.SMA50)
+	add("- 200日移動平均: %.2f", s.SMA200)
+	add("- RSI(14): %.1f", s.RSI14)
+	if s.MACD != nil && s.MACDSignal != nil {
+		lines = append(lines, fmt.Sprintf("- MACD: %.3f（シグナル %.3f）", *s.MACD, *s.MACDSignal))
+	}
+	if s.BollingerUpper != nil {
+		lines = append(lines, fmt.Sprintf("- ボリンジャーバンド(20, 2σ): %.2f 〜 %.2f", *s.BollingerLower, *s.BollingerUpper))
+	}
+	add("- ATR(14): 終値の %.2f%%", s.ATR14Percent)
+	add("- 出来高Zスコア(20日): %.2f", s.VolumeZScore20)
+	lines = append(lines, fmt.Sprintf("- 52週高値からの距離: %.1f%% / 52週安値からの距離: %+.1f%%", s.DistanceFromHigh52Week, s.DistanceFromLow52Week))
+
+	return strings.Join(lines, "\n")
+}
+
+// last 系列の最新値（NaNの場合は nil）
+func last(series []float64) *float64 {
+	if len(series) == 0 {
+		return nil
+	}
+	value := series[len(series)-1]
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	return &value
+}
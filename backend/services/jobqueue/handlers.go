@@ -0,0 +1,135 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"stock-prediction/backend/services"
+	america_stock "stock-prediction/backend/services/America_stock"
+	japanesestock "stock-prediction/backend/services/Japanese_Stock"
+	"stock-prediction/backend/services/progress"
+	xpost "stock-prediction/backend/services/x_post"
+)
+
+// XPostPayload TypeXPost の引数
+type XPostPayload struct {
+	PostType string `json:"PostType"` // ranking / analysis / all
+	Date     string `json:"Date"`     // 省略時はNYSEの直近の取引日
+}
+
+// JPComparePayload TypeJPCompare の引数
+type JPComparePayload struct {
+	Sector33 string `json:"Sector33"`
+	Date     string `json:"Date"` // 省略時は日本時間の今日
+}
+
+// USSyncResult TypeUSSync の結果（プロバイダーがデータを返さなかった場合の理由）
+type USSyncResult struct {
+	ProviderErrors []*america_stock.ProviderError `json:"ProviderErrors,omitempty"`
+}
+
+// DefaultHandlers 管理系APIから登録するジョブのハンドラー
+func DefaultHandlers(stockService services.IStockService, japaneseStockService japanesestock.IJapaneseStockService, xPostService xpost.IXPostService) map[string]Handler {
+	return map[string]Handler{
+		TypeUSSync: func(ctx context.Context, payload json.RawMessage, reporter progress.Reporter) (any, error) {
+			err := stockService.SyncData(ctx, reporter)
+			if err == nil {
+				return nil, nil
+			}
+
+			providerErrors := america_stock.ProviderErrors(err)
+			if len(providerErrors) == 0 {
+				return nil, err
+			}
+			result := &USSyncResult{ProviderErrors: providerErrors}
+			// APIキー不正・プレミアム限定はリトライしても結果が変わらない
+			if !providerErrorsRetryable(providerErrors) {
+				return result, Permanent(err)
+			}
+			return result, err
+		},
+
+		TypeXPost: func(ctx context.Context, payload json.RawMessage, reporter progress.Reporter) (any, error) {
+			var p XPostPayload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return nil, Permanent(fmt.Errorf("invalid payload: %w", err))
+			}
+
+			if p.PostType == "ranking" || p.PostType == "all" {
+				if err := xPostService.PostRanking(p.Date); err != nil {
+					reporter.Report("ranking", progress.StatusFailed, err.Error())
+					return nil, fmt.Errorf("failed to post ranking to x: %w", err)
+				}
+				reporter.Report("ranking", progress.StatusSucceeded, "")
+			}
+			if p.PostType == "analysis" || p.PostType == "all" {
+				if err := xPostService.PostAnalysis(p.Date); err != nil {
+					reporter.Report("analysis", progress.StatusFailed, err.Error())
+					err = fmt.Errorf("failed to post analysis to x: %w", err)
+					// ランキングを投稿済みの場合、リトライすると同じランキングを二重に投稿してしまう
+					if p.PostType == "all" {
+						return nil, Permanent(err)
+					}
+					return nil, err
+				}
+				reporter.Report("analysis", progress.StatusSucceeded, "")
+			}
+			return nil, nil
+		},
+
+		TypeJPSync: func(ctx context.Context, payload json.RawMessage, reporter progress.Reporter) (any, error) {
+			var filter japanesestock.SectorFilter
+			if err := json.Unmarshal(payload, &filter); err != nil {
+				return nil, Permanent(fmt.Errorf("invalid payload: %w", err))
+			}
+			return japaneseStockService.SyncSector(ctx, filter, reporter)
+		},
+
+		TypeJPAnalyze: func(ctx context.Context, payload json.RawMessage, reporter progress.Reporter) (any, error) {
+			var filter japanesestock.SectorFilter
+			if err := json.Unmarshal(payload, &filter); err != nil {
+				return nil, Permanent(fmt.Errorf("invalid payload: %w", err))
+			}
+
+			report, err := japaneseStockService.AnalyzeSector(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			for _, outcome := range report.Companies {
+				if outcome.Error != "" {
+					reporter.Report(outcome.Code, progress.StatusFailed, outcome.Error)
+				} else {
+					reporter.Report(outcome.Code, progress.StatusSucceeded, "")
+				}
+			}
+			return report, nil
+		},
+
+		TypeJPCompare: func(ctx context.Context, payload json.RawMessage, reporter progress.Reporter) (any, error) {
+			var p JPComparePayload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return nil, Permanent(fmt.Errorf("invalid payload: %w", err))
+			}
+			return japaneseStockService.CompareSector(ctx, p.Sector33, p.Date)
+		},
+
+		TypeJPMovers: func(ctx context.Context, payload json.RawMessage, reporter progress.Reporter) (any, error) {
+			var filter japanesestock.MoversFilter
+			if err := json.Unmarshal(payload, &filter); err != nil {
+				return nil, Permanent(fmt.Errorf("invalid payload: %w", err))
+			}
+			return japaneseStockService.SyncMovers(ctx, filter)
+		},
+	}
+}
+
+func providerErrorsRetryable(providerErrors []*america_stock.ProviderError) bool {
+	for _, providerErr := range providerErrors {
+		switch providerErr.Kind {
+		case america_stock.ErrKindInvalidKey, america_stock.ErrKindPremiumOnly:
+		default:
+			return true
+		}
+	}
+	return false
+}
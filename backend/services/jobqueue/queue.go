@@ -0,0 +1,199 @@
+// Package jobqueue はDBをキューとして使う非同期ジョブの登録・実行を提供する
+//
+// 管理系APIは Enqueue でジョブを登録してすぐにジョブIDを返し、Worker がDBからジョブを取り出して実行する。
+// 失敗したジョブは指数バックオフで MaxAttempts 回まで再実行し、進捗は銘柄ごとに BackgroundJobStep に記録する。
+package jobqueue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"stock-prediction/backend/dto"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/progress"
+	"time"
+)
+
+// ジョブの種類
+const (
+	TypeUSSync    = "us_sync"    // 米国株ランキング・企業情報・AI分析の同期
+	TypeXPost     = "xpost"      // Xへの投稿
+	TypeJPSync    = "jp_sync"    // 日本株セクターの同期
+	TypeJPAnalyze = "jp_analyze" // 日本株セクターの分析（Phase 1 → Phase 2）
+	TypeJPCompare = "jp_compare" // 日本株セクター内の比較（Phase 3）
+	TypeJPMovers  = "jp_movers"  // 日本株の全銘柄の日足からランキングを計算
+)
+
+const defaultMaxAttempts = 3
+
+var ErrJobNotFound = errors.New("job not found")
+
+// permanentError リトライしても成功しないエラー（引数の誤り・APIキー未設定など）
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent リトライせずにジョブを失敗させるエラーにする
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent Permanent でラップされたエラーか
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// JobStatus APIで返すジョブの状態（Payload・ResultはJSONのまま埋め込む）
+type JobStatus struct {
+	ID          uint            `json:"ID"`
+	Type        string          `json:"Type"`
+	Status      string          `json:"Status"`
+	Payload     json.RawMessage `json:"Payload"`
+	Result      json.RawMessage `json:"Result,omitempty"`
+	Attempts    int             `json:"Attempts"`
+	MaxAttempts int             `json:"MaxAttempts"`
+	RunAt       time.Time       `json:"RunAt"`
+	CreatedAt   time.Time       `json:"CreatedAt"`
+	StartedAt   *time.Time      `json:"StartedAt"`
+	FinishedAt  *time.Time      `json:"FinishedAt"`
+	LastError   string          `json:"LastError,omitempty"`
+	CreatedBy   string          `json:"CreatedBy"`
+	Progress    *JobProgress    `json:"Progress,omitempty"`
+	Steps       []dto.JobStep   `json:"Steps,omitempty"`
+}
+
+// JobProgress 銘柄ごとの進捗の集計
+type JobProgress struct {
+	Total     int `json:"Total"`
+	Running   int `json:"Running"`
+	Succeeded int `json:"Succeeded"`
+	Failed    int `json:"Failed"`
+	Skipped   int `json:"Skipped"`
+}
+
+type IJobQueue interface {
+	Enqueue(jobType string, payload any, createdBy string) (*JobStatus, error)
+	FindJob(id uint) (*JobStatus, error)
+	FindJobs(status string, limit int) ([]JobStatus, error)
+}
+
+type jobqueue struct {
+	repository repositories.IBackgroundJobRepository
+}
+
+func NewJobQueue(repository repositories.IBackgroundJobRepository) IJobQueue {
+	return &jobqueue{repository: repository}
+}
+
+// Enqueue ジョブを登録する（payload はJSONに変換して保存し、ハンドラーで同じ型に戻す）
+func (q *jobqueue) Enqueue(jobType string, payload any, createdBy string) (*JobStatus, error) {
+	payloadJSON := []byte("{}")
+	if payload != nil {
+		var err error
+		payloadJSON, err = json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+	}
+
+	job := &models.BackgroundJob{
+		Type:        jobType,
+		Payload:     string(payloadJSON),
+		Result:      "null",
+		Status:      models.BackgroundJobStatusQueued,
+		RunAt:       time.Now(),
+		MaxAttempts: defaultMaxAttempts,
+		CreatedBy:   createdBy,
+	}
+	if err := q.repository.CreateJob(job); err != nil {
+		return nil, fmt.Errorf("failed to enqueue %s job: %w", jobType, err)
+	}
+
+	return toJobStatus(job), nil
+}
+
+func (q *jobqueue) FindJob(id uint) (*JobStatus, error) {
+	job, err := q.repository.FindJobByID(id)
+	if err != nil {
+		return nil, ErrJobNotFound
+	}
+	return toJobStatus(job), nil
+}
+
+func (q *jobqueue) FindJobs(status string, limit int) ([]JobStatus, error) {
+	jobs, err := q.repository.FindJobs(status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]JobStatus, 0, len(*jobs))
+	for i := range *jobs {
+		statuses = append(statuses, *toJobStatus(&(*jobs)[i]))
+	}
+	return statuses, nil
+}
+
+func toJobStatus(job *models.BackgroundJob) *JobStatus {
+	status := &JobStatus{
+		ID:          job.ID,
+		Type:        job.Type,
+		Status:      job.Status,
+		Payload:     rawJSON(job.Payload),
+		Result:      rawJSON(job.Result),
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		FinishedAt:  job.FinishedAt,
+		LastError:   job.LastError,
+		CreatedBy:   job.CreatedBy,
+		Steps:       mapJobSteps(job.Steps),
+	}
+
+	if len(job.Steps) > 0 {
+		jobProgress := &JobProgress{Total: len(job.Steps)}
+		for _, step := range job.Steps {
+			switch step.Status {
+			case progress.StatusRunning:
+				jobProgress.Running++
+			case progress.StatusSucceeded:
+				jobProgress.Succeeded++
+			case progress.StatusFailed:
+				jobProgress.Failed++
+			case progress.StatusSkipped:
+				jobProgress.Skipped++
+			}
+		}
+		status.Progress = jobProgress
+	}
+
+	return status
+}
+
+// mapJobSteps Stepsを読み込んでいない場合は nil のまま（Stepsを省略する）
+func mapJobSteps(steps []models.BackgroundJobStep) []dto.JobStep {
+	if steps == nil {
+		return nil
+	}
+	result := make([]dto.JobStep, 0, len(steps))
+	for _, step := range steps {
+		result = append(result, dto.NewJobStep(step))
+	}
+	return result
+}
+
+func rawJSON(value string) json.RawMessage {
+	if value == "" || value == "null" {
+		return nil
+	}
+	return json.RawMessage(value)
+}
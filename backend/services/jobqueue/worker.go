@@ -0,0 +1,263 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/progress"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	pollInterval = 2 * time.Second
+	// 再実行までの待ち時間（30秒 → 1分 → 2分 … 最大30分）
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = 30 * time.Minute
+	// 実行中のジョブは heartbeatInterval ごとに locked_at を更新する
+	// staleJobTimeout の間ハートビートが無い running のジョブはワーカーが落ちたとみなして実行待ちに戻す
+	// （実行時間ではなくハートビートで判定するので、数十分かかる米国株の同期も戻されない）
+	heartbeatInterval    = time.Minute
+	staleJobTimeout      = 10 * time.Minute
+	staleJobScanInterval = time.Minute
+)
+
+// Handler ジョブ1件を実行する
+// result はJSONにしてジョブに保存する（失敗時も保存するので、エラーの詳細を返してもよい）
+// Permanent でラップしたエラーを返すとリトライせずに失敗させる
+type Handler func(ctx context.Context, payload json.RawMessage, reporter progress.Reporter) (result any, err error)
+
+// Worker DBからジョブを取り出して実行する
+type Worker struct {
+	repository  repositories.IBackgroundJobRepository
+	handlers    map[string]Handler
+	concurrency int
+	id          string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWorker concurrency 個のゴルーチンでジョブを並行実行するワーカーを作成する
+func NewWorker(repository repositories.IBackgroundJobRepository, handlers map[string]Handler, concurrency int) *Worker {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	hostname, _ := os.Hostname()
+	return &Worker{
+		repository:  repository,
+		handlers:    handlers,
+		concurrency: concurrency,
+		id:          fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+func (w *Worker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	for i := 0; i < w.concurrency; i++ {
+		w.wg.Add(1)
+		go func(slot int) {
+			defer w.wg.Done()
+			w.loop(ctx, fmt.Sprintf("%s/%d", w.id, slot))
+		}(i)
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.requeueStaleJobs(ctx)
+	}()
+
+	log.Printf("Job worker %s started with %d slots", w.id, w.concurrency)
+}
+
+// Stop 新しいジョブの取得を止め、実行中のジョブの終了を待つ
+func (w *Worker) Stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *Worker) loop(ctx context.Context, workerID string) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		job, err := w.repository.ClaimNextJob(workerID, time.Now())
+		if err != nil {
+			log.Printf("Warning: Failed to claim job: %v", err)
+		}
+		if job != nil {
+			w.run(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// run ジョブを実行し、結果に応じて成功・リトライ待ち・失敗のいずれかにする
+func (w *Worker) run(ctx context.Context, job *models.BackgroundJob) {
+	log.Printf("Running job %d (%s), attempt %d/%d", job.ID, job.Type, job.Attempts, job.MaxAttempts)
+
+	// 実行中はハートビートでロックを延長し、ロックを失った場合はハンドラーの ctx をキャンセルする
+	lockedBy := job.LockedBy
+	reporter := &stepReporter{repository: w.repository, jobID: job.ID}
+	jobCtx, cancel := context.WithCancel(ctx)
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		w.heartbeat(jobCtx, cancel, job.ID, lockedBy, reporter)
+	}()
+
+	result, err := w.execute(jobCtx, job, reporter)
+	cancel()
+	<-heartbeatDone
+
+	if result != nil {
+		if resultJSON, marshalErr := json.Marshal(result); marshalErr == nil {
+			job.Result = string(resultJSON)
+		} else {
+			log.Printf("Warning: Failed to marshal result of job %d: %v", job.ID, marshalErr)
+		}
+	}
+
+	now := time.Now()
+	job.LockedBy = ""
+	job.LockedAt = nil
+
+	switch {
+	case err == nil:
+		job.Status = models.BackgroundJobStatusSucceeded
+		job.LastError = ""
+		job.FinishedAt = &now
+		log.Printf("Job %d (%s) succeeded", job.ID, job.Type)
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		job.Status = models.BackgroundJobStatusFailed
+		job.LastError = err.Error()
+		job.FinishedAt = &now
+		log.Printf("Job %d (%s) failed: %v", job.ID, job.Type, err)
+	default:
+		delay := retryDelay(job.Attempts)
+		job.Status = models.BackgroundJobStatusQueued
+		job.LastError = err.Error()
+		job.RunAt = now.Add(delay)
+		log.Printf("Job %d (%s) failed, retrying in %s: %v", job.ID, job.Type, delay, err)
+	}
+
+	// ロックを失っていた場合は別のワーカーが実行しているので、その結果を上書きしない
+	released, err := w.repository.ReleaseJob(job, lockedBy)
+	if err != nil {
+		log.Printf("Warning: Failed to update job %d: %v", job.ID, err)
+	} else if !released {
+		log.Printf("Warning: Job %d (%s) lost its lock while running, discarding the result", job.ID, job.Type)
+	}
+}
+
+// heartbeat ctx がキャンセルされるまで heartbeatInterval ごとにジョブの locked_at を更新する
+// ロックを失っていた場合は以降の進捗を保存しないようにし、cancel でハンドラーに中断を伝える
+func (w *Worker) heartbeat(ctx context.Context, cancel context.CancelFunc, jobID uint, lockedBy string, reporter *stepReporter) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		held, err := w.repository.HeartbeatJob(jobID, lockedBy, time.Now())
+		if err != nil {
+			log.Printf("Warning: Failed to heartbeat job %d: %v", jobID, err)
+			continue
+		}
+		if !held {
+			log.Printf("Warning: Job %d lost its lock, canceling", jobID)
+			reporter.lockLost.Store(true)
+			cancel()
+			return
+		}
+	}
+}
+
+// execute ハンドラーを呼び出す（panicはエラーとして扱う）
+func (w *Worker) execute(ctx context.Context, job *models.BackgroundJob, reporter progress.Reporter) (result any, err error) {
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return nil, Permanent(fmt.Errorf("unknown job type: %s", job.Type))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, json.RawMessage(job.Payload), reporter)
+}
+
+func (w *Worker) requeueStaleJobs(ctx context.Context) {
+	ticker := time.NewTicker(staleJobScanInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		count, err := w.repository.RequeueStaleJobs(now.Add(-staleJobTimeout), now)
+		if err != nil {
+			log.Printf("Warning: Failed to requeue stale jobs: %v", err)
+		} else if count > 0 {
+			log.Printf("Requeued %d stale jobs", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// retryDelay attempts 回目の失敗後の待ち時間
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= retryMaxDelay {
+			return retryMaxDelay
+		}
+	}
+	return delay
+}
+
+// stepReporter 銘柄ごとの進捗を BackgroundJobStep に保存する
+// ロックを失った後は、ジョブを引き継いだワーカーの進捗を上書きしないよう保存しない
+type stepReporter struct {
+	repository repositories.IBackgroundJobRepository
+	jobID      uint
+	lockLost   atomic.Bool
+}
+
+func (r *stepReporter) Report(item string, status string, message string) {
+	if r.lockLost.Load() {
+		return
+	}
+	step := &models.BackgroundJobStep{JobID: r.jobID, Item: item, Status: status, Message: message}
+	if err := r.repository.UpsertJobStep(step); err != nil {
+		log.Printf("Warning: Failed to save progress of job %d (%s): %v", r.jobID, item, err)
+	}
+}
@@ -0,0 +1,28 @@
+// Package progress は長時間かかる処理（同期・分析）の銘柄ごとの進捗を呼び出し元に通知するためのインターフェースを提供する
+package progress
+
+// 銘柄ごとの進捗状態
+const (
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped"
+)
+
+// Reporter 銘柄（ticker・銘柄コードなど）ごとの進捗を受け取る
+// 並行して呼ばれることがあるため、実装はゴルーチンセーフにすること
+type Reporter interface {
+	Report(item string, status string, message string)
+}
+
+// ReporterFunc 関数をReporterとして使うためのアダプター
+type ReporterFunc func(item string, status string, message string)
+
+func (f ReporterFunc) Report(item string, status string, message string) {
+	f(item, status, message)
+}
+
+// Nop 進捗を捨てるReporter（スケジューラーなど、進捗を記録しない呼び出し元用）
+func Nop() Reporter {
+	return ReporterFunc(func(string, string, string) {})
+}
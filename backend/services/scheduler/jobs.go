@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"stock-prediction/backend/models"
+	japanesestock "stock-prediction/backend/services/Japanese_Stock"
+	"stock-prediction/backend/services/calendar"
+	"stock-prediction/backend/services/jobqueue"
+	"strings"
+	"time"
+)
+
+// ジョブ名（実行履歴の JobName）
+const (
+	JobUSSync   = "us_sync"
+	JobUSXPost  = "us_xpost"
+	JobJPSync   = "jp_sync"
+	JobJPMovers = "jp_movers"
+)
+
+// デフォルトのスケジュール（取引所の現地時間、平日のみ）
+const (
+	usSyncSpec   = "CRON_TZ=America/New_York 30 16 * * 1-5" // NYSE引け（16:00）の30分後
+	usXPostSpec  = "CRON_TZ=America/New_York 0 18 * * 1-5"  // 米国株の同期（AI分析を含む）が終わった後
+	jpSyncSpec   = "CRON_TZ=Asia/Tokyo 0 16 * * 1-5"        // 東証引け（15:30）の30分後
+	jpMoversSpec = "CRON_TZ=Asia/Tokyo 0 18 * * 1-5"        // J-Quantsの日足（全銘柄）の更新後
+)
+
+const (
+	// ジョブキューに登録したジョブの登録者（BackgroundJob.CreatedBy）
+	queuedBy = "scheduler"
+	// 登録したジョブが終わったかを確認する間隔
+	queuedJobPollInterval = 10 * time.Second
+)
+
+// DefaultJobs 米国株の同期・X投稿、日本株のランキング計算・同期のジョブを作成する
+// 処理はジョブキューに登録して管理系APIから登録したジョブと同じワーカーで実行するので、
+// 同じ種類のジョブ（例: 定期実行と手動の同期）が同時に実行されることはない
+// 日本株の同期対象は JP_SYNC_SECTOR33（33業種コードまたは名称のカンマ区切り）で指定し、未設定の場合は登録しない
+func DefaultJobs(queue jobqueue.IJobQueue) []Job {
+	jobs := []Job{
+		{
+			Name:     JobUSSync,
+			Spec:     usSyncSpec,
+			Calendar: calendar.NYSE,
+			Run: func(ctx context.Context, tradingDate string) error {
+				_, err := runQueued(ctx, queue, jobqueue.TypeUSSync, nil)
+				return err
+			},
+		},
+		{
+			Name:      JobUSXPost,
+			Spec:      usXPostSpec,
+			Calendar:  calendar.NYSE,
+			DependsOn: JobUSSync,
+			Run: func(ctx context.Context, tradingDate string) error {
+				_, err := runQueued(ctx, queue, jobqueue.TypeXPost, jobqueue.XPostPayload{PostType: "all", Date: tradingDate})
+				return err
+			},
+		},
+		{
+			Name:     JobJPMovers,
+			Spec:     jpMoversSpec,
+			Calendar: calendar.TSE,
+			Run: func(ctx context.Context, tradingDate string) error {
+				_, err := runQueued(ctx, queue, jobqueue.TypeJPMovers, japanesestock.MoversFilter{Date: tradingDate})
+				return err
+			},
+		},
+	}
+
+	sectors := splitSectors(os.Getenv("JP_SYNC_SECTOR33"))
+	if len(sectors) == 0 {
+		log.Println("JP_SYNC_SECTOR33 is not set, skipping scheduled J-Quants sync")
+		return jobs
+	}
+
+	jobs = append(jobs, Job{
+		Name:     JobJPSync,
+		Spec:     jpSyncSpec,
+		Calendar: calendar.TSE,
+		Run: func(ctx context.Context, tradingDate string) error {
+			// 1セクターの失敗で他のセクターを止めないよう、エラーはまとめて返す
+			var errs []error
+			for _, sector := range sectors {
+				status, err := runQueued(ctx, queue, jobqueue.TypeJPSync, japanesestock.SectorFilter{Sector33: sector})
+				if err != nil {
+					errs = append(errs, fmt.Errorf("sector %s: %w", sector, err))
+					if ctx.Err() != nil {
+						break
+					}
+					continue
+				}
+				if status.Progress != nil && status.Progress.Failed > 0 {
+					errs = append(errs, fmt.Errorf("sector %s: %d/%d companies failed", sector, status.Progress.Failed, status.Progress.Total))
+				}
+			}
+			return errors.Join(errs...)
+		},
+	})
+
+	return jobs
+}
+
+// runQueued ジョブキューにジョブを登録し、成功または失敗（リトライを使い切った）するまで待つ
+func runQueued(ctx context.Context, queue jobqueue.IJobQueue, jobType string, payload any) (*jobqueue.JobStatus, error) {
+	job, err := queue.Enqueue(jobType, payload, queuedBy)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Queued %s job %d", jobType, job.ID)
+
+	ticker := time.NewTicker(queuedJobPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped waiting for %s job %d: %w", jobType, job.ID, ctx.Err())
+		case <-ticker.C:
+		}
+
+		status, err := queue.FindJob(job.ID)
+		if err != nil {
+			log.Printf("Warning: Failed to check %s job %d: %v", jobType, job.ID, err)
+			continue
+		}
+		switch status.Status {
+		case models.BackgroundJobStatusSucceeded:
+			return status, nil
+		case models.BackgroundJobStatusFailed:
+			return status, fmt.Errorf("%s job %d failed: %s", jobType, job.ID, status.LastError)
+		}
+	}
+}
+
+func splitSectors(value string) []string {
+	var sectors []string
+	for _, sector := range strings.Split(value, ",") {
+		if sector = strings.TrimSpace(sector); sector != "" {
+			sectors = append(sectors, sector)
+		}
+	}
+	return sectors
+}
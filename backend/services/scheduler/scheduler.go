@@ -0,0 +1,234 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"stock-prediction/backend/dto"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/calendar"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Job 定期実行するジョブ
+type Job struct {
+	Name string
+	// cron式（分 時 日 月 曜日）。取引所のタイムゾーンで書けるよう "CRON_TZ=America/New_York 30 16 * * 1-5" の形式を使う
+	Spec string
+	// 取引日・休場日の判定に使う取引所のカレンダー
+	Calendar *calendar.Calendar
+	// 同じ取引日にこのジョブが成功していない場合は予定時刻には実行せず、成功した時点で実行する（例: 同期が終わってから投稿する）
+	DependsOn string
+	// 実行本体。tradingDate は取引所の現地日付での実行日（YYYY-MM-DD）。ctx は Stop でキャンセルされる
+	Run func(ctx context.Context, tradingDate string) error
+}
+
+// JobEntry 登録済みジョブの状態
+type JobEntry struct {
+	Name      string     `json:"Name"`
+	Spec      string     `json:"Spec"`
+	DependsOn string     `json:"DependsOn,omitempty"`
+	Next      time.Time  `json:"Next"`
+	Prev      *time.Time `json:"Prev"`
+}
+
+type IScheduler interface {
+	Start()
+	Stop()
+	Jobs() []JobEntry
+	FindJobRuns(jobName string, limit int) ([]dto.JobRun, error)
+}
+
+type scheduler struct {
+	repository repositories.IJobRunRepository
+	cron       *cron.Cron
+	jobs       map[cron.EntryID]Job
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+// NewScheduler ジョブを登録したスケジューラーを作成する（Startを呼ぶまでは実行されない）
+func NewScheduler(repository repositories.IJobRunRepository, jobs ...Job) (IScheduler, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &scheduler{
+		repository: repository,
+		// 前回の実行が終わっていない場合は今回の実行をスキップし、panicしてもプロセスは落とさない
+		cron: cron.New(cron.WithChain(
+			cron.Recover(cron.DefaultLogger),
+			cron.SkipIfStillRunning(cron.DefaultLogger),
+		)),
+		jobs:   make(map[cron.EntryID]Job),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	for _, job := range jobs {
+		job := job
+		id, err := s.cron.AddFunc(job.Spec, func() { s.execute(job) })
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to schedule job %s (%q): %w", job.Name, job.Spec, err)
+		}
+		s.jobs[id] = job
+	}
+
+	return s, nil
+}
+
+func (s *scheduler) Start() {
+	s.cron.Start()
+	for _, entry := range s.Jobs() {
+		log.Printf("Scheduled job %s (%s), next run at %s", entry.Name, entry.Spec, entry.Next.Format(time.RFC3339))
+	}
+}
+
+// Stop 新しい実行を止め、実行中のジョブをキャンセルして終了を待つ
+func (s *scheduler) Stop() {
+	done := s.cron.Stop().Done()
+	s.cancel()
+	<-done
+}
+
+func (s *scheduler) Jobs() []JobEntry {
+	var entries []JobEntry
+	for _, entry := range s.cron.Entries() {
+		job := s.jobs[entry.ID]
+		jobEntry := JobEntry{Name: job.Name, Spec: job.Spec, DependsOn: job.DependsOn, Next: entry.Next}
+		if !entry.Prev.IsZero() {
+			prev := entry.Prev
+			jobEntry.Prev = &prev
+		}
+		entries = append(entries, jobEntry)
+	}
+	return entries
+}
+
+// FindJobRuns 実行履歴を新しい順に取得する（jobNameが空の場合は全ジョブ）
+func (s *scheduler) FindJobRuns(jobName string, limit int) ([]dto.JobRun, error) {
+	runs, err := s.repository.FindJobRuns(jobName, limit)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewJobRuns(*runs), nil
+}
+
+// execute cronから呼ばれ、取引所の現地日付を実行日としてジョブを実行する
+func (s *scheduler) execute(job Job) {
+	s.executeFor(job, time.Now().In(job.Calendar.Location()).Format("2006-01-02"))
+}
+
+// executeFor 休場日・依存ジョブを確認してから tradingDate のジョブを実行し、結果を実行履歴に記録する
+// 実行履歴の作成でその取引日の実行権を取得するので、複数のプロセスが同じ取引日のジョブを実行することはない
+// 休場日はスキップとして記録する。依存ジョブがまだ成功していない場合は実行権を取得せずに見送り、
+// 依存ジョブが成功した時点で runDependents から実行する
+func (s *scheduler) executeFor(job Job, tradingDate string) {
+	now := time.Now().In(job.Calendar.Location())
+	run := &models.JobRun{
+		JobName:     job.Name,
+		TradingDate: tradingDate,
+		Status:      models.JobRunStatusRunning,
+		StartedAt:   now,
+	}
+
+	if reason, closed := s.holiday(job, tradingDate); closed {
+		log.Printf("Skipping job %s for %s: %s", job.Name, tradingDate, reason)
+		run.Status = models.JobRunStatusSkipped
+		run.Message = reason
+		run.FinishedAt = &now
+		s.claim(run)
+		return
+	}
+
+	if !s.dependencySucceeded(job, tradingDate) {
+		return
+	}
+
+	if !s.claim(run) {
+		return
+	}
+
+	log.Printf("Running job %s for %s...", job.Name, tradingDate)
+	err := job.Run(s.ctx, tradingDate)
+
+	finishedAt := time.Now().In(job.Calendar.Location())
+	run.FinishedAt = &finishedAt
+	if err != nil {
+		log.Printf("Job %s failed: %v", job.Name, err)
+		run.Status = models.JobRunStatusFailed
+		run.Message = err.Error()
+	} else {
+		log.Printf("Job %s succeeded in %s", job.Name, finishedAt.Sub(now).Round(time.Second))
+		run.Status = models.JobRunStatusSucceeded
+	}
+	if err := s.repository.UpdateJobRun(run); err != nil {
+		log.Printf("Warning: Failed to save job run for %s: %v", run.JobName, err)
+	}
+
+	if run.Status == models.JobRunStatusSucceeded {
+		s.runDependents(job.Name, tradingDate)
+	}
+}
+
+// holiday tradingDate が取引所の休場日であればその理由を返す
+func (s *scheduler) holiday(job Job, tradingDate string) (string, bool) {
+	date, err := job.Calendar.ParseDate(tradingDate)
+	if err != nil {
+		return err.Error(), true
+	}
+	if holiday, closed := job.Calendar.Holiday(date); closed {
+		return fmt.Sprintf("%s closed: %s", job.Calendar.Name(), holiday), true
+	}
+	return "", false
+}
+
+// dependencySucceeded 依存ジョブが同じ取引日に成功しているか（依存ジョブが無い場合は true）
+// 成功していない・確認できない場合は実行履歴を作らないので、依存ジョブが後から成功すれば実行できる
+func (s *scheduler) dependencySucceeded(job Job, tradingDate string) bool {
+	if job.DependsOn == "" {
+		return true
+	}
+	succeeded, err := s.repository.HasSucceededJobRun(job.DependsOn, tradingDate)
+	if err != nil {
+		log.Printf("Warning: Failed to check %s for %s, not running %s: %v", job.DependsOn, tradingDate, job.Name, err)
+		return false
+	}
+	if !succeeded {
+		log.Printf("Job %s for %s is waiting for %s to succeed", job.Name, tradingDate, job.DependsOn)
+	}
+	return succeeded
+}
+
+// runDependents 成功したジョブに依存するジョブのうち、その取引日の予定時刻を過ぎているものを実行する
+// （依存ジョブが長引いて予定時刻に見送られたジョブ。予定時刻前のジョブは cron の実行に任せる）
+func (s *scheduler) runDependents(jobName string, tradingDate string) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	for _, entry := range s.cron.Entries() {
+		job := s.jobs[entry.ID]
+		if job.DependsOn != jobName || entry.Prev.IsZero() {
+			continue
+		}
+		if entry.Prev.In(job.Calendar.Location()).Format("2006-01-02") != tradingDate {
+			continue
+		}
+		s.executeFor(job, tradingDate)
+	}
+}
+
+// claim 実行履歴を作成して実行権を取得する
+// 別のプロセスが既に同じ取引日の実行履歴を作成していた場合や、作成に失敗した場合（重複を確認できない）は false を返す
+func (s *scheduler) claim(run *models.JobRun) bool {
+	claimed, err := s.repository.ClaimJobRun(run)
+	if err != nil {
+		log.Printf("Warning: Failed to claim job %s for %s, not running: %v", run.JobName, run.TradingDate, err)
+		return false
+	}
+	if !claimed {
+		log.Printf("Job %s for %s was already claimed by another instance, skipping", run.JobName, run.TradingDate)
+	}
+	return claimed
+}
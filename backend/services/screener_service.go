@@ -0,0 +1,223 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"stock-prediction/backend/repositories"
+	"strings"
+)
+
+const (
+	defaultScreenerPerPage = 50
+	maxScreenerPerPage     = 200
+	// 1回の検索で指定できる条件・in の候補の上限
+	maxScreenerConditions = 20
+	maxScreenerInValues   = 100
+)
+
+// ErrInvalidScreenerQuery 市場・項目・演算子・値のいずれかが不正（詳細はラップしたメッセージに含める）
+var ErrInvalidScreenerQuery = errors.New("invalid screener query")
+
+// ScreenerCondition 1つの絞り込み条件
+//   - op: eq / ne / gt / gte / lt / lte / between / in
+//   - value: between は [下限, 上限]、in は配列、それ以外は単一の値（文字列の項目は eq / ne / in のみ）
+type ScreenerCondition struct {
+	Field string          `json:"field"`
+	Op    string          `json:"op"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ScreenerRequest スクリーナーの検索条件（条件はすべてAND）
+// 例: {"market":"JP","conditions":[{"field":"PER","op":"between","value":[5,15]},{"field":"MarketCode","op":"in","value":["0111"]}],"sortBy":"MarketCap","sortOrder":"desc"}
+type ScreenerRequest struct {
+	Market     string              `json:"market"` // JP / US
+	Conditions []ScreenerCondition `json:"conditions"`
+	SortBy     string              `json:"sortBy"`    // 省略時は銘柄コード順
+	SortOrder  string              `json:"sortOrder"` // asc / desc（省略時は asc）
+	Page       int                 `json:"page"`      // 1始まり（省略時は1）
+	PerPage    int                 `json:"perPage"`   // 省略時は50、最大200
+}
+
+// ScreenerResult スクリーナーの結果（Total はページングする前の件数）
+type ScreenerResult struct {
+	Market  string                     `json:"Market"`
+	Page    int                        `json:"Page"`
+	PerPage int                        `json:"PerPage"`
+	Total   int64                      `json:"Total"`
+	Rows    []repositories.ScreenerRow `json:"Rows"`
+}
+
+type IScreenerService interface {
+	Screen(request ScreenerRequest) (*ScreenerResult, error)
+}
+
+type screenerService struct {
+	repository repositories.IScreenerRepository
+}
+
+func NewScreenerService(repository repositories.IScreenerRepository) IScreenerService {
+	return &screenerService{repository: repository}
+}
+
+// Screen 条件を検証して、会社情報・日次のバリュエーション・財務指標を横断して銘柄を絞り込む
+func (s *screenerService) Screen(request ScreenerRequest) (*ScreenerResult, error) {
+	query, err := buildScreenerQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, total, err := s.repository.Screen(*query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to screen stocks: %w", err)
+	}
+	if rows == nil {
+		rows = []repositories.ScreenerRow{}
+	}
+	return &ScreenerResult{
+		Market:  query.Market,
+		Page:    query.Offset/query.Limit + 1,
+		PerPage: query.Limit,
+		Total:   total,
+		Rows:    rows,
+	}, nil
+}
+
+// buildScreenerQuery リクエストを検証し、項目名・演算子を repositories.ScreenerFields の一覧にあるものだけに限定したクエリに変換する
+func buildScreenerQuery(request ScreenerRequest) (*repositories.ScreenerQuery, error) {
+	market := strings.ToUpper(request.Market)
+	fields, ok := repositories.ScreenerFields[market]
+	if !ok {
+		return nil, fmt.Errorf("%w: market must be 'JP' or 'US'", ErrInvalidScreenerQuery)
+	}
+	if len(request.Conditions) > maxScreenerConditions {
+		return nil, fmt.Errorf("%w: too many conditions (max %d)", ErrInvalidScreenerQuery, maxScreenerConditions)
+	}
+
+	query := &repositories.ScreenerQuery{Market: market}
+	for _, condition := range request.Conditions {
+		field, ok := fields[condition.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q for %s (available: %s)", ErrInvalidScreenerQuery, condition.Field, market, screenerFieldNames(fields))
+		}
+		values, err := parseScreenerValues(field, condition)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidScreenerQuery, condition.Field, err)
+		}
+		query.Conditions = append(query.Conditions, repositories.ScreenerCondition{
+			Field:  condition.Field,
+			Op:     condition.Op,
+			Values: values,
+		})
+	}
+
+	if request.SortBy != "" {
+		if _, ok := fields[request.SortBy]; !ok {
+			return nil, fmt.Errorf("%w: unknown sortBy %q for %s (available: %s)", ErrInvalidScreenerQuery, request.SortBy, market, screenerFieldNames(fields))
+		}
+		query.SortBy = request.SortBy
+	}
+	switch strings.ToLower(request.SortOrder) {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return nil, fmt.Errorf("%w: sortOrder must be 'asc' or 'desc'", ErrInvalidScreenerQuery)
+	}
+
+	page := request.Page
+	if page == 0 {
+		page = 1
+	}
+	perPage := request.PerPage
+	if perPage == 0 {
+		perPage = defaultScreenerPerPage
+	}
+	if page < 1 || perPage < 1 || perPage > maxScreenerPerPage {
+		return nil, fmt.Errorf("%w: page must be >= 1 and perPage must be between 1 and %d", ErrInvalidScreenerQuery, maxScreenerPerPage)
+	}
+	query.Limit = perPage
+	query.Offset = (page - 1) * perPage
+	return query, nil
+}
+
+// parseScreenerValues 演算子に応じて値を取り出す（文字列の項目は文字列、それ以外は数値のみ受け付ける）
+func parseScreenerValues(field repositories.ScreenerField, condition ScreenerCondition) ([]any, error) {
+	if len(condition.Value) == 0 {
+		return nil, errors.New("value is required")
+	}
+
+	switch condition.Op {
+	case repositories.ScreenerOpEq, repositories.ScreenerOpNe:
+		value, err := parseScreenerValue(field, condition.Value)
+		if err != nil {
+			return nil, err
+		}
+		return []any{value}, nil
+	case repositories.ScreenerOpGt, repositories.ScreenerOpGte, repositories.ScreenerOpLt, repositories.ScreenerOpLte:
+		if field.Text {
+			return nil, fmt.Errorf("operator %q is not available for text fields", condition.Op)
+		}
+		value, err := parseScreenerValue(field, condition.Value)
+		if err != nil {
+			return nil, err
+		}
+		return []any{value}, nil
+	case repositories.ScreenerOpBetween:
+		if field.Text {
+			return nil, fmt.Errorf("operator %q is not available for text fields", condition.Op)
+		}
+		var bounds []float64
+		if err := json.Unmarshal(condition.Value, &bounds); err != nil || len(bounds) != 2 {
+			return nil, errors.New("between requires [min, max]")
+		}
+		if bounds[0] > bounds[1] {
+			return nil, errors.New("between requires min <= max")
+		}
+		return []any{bounds[0], bounds[1]}, nil
+	case repositories.ScreenerOpIn:
+		var raws []json.RawMessage
+		if err := json.Unmarshal(condition.Value, &raws); err != nil || len(raws) == 0 {
+			return nil, errors.New("in requires a non-empty array")
+		}
+		if len(raws) > maxScreenerInValues {
+			return nil, fmt.Errorf("in accepts at most %d values", maxScreenerInValues)
+		}
+		values := make([]any, 0, len(raws))
+		for _, raw := range raws {
+			value, err := parseScreenerValue(field, raw)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %q. use 'eq', 'ne', 'gt', 'gte', 'lt', 'lte', 'between', or 'in'", condition.Op)
+	}
+}
+
+func parseScreenerValue(field repositories.ScreenerField, raw json.RawMessage) (any, error) {
+	if field.Text {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, errors.New("value must be a string")
+		}
+		return value, nil
+	}
+	var value float64
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, errors.New("value must be a number")
+	}
+	return value, nil
+}
+
+func screenerFieldNames(fields map[string]repositories.ScreenerField) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -1,75 +1,598 @@
 package services
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
-	"os"
+	"log"
+	"stock-prediction/backend/dto"
 	"stock-prediction/backend/models"
 	"stock-prediction/backend/repositories"
 	AI "stock-prediction/backend/services/AI"
+	"stock-prediction/backend/services/AI/llm"
 	america_stock "stock-prediction/backend/services/America_stock"
+	"stock-prediction/backend/services/calendar"
+	"stock-prediction/backend/services/indicators"
+	"stock-prediction/backend/services/progress"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type IStockService interface {
-	FindLatestRanking() (*[]models.DailyRanking, error)
-	FindDailyRanking(date string) (*[]models.DailyRanking, error)
-	FindStock(ticker string) (*[]models.DailyRanking, error)
-	SyncData() error
+	FindLatestRanking(category string, maxRank int) ([]dto.DailyRanking, error)
+	FindDailyRanking(date string, category string, maxRank int) ([]dto.DailyRanking, error)
+	FindStock(ticker string, query StockHistoryQuery) (*dto.Page[dto.DailyRanking], error)
+	SyncData(ctx context.Context, reporter progress.Reporter) error
+	FindSyncRuns(limit int) ([]dto.SyncRun, error)
+	FindDailyBars(ticker string, from string, to string) ([]dto.StockDailyBar, error)
+	FindIndicators(ticker string, date string) (*indicators.Snapshot, error)
+	FindValuation(ticker string, from string, to string) (*StockValuationHistory, error)
 }
 
+// ErrInvalidDateRange from が to より後の日付
+var ErrInvalidDateRange = errors.New("from must be on or before to")
+
+// ErrNoDailyBars 指標の計算に使う日足が保存されていない
+var ErrNoDailyBars = errors.New("no daily bars found")
+
+// ErrValuationNotFound 指定期間のバリュエーションが保存されていない
+var ErrValuationNotFound = errors.New("valuation not found")
+
+// ErrRankingNotFound 指定した日付・カテゴリ・市場区分のランキングが無い
+var ErrRankingNotFound = errors.New("ranking not found")
+
+// ErrInvalidCursor ページングの cursor が不正（別のAPIのカーソル・改変されたカーソルなど）
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// StockHistoryQuery 銘柄のランキング履歴の検索条件
+type StockHistoryQuery struct {
+	From     string // YYYY-MM-DD（含む）。空の場合は制限なし
+	To       string // YYYY-MM-DD（含む）。空の場合は制限なし
+	Category string // DailyRanking.Category の値。空の場合は全カテゴリ
+	MaxRank  int    // この順位以内のみ。0の場合は制限なし
+	Limit    int    // 1ページの件数
+	Cursor   string // 前のページの NextCursor。空の場合は先頭から
+}
+
+// 日足APIで from を省略した場合に返す期間
+const defaultBarsDays = 180
+
+// バリュエーションAPIで from を省略した場合に返す期間
+const defaultValuationDays = 365
+
+// StockValuationHistory 米国株のバリュエーションの推移
+type StockValuationHistory struct {
+	Ticker  string               `json:"Ticker"`
+	Latest  dto.StockValuation   `json:"Latest"`
+	History []dto.StockValuation `json:"History"` // from〜to の日次の値（日付の古い順、Latestを含む）
+}
+
+// rankingCategories ニュース取得・AI分析を行うランキングカテゴリ
+var rankingCategories = []string{models.CategoryTopGainers, models.CategoryTopLosers, models.CategoryMostActivelyTraded}
+
 type stockservice struct {
-	repository repositories.IStockRepository
+	repository        repositories.IStockRepository
+	syncRunRepository repositories.ISyncRunRepository
+	moversProvider    america_stock.MoversProvider
+	profileProvider   america_stock.ProfileProvider
+	barsProvider      america_stock.BarsProvider
+	perShareProvider  america_stock.PerShareProvider
+	llmClient         llm.LLMClient
 }
 
-func NewStockService(repository repositories.IStockRepository) IStockService {
-	return &stockservice{repository: repository}
+func NewStockService(repository repositories.IStockRepository, syncRunRepository repositories.ISyncRunRepository, moversProvider america_stock.MoversProvider, profileProvider america_stock.ProfileProvider, barsProvider america_stock.BarsProvider, perShareProvider america_stock.PerShareProvider, llmClient llm.LLMClient) IStockService {
+	return &stockservice{
+		repository:        repository,
+		syncRunRepository: syncRunRepository,
+		moversProvider:    moversProvider,
+		profileProvider:   profileProvider,
+		barsProvider:      barsProvider,
+		perShareProvider:  perShareProvider,
+		llmClient:         llmClient,
+	}
 }
 
-func (s *stockservice) FindLatestRanking() (*[]models.DailyRanking, error) {
-	return s.repository.FindLatestRanking()
+// FindLatestRanking category は DailyRanking.Category の値（models.CategoryTopGainers など）
+// maxRank 位以内を返す。ランキングが無い場合は repositories.ErrNotFound を返す
+func (s *stockservice) FindLatestRanking(category string, maxRank int) ([]dto.DailyRanking, error) {
+	rankings, err := s.repository.FindLatestRanking(category, maxRank)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewDailyRankings(*rankings), nil
 }
 
-func (s *stockservice) FindDailyRanking(date string) (*[]models.DailyRanking, error) {
-	return s.repository.FindDailyRanking(date)
+// FindDailyRanking 休場日が指定された場合は、その日以前の直近の取引日のランキングを返す
+// date の形式が不正な場合は calendar.ErrInvalidDate、ランキングが無い場合は ErrRankingNotFound を返す
+func (s *stockservice) FindDailyRanking(date string, category string, maxRank int) ([]dto.DailyRanking, error) {
+	tradingDate, err := calendar.NYSE.SnapDate(date)
+	if err != nil {
+		return nil, err
+	}
+	rankings, err := s.repository.FindDailyRanking(tradingDate, category, maxRank)
+	if err != nil {
+		return nil, err
+	}
+	if len(*rankings) == 0 {
+		return nil, ErrRankingNotFound
+	}
+	return dto.NewDailyRankings(*rankings), nil
 }
 
-func (s *stockservice) FindStock(ticker string) (*[]models.DailyRanking, error) {
-	return s.repository.FindStock(ticker)
+// FindStock 銘柄のランキング履歴を日付の新しい順に1ページ分返す
+// 日付の形式が不正な場合は calendar.ErrInvalidDate、from が to より後の場合は ErrInvalidDateRange、cursor が不正な場合は ErrInvalidCursor、
+// 銘柄が登録されていない場合は repositories.ErrNotFound を返す
+func (s *stockservice) FindStock(ticker string, query StockHistoryQuery) (*dto.Page[dto.DailyRanking], error) {
+	for _, date := range []string{query.From, query.To} {
+		if date == "" {
+			continue
+		}
+		if _, err := calendar.NYSE.ParseDate(date); err != nil {
+			return nil, err
+		}
+	}
+	if query.From != "" && query.To != "" && query.From > query.To {
+		return nil, ErrInvalidDateRange
+	}
+
+	repositoryQuery := repositories.RankingHistoryQuery{
+		From:     query.From,
+		To:       query.To,
+		Category: query.Category,
+		MaxRank:  query.MaxRank,
+		// 次のページがあるかを判定するため1件多く取得する
+		Limit: query.Limit + 1,
+	}
+	if query.Cursor != "" {
+		cursor, err := decodeRankingCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		repositoryQuery.After = cursor
+	}
+
+	// 履歴が0件の場合に「ランキング入りしていない」と「銘柄が無い」を区別する
+	if _, err := s.repository.FindStockByTicker(ticker); err != nil {
+		return nil, err
+	}
+	rankings, err := s.repository.FindStock(ticker, repositoryQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	items := *rankings
+	page := &dto.Page[dto.DailyRanking]{Limit: query.Limit}
+	if len(items) > query.Limit {
+		items = items[:query.Limit]
+		last := items[len(items)-1]
+		nextCursor := encodeRankingCursor(repositories.RankingCursor{Date: last.Date, ID: last.ID})
+		page.NextCursor = &nextCursor
+		page.HasMore = true
+	}
+	page.Items = dto.NewDailyRankings(items)
+	return page, nil
 }
 
-func (s *stockservice) SyncData() error {
-	AlphaVantageApiKey := os.Getenv("ALPHA_VANTAGE_API_KEY")
-	if AlphaVantageApiKey == "" {
-		return fmt.Errorf("ALPHA_VANTAGE_API_KEY is not set")
+// encodeRankingCursor ランキング履歴の位置を cursor パラメータの文字列にする（"日付|ID" をURLセーフなBase64にしたもの）
+func encodeRankingCursor(cursor repositories.RankingCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s|%d", cursor.Date, cursor.ID)))
+}
+
+func decodeRankingCursor(value string) (*repositories.RankingCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	date, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := calendar.NYSE.ParseDate(date); err != nil {
+		return nil, ErrInvalidCursor
 	}
+	parsedID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &repositories.RankingCursor{Date: date, ID: uint(parsedID)}, nil
+}
+
+// FindDailyBars tickerの from〜to（YYYY-MM-DD, 両端を含む）の日足を返す
+// to を省略した場合はNYSEの直近の取引日、from を省略した場合は to の180日前とする
+// 日付の形式が不正な場合は calendar.ErrInvalidDate、from が to より後の場合は ErrInvalidDateRange を返す
+func (s *stockservice) FindDailyBars(ticker string, from string, to string) ([]dto.StockDailyBar, error) {
+	from, to, err := resolveDateRange(calendar.NYSE, from, to, defaultBarsDays)
+	if err != nil {
+		return nil, err
+	}
+	bars, err := s.repository.FindDailyBars(ticker, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewStockDailyBars(*bars), nil
+}
 
-	FmpApiKey := os.Getenv("FMP_API_KEY")
-	if FmpApiKey == "" {
-		return fmt.Errorf("FMP_API_KEY is not set")
+// FindValuation tickerの from〜to（YYYY-MM-DD, 両端を含む）のバリュエーションの推移と最新の値を返す
+// to を省略した場合はNYSEの直近の取引日、from を省略した場合は to の1年前とする
+// 日付の形式が不正な場合は calendar.ErrInvalidDate、from が to より後の場合は ErrInvalidDateRange、期間内に値が無い場合は ErrValuationNotFound を返す
+func (s *stockservice) FindValuation(ticker string, from string, to string) (*StockValuationHistory, error) {
+	from, to, err := resolveDateRange(calendar.NYSE, from, to, defaultValuationDays)
+	if err != nil {
+		return nil, err
+	}
+
+	valuations, err := s.repository.FindStockValuations(ticker, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(*valuations) == 0 {
+		return nil, ErrValuationNotFound
+	}
+	return &StockValuationHistory{
+		Ticker:  ticker,
+		Latest:  dto.NewStockValuation((*valuations)[len(*valuations)-1]),
+		History: dto.NewStockValuations(*valuations),
+	}, nil
+}
+
+// resolveDateRange 期間指定のAPIの from / to を検証し、省略された値を補う
+// to を省略した場合は cal の直近の取引日、from を省略した場合は to の defaultDays 日前とする
+func resolveDateRange(cal *calendar.Calendar, from string, to string, defaultDays int) (string, string, error) {
+	if to == "" {
+		to = cal.LatestSessionDate(time.Now())
+	}
+	toDate, err := cal.ParseDate(to)
+	if err != nil {
+		return "", "", err
 	}
+	if from == "" {
+		from = toDate.AddDate(0, 0, -defaultDays).Format("2006-01-02")
+	} else if _, err := cal.ParseDate(from); err != nil {
+		return "", "", err
+	}
+	if from > to {
+		return "", "", ErrInvalidDateRange
+	}
+	return from, to, nil
+}
 
-	// Alpha Vantage APIからデータを取得
-	alphadata, err := america_stock.FetchAlphaVantageData(AlphaVantageApiKey)
+// FindIndicators ticker の date 時点（省略時はNYSEの直近の取引日）のテクニカル指標を保存済みの日足から計算する
+// 日付の形式が不正な場合は calendar.ErrInvalidDate、日足が無い場合は ErrNoDailyBars を返す
+func (s *stockservice) FindIndicators(ticker string, date string) (*indicators.Snapshot, error) {
+	if date == "" {
+		date = calendar.NYSE.LatestSessionDate(time.Now())
+	}
+	toDate, err := calendar.NYSE.ParseDate(date)
 	if err != nil {
-		return fmt.Errorf("failed to fetch Alpha Vantage data: %w", err)
+		return nil, err
 	}
+	from := toDate.AddDate(0, 0, -indicators.LookbackDays).Format("2006-01-02")
 
-	// Alpha Vantage APIから取得したデータをDBに保存
-	if err := america_stock.SaveAlphaVantageDatatoDB(alphadata, s.repository); err != nil {
-		return fmt.Errorf("failed to save data to DB: %w", err)
+	bars, err := s.repository.FindDailyBars(ticker, from, date)
+	if err != nil {
+		return nil, err
 	}
+	snapshot := indicators.Compute(indicators.FromStockDailyBars(*bars))
+	if snapshot == nil {
+		return nil, ErrNoDailyBars
+	}
+	return snapshot, nil
+}
+
+// SyncData ランキング → 企業情報 → 日足 → バリュエーション → ニュース → AI分析 の順に同期し、取引日ごとに SyncRun として記録する
+// 同じ取引日を再実行した場合は成功済みのステージを飛ばし、保存済みのデータを使って失敗・未実行のステージだけを実行する
+// 銘柄ごとの進捗は reporter に "profile/<ticker>"・"bars/<ticker>"・"valuation/<ticker>"・"news/<category>/<ticker>"・"analysis/<category>/<ticker>" の単位で通知する
+// ctx がキャンセルされた場合は実行中のステージを銘柄の区切りで止め、残りのステージを実行せずに ctx のエラーを返す
+func (s *stockservice) SyncData(ctx context.Context, reporter progress.Reporter) error {
+	latestDate := calendar.NYSE.LatestSessionDate(time.Now())
 
-	//Top Gainersの企業情報を更新（静的情報は空の場合のみ、動的情報は常に更新させる）
-	for _, tickerData := range alphadata.TopGainers {
-		if err := america_stock.SyncCompanyInfo(tickerData.Ticker, s.repository, FmpApiKey); err != nil {
-			// エラーが発生してもログに記録するのみで全体は中断しない
-			fmt.Printf("failed to sync company info for %s: %v\n", tickerData.Ticker, err)
+	// 直近の取引日のランキングを引け後に保存済みであれば、プロバイダーを呼ばずに保存済みのランキングを使う
+	run, err := s.syncRunRepository.FindSyncRunByDate(latestDate)
+	if err != nil || !moversSettled(run) {
+		if run, err = s.syncMovers(latestDate); err != nil {
+			return err
 		}
+	} else {
+		if syncRunCompleted(run) {
+			log.Printf("Sync for %s has already completed, nothing to do", run.TradingDate)
+			return nil
+		}
+		log.Printf("Resuming sync for %s with stored movers", run.TradingDate)
+		s.startSyncRun(run)
 	}
 
-	// AI分析を実行
-	if err := AI.PerformDailyAnalysis(s.repository); err != nil {
-		return fmt.Errorf("failed to perform daily analysis: %w", err)
+	stages := []struct {
+		name string
+		run  func() error
+	}{
+		{models.SyncStageProfiles, func() error { return s.syncProfiles(ctx, run.TradingDate, reporter) }},
+		{models.SyncStageBars, func() error { return s.syncBars(ctx, run.TradingDate, reporter) }},
+		{models.SyncStageValuation, func() error { return s.syncValuations(ctx, run.TradingDate, reporter) }},
+		{models.SyncStageNews, func() error { return s.syncNews(ctx, run.TradingDate, reporter) }},
+		{models.SyncStageAI, func() error { return s.syncAnalysis(ctx, run.TradingDate, reporter) }},
 	}
 
+	// 企業情報・日足・ニュースの取得に失敗しても後続のステージは実行する（失敗したステージは再実行時にやり直す）
+	var stageErrs []error
+	for _, stage := range stages {
+		if ctx.Err() != nil {
+			break
+		}
+		if run.Stage(stage.name).Status == models.SyncStatusSucceeded {
+			log.Printf("Skipping %s stage for %s (already succeeded)", stage.name, run.TradingDate)
+			continue
+		}
+		if err := s.runSyncStage(run, stage.name, stage.run); err != nil {
+			stageErrs = append(stageErrs, fmt.Errorf("%s: %w", stage.name, err))
+		}
+	}
+	s.finishSyncRun(run)
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("sync for %s was canceled: %w", run.TradingDate, err)
+	}
+	if len(stageErrs) > 0 {
+		return fmt.Errorf("sync for %s has failed stages: %w", run.TradingDate, errors.Join(stageErrs...))
+	}
 	return nil
 }
+
+func (s *stockservice) FindSyncRuns(limit int) ([]dto.SyncRun, error) {
+	runs, err := s.syncRunRepository.FindSyncRuns(limit)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewSyncRuns(*runs), nil
+}
+
+// syncMovers ランキングを取得して保存し、その取引日の SyncRun を返す
+// ランキングを取り直した場合、後続のステージは保存済みの銘柄を飛ばしながらやり直す
+func (s *stockservice) syncMovers(latestDate string) (*models.SyncRun, error) {
+	// ランキングを取得（先頭のプロバイダーが失敗した場合は次のプロバイダーにフォールバック）
+	movers, fetchErr := s.moversProvider.FetchMovers()
+	if fetchErr != nil {
+		// 取引日はランキングから決まるので、取得できなかった場合は直近の取引日の記録に残す
+		if run, err := s.syncRunRepository.FindOrCreateSyncRun(latestDate); err != nil {
+			log.Printf("Warning: Failed to record sync run for %s: %v", latestDate, err)
+		} else {
+			s.startSyncRun(run)
+			s.runSyncStage(run, models.SyncStageMovers, func() error { return fetchErr })
+			s.finishSyncRun(run)
+		}
+		return nil, fmt.Errorf("failed to fetch movers: %w", fetchErr)
+	}
+	log.Printf("Fetched movers for %s from %s", movers.Date, movers.Provider)
+
+	run, err := s.syncRunRepository.FindOrCreateSyncRun(movers.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record sync run for %s: %w", movers.Date, err)
+	}
+	run.Provider = movers.Provider
+	s.startSyncRun(run)
+
+	// 取得したランキングをDBに保存
+	if err := s.runSyncStage(run, models.SyncStageMovers, func() error {
+		return america_stock.SaveMoversToDB(movers, s.repository)
+	}); err != nil {
+		s.finishSyncRun(run)
+		return nil, fmt.Errorf("failed to save data to DB: %w", err)
+	}
+
+	for _, name := range models.SyncStages[1:] {
+		stage := run.Stage(name)
+		if stage.Status == models.SyncStatusSucceeded {
+			stage.Status = models.SyncStatusPending
+			s.saveSyncRunStage(stage)
+		}
+	}
+
+	return run, nil
+}
+
+// syncProfiles その日のランキングに出てきた銘柄の企業情報を更新する
+// 静的情報は空の場合のみ、動的情報はその日の分が未保存の場合のみ取得する（複数カテゴリに出てくる銘柄は1回だけ）
+func (s *stockservice) syncProfiles(ctx context.Context, date string, reporter progress.Reporter) error {
+	rankings, err := s.repository.FindRankingsByDate(date)
+	if err != nil {
+		return fmt.Errorf("failed to find rankings for %s: %w", date, err)
+	}
+
+	var errs []error
+	synced := make(map[string]bool)
+	for _, ranking := range *rankings {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ticker := ranking.Stock.Ticker
+		if synced[ticker] {
+			continue
+		}
+		synced[ticker] = true
+
+		if saved, err := s.repository.HasStockMetric(ranking.StockID, date); err == nil && saved && ranking.Stock.Name != "" {
+			continue
+		}
+
+		item := "profile/" + ticker
+		if err := america_stock.SyncCompanyInfo(ticker, date, s.repository, s.profileProvider); err != nil {
+			// エラーが発生しても他の銘柄の同期は続ける
+			fmt.Printf("failed to sync company info for %s: %v\n", ticker, err)
+			reporter.Report(item, progress.StatusFailed, err.Error())
+			errs = append(errs, err)
+			continue
+		}
+		reporter.Report(item, progress.StatusSucceeded, "")
+	}
+
+	return errors.Join(errs...)
+}
+
+// syncBars カテゴリごとの上位銘柄について、その日までの日足を同期する（保存済みの日付より後の分だけを取得する）
+func (s *stockservice) syncBars(ctx context.Context, date string, reporter progress.Reporter) error {
+	return s.forEachRankedTicker(ctx, date, func(ticker string) error {
+		item := "bars/" + ticker
+		if err := america_stock.SyncDailyBars(ticker, date, s.repository, s.barsProvider); err != nil {
+			log.Printf("Warning: Failed to sync daily bars for %s: %v", ticker, err)
+			reporter.Report(item, progress.StatusFailed, err.Error())
+			return err
+		}
+		reporter.Report(item, progress.StatusSucceeded, "")
+		return nil
+	})
+}
+
+// syncValuations カテゴリごとの上位銘柄について、日足の終値とプロバイダーの1株あたりの指標からバリュエーションを計算して保存する
+func (s *stockservice) syncValuations(ctx context.Context, date string, reporter progress.Reporter) error {
+	return s.forEachRankedTicker(ctx, date, func(ticker string) error {
+		item := "valuation/" + ticker
+		if err := america_stock.SyncValuation(ticker, date, s.repository, s.perShareProvider); err != nil {
+			log.Printf("Warning: Failed to sync valuation for %s: %v", ticker, err)
+			reporter.Report(item, progress.StatusFailed, err.Error())
+			return err
+		}
+		reporter.Report(item, progress.StatusSucceeded, "")
+		return nil
+	})
+}
+
+// forEachRankedTicker その日のいずれかのカテゴリにランクインした銘柄ごとに fn を1回ずつ実行する（失敗しても残りの銘柄は続行する）
+// ctx がキャンセルされた場合は残りの銘柄を実行せずに ctx のエラーを返す
+func (s *stockservice) forEachRankedTicker(ctx context.Context, date string, fn func(ticker string) error) error {
+	var errs []error
+	visited := make(map[string]bool)
+	for _, category := range rankingCategories {
+		rankings, err := s.repository.FindDailyRanking(date, category, repositories.DefaultRankingMaxRank)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to find %s rankings for %s: %w", category, date, err))
+			continue
+		}
+
+		for _, ranking := range *rankings {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			ticker := ranking.Stock.Ticker
+			if visited[ticker] {
+				continue
+			}
+			visited[ticker] = true
+
+			if err := fn(ticker); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// syncNews カテゴリごとに上位銘柄のニュースを取得して保存する
+func (s *stockservice) syncNews(ctx context.Context, date string, reporter progress.Reporter) error {
+	var errs []error
+	for _, category := range rankingCategories {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := AI.CollectDailyNews(ctx, s.repository, date, category, reporter); err != nil {
+			errs = append(errs, fmt.Errorf("failed to collect news for %s: %w", category, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// syncAnalysis カテゴリごとに上位銘柄のAI分析を実行する
+func (s *stockservice) syncAnalysis(ctx context.Context, date string, reporter progress.Reporter) error {
+	var errs []error
+	for _, category := range rankingCategories {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := AI.PerformDailyAnalysis(ctx, s.repository, s.llmClient, date, category, reporter); err != nil {
+			errs = append(errs, fmt.Errorf("failed to perform daily analysis for %s: %w", category, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runSyncStage ステージを実行し、結果を SyncRunStage に記録する
+func (s *stockservice) runSyncStage(run *models.SyncRun, name string, fn func() error) error {
+	stage := run.Stage(name)
+	now := time.Now()
+	stage.Status = models.SyncStatusRunning
+	stage.Attempts++
+	stage.StartedAt = &now
+	stage.FinishedAt = nil
+	stage.Message = ""
+	s.saveSyncRunStage(stage)
+
+	err := fn()
+
+	finishedAt := time.Now()
+	stage.FinishedAt = &finishedAt
+	if err != nil {
+		stage.Status = models.SyncStatusFailed
+		stage.Message = err.Error()
+	} else {
+		stage.Status = models.SyncStatusSucceeded
+	}
+	s.saveSyncRunStage(stage)
+
+	return err
+}
+
+func (s *stockservice) startSyncRun(run *models.SyncRun) {
+	now := time.Now()
+	run.Status = models.SyncStatusRunning
+	run.Attempts++
+	run.StartedAt = &now
+	run.FinishedAt = nil
+	s.saveSyncRun(run)
+}
+
+func (s *stockservice) finishSyncRun(run *models.SyncRun) {
+	now := time.Now()
+	run.FinishedAt = &now
+	run.Status = models.SyncStatusFailed
+	if syncRunCompleted(run) {
+		run.Status = models.SyncStatusSucceeded
+	}
+	s.saveSyncRun(run)
+}
+
+// 記録の保存に失敗しても同期自体は続ける（次回の実行で該当ステージがやり直されるだけ）
+func (s *stockservice) saveSyncRun(run *models.SyncRun) {
+	if err := s.syncRunRepository.UpdateSyncRun(run); err != nil {
+		log.Printf("Warning: Failed to save sync run for %s: %v", run.TradingDate, err)
+	}
+}
+
+func (s *stockservice) saveSyncRunStage(stage *models.SyncRunStage) {
+	if err := s.syncRunRepository.UpdateSyncRunStage(stage); err != nil {
+		log.Printf("Warning: Failed to save %s stage of sync run %d: %v", stage.Stage, stage.SyncRunID, err)
+	}
+}
+
+// moversSettled ランキングが引け後に保存済みか（取引時間中に保存したランキングは確定していないので取り直す）
+func moversSettled(run *models.SyncRun) bool {
+	stage := run.Stage(models.SyncStageMovers)
+	if stage == nil || stage.Status != models.SyncStatusSucceeded || stage.FinishedAt == nil {
+		return false
+	}
+	tradingDay, err := calendar.NYSE.ParseDate(run.TradingDate)
+	if err != nil {
+		return false
+	}
+	return !stage.FinishedAt.Before(calendar.NYSE.CloseTime(tradingDay))
+}
+
+// syncRunCompleted 全ステージが成功しているか
+func syncRunCompleted(run *models.SyncRun) bool {
+	for _, name := range models.SyncStages {
+		stage := run.Stage(name)
+		if stage == nil || stage.Status != models.SyncStatusSucceeded {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,217 @@
+// Package valuation は終値と1株あたりの指標から PER・PBR・配当利回り・ROE を計算する
+//
+// 米国株はプロバイダーの直近12ヶ月（TTM）の1株あたりの指標を使い（america_stock.SyncValuation）、
+// 日本株は各取引日までに開示された財務指標（FinancialMetric）から直近12ヶ月の値を組み立てる（SyncJapaneseValuations）。
+// スクリーナーの条件に使えるよう、日足から計算した前日比・RSI（PriceSignals）も合わせて保存する。
+package valuation
+
+import (
+	"fmt"
+	"math"
+	"stock-prediction/backend/models"
+	"stock-prediction/backend/repositories"
+	fundamentals "stock-prediction/backend/services/Japanese_Stock/fandamentals"
+	"stock-prediction/backend/services/indicators"
+)
+
+// RSIの期間（indicators.Snapshot と同じ）
+const rsiPeriod = 14
+
+// Compute 終値と1株あたりの指標からバリュエーションを計算する
+// ROE は EPS / BPS（期末の1株当たり純資産に対する直近12ヶ月の利益）で近似する
+func Compute(close float64, earningsPerShare *float64, bookValuePerShare *float64, dividendPerShare *float64) models.ValuationRatios {
+	ratios := models.ValuationRatios{
+		Close:             close,
+		EarningsPerShare:  earningsPerShare,
+		BookValuePerShare: bookValuePerShare,
+		DividendPerShare:  dividendPerShare,
+	}
+	if close <= 0 {
+		return ratios
+	}
+
+	// 赤字・債務超過の場合は倍率に意味がないので計算しない
+	if earningsPerShare != nil && *earningsPerShare > 0 {
+		per := close / *earningsPerShare
+		ratios.PER = &per
+	}
+	if bookValuePerShare != nil && *bookValuePerShare > 0 {
+		pbr := close / *bookValuePerShare
+		ratios.PBR = &pbr
+		if earningsPerShare != nil {
+			roe := *earningsPerShare / *bookValuePerShare * 100
+			ratios.ROE = &roe
+		}
+	}
+	if dividendPerShare != nil && *dividendPerShare >= 0 {
+		dividendYield := *dividendPerShare / close * 100
+		ratios.DividendYield = &dividendYield
+	}
+	return ratios
+}
+
+// JapaneseInputs 日本株のバリュエーションの計算に使う、ある日付時点の1株あたりの指標と株式数
+type JapaneseInputs struct {
+	EarningsPerShare  *float64
+	BookValuePerShare *float64
+	DividendPerShare  *float64
+	SharesOutstanding *float64 // 自己株式を除く期末発行済株式数
+	DisclosureNumber  string   // EPS の計算に使った直近の開示（実績が無い場合は空文字）
+}
+
+// FindJapaneseInputs date（YYYY-MM-DD）までに開示された財務指標から、1株あたりの指標を組み立てる
+//   - EPS: 直近の実績が四半期の場合は「前期のFY + 当期の累計 - 前期の同じ期の累計」で直近12ヶ月に換算する（前期の値が無い場合は直近のFYの実績）
+//   - BPS: 1株当たり純資産が開示された直近の値
+//   - 配当: 直近の開示の通期予想（FYの決算短信は翌期予想）、予想が無い場合は直近のFYの実績
+//   - 株式数: 期末発行済株式数が開示された直近の値から自己株式数を引いたもの
+func FindJapaneseInputs(metrics []models.FinancialMetric, date string) JapaneseInputs {
+	var latestActual, latestFY, latestBPS, latestDividend, latestShares *models.FinancialMetric
+	// 会計年度（期末の年月）・期ごとの実績（財務指標の前年同期比と同じ fundamentals.PeriodKey で対応付ける）
+	actuals := make(map[fundamentals.PeriodKey]*models.FinancialMetric)
+
+	for i := range metrics {
+		metric := &metrics[i]
+		if metric.DisclosedDate > date {
+			continue
+		}
+		if metric.EarningsPerShare != nil {
+			if key, ok := fundamentals.NewPeriodKey(metric.CurrentFiscalYearEndDate, metric.TypeOfCurrentPeriod); ok {
+				if current := actuals[key]; current == nil || fundamentals.LaterDisclosure(metric, current) {
+					actuals[key] = metric
+				}
+			}
+			if latestActual == nil || fundamentals.LaterDisclosure(metric, latestActual) {
+				latestActual = metric
+			}
+			if metric.TypeOfCurrentPeriod == "FY" && (latestFY == nil || fundamentals.LaterDisclosure(metric, latestFY)) {
+				latestFY = metric
+			}
+		}
+		if metric.BookValuePerShare != nil && (latestBPS == nil || fundamentals.LaterDisclosure(metric, latestBPS)) {
+			latestBPS = metric
+		}
+		if forecastDividend(metric) != nil && (latestDividend == nil || fundamentals.LaterDisclosure(metric, latestDividend)) {
+			latestDividend = metric
+		}
+		if metric.IssuedShares != nil && (latestShares == nil || fundamentals.LaterDisclosure(metric, latestShares)) {
+			latestShares = metric
+		}
+	}
+
+	var inputs JapaneseInputs
+	if latestActual != nil {
+		inputs.DisclosureNumber = latestActual.DisclosureNumber
+		inputs.EarningsPerShare = trailingEarningsPerShare(latestActual, actuals)
+		if inputs.EarningsPerShare == nil && latestFY != nil {
+			inputs.EarningsPerShare = latestFY.EarningsPerShare
+		}
+	}
+	if latestBPS != nil {
+		inputs.BookValuePerShare = latestBPS.BookValuePerShare
+	}
+	if latestDividend != nil {
+		inputs.DividendPerShare = forecastDividend(latestDividend)
+	} else if latestFY != nil {
+		inputs.DividendPerShare = latestFY.DividendPerShare
+	}
+	if latestShares != nil {
+		shares := *latestShares.IssuedShares
+		if latestShares.TreasuryShares != nil {
+			shares -= *latestShares.TreasuryShares
+		}
+		inputs.SharesOutstanding = &shares
+	}
+	return inputs
+}
+
+// ComputePriceSignals 日付の古い順に並んだ日足について、各日の前日比・RSI(14)を計算する（戻り値は bars と同じ並び）
+func ComputePriceSignals(bars []indicators.Bar) []models.PriceSignals {
+	rsi := indicators.RSI(indicators.Closes(bars), rsiPeriod)
+
+	signals := make([]models.PriceSignals, len(bars))
+	for i := range bars {
+		if !math.IsNaN(rsi[i]) {
+			value := rsi[i]
+			signals[i].RSI14 = &value
+		}
+		if i > 0 && bars[i-1].Close != 0 {
+			changeRate := (bars[i].Close/bars[i-1].Close - 1) * 100
+			signals[i].ChangeRate = &changeRate
+		}
+	}
+	return signals
+}
+
+// SyncJapaneseValuations 保存済みの日足すべてについて、その日までに開示された財務指標からバリュエーションを計算して保存する（保存した件数を返す）
+// 財務指標（FinancialMetric）を作り直した後に呼ぶ
+func SyncJapaneseValuations(code string, repository repositories.IJapaneseStockRepository) (int, error) {
+	dailyQuotes, err := repository.FindDailyQuotesByCode(code, "", "")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find daily quotes for %s: %w", code, err)
+	}
+	metrics, err := repository.FindFinancialMetricsByCode(code)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find financial metrics for %s: %w", code, err)
+	}
+
+	// 前日比・RSIは株式分割の影響を受けないよう調整後の終値で計算する（売買が無かった日は除外される）
+	bars := indicators.FromDailyQuotes(dailyQuotes)
+	signals := make(map[string]models.PriceSignals, len(bars))
+	for i, signal := range ComputePriceSignals(bars) {
+		signals[bars[i].Date] = signal
+	}
+
+	valuations := make([]models.JapaneseStockValuation, 0, len(dailyQuotes))
+	for _, quote := range dailyQuotes {
+		// 売買が無かった日は終値が0で返るので除外する
+		if quote.Close == 0 {
+			continue
+		}
+		// PER・PBRなどは開示時点の1株あたりの指標と揃うよう、調整前の終値を使う
+		inputs := FindJapaneseInputs(metrics, quote.Date)
+		valuation := models.JapaneseStockValuation{
+			Code:             code,
+			Date:             quote.Date,
+			ValuationRatios:  Compute(quote.Close, inputs.EarningsPerShare, inputs.BookValuePerShare, inputs.DividendPerShare),
+			PriceSignals:     signals[quote.Date],
+			DisclosureNumber: inputs.DisclosureNumber,
+		}
+		if inputs.SharesOutstanding != nil {
+			marketCap := quote.Close * *inputs.SharesOutstanding
+			valuation.MarketCap = &marketCap
+		}
+		valuations = append(valuations, valuation)
+	}
+
+	if err := repository.UpsertJapaneseStockValuations(valuations); err != nil {
+		return 0, fmt.Errorf("failed to upsert valuations for %s: %w", code, err)
+	}
+	return len(valuations), nil
+}
+
+// trailingEarningsPerShare 直近の実績から直近12ヶ月のEPSを計算する（計算できない場合は nil）
+func trailingEarningsPerShare(latest *models.FinancialMetric, actuals map[fundamentals.PeriodKey]*models.FinancialMetric) *float64 {
+	key, ok := fundamentals.NewPeriodKey(latest.CurrentFiscalYearEndDate, latest.TypeOfCurrentPeriod)
+	if !ok {
+		return nil
+	}
+	if key.IsFullYear() {
+		return latest.EarningsPerShare
+	}
+
+	lastFY := actuals[key.LastFiscalYear()]
+	lastYearSamePeriod := actuals[key.LastYear()]
+	if lastFY == nil || lastYearSamePeriod == nil {
+		return nil
+	}
+	value := *lastFY.EarningsPerShare + *latest.EarningsPerShare - *lastYearSamePeriod.EarningsPerShare
+	return &value
+}
+
+// forecastDividend 開示に含まれる通期の配当予想（FYの決算短信は翌期予想）
+func forecastDividend(metric *models.FinancialMetric) *float64 {
+	if metric.TypeOfCurrentPeriod == "FY" {
+		return metric.NextYearForecastDividendPerShare
+	}
+	return metric.ForecastDividendPerShare
+}
@@ -7,7 +7,9 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"stock-prediction/backend/models"
 	"stock-prediction/backend/repositories"
+	"stock-prediction/backend/services/calendar"
 	"time"
 
 	"github.com/dghubble/oauth1"
@@ -105,7 +107,12 @@ func (s *xPostService) PostRanking(date string) error {
 	}
 	// ===== デバッグコード終了 =====
 
-	rankings, err := s.repository.FindDailyRanking(date)
+	date, err := tradingDate(date)
+	if err != nil {
+		return err
+	}
+
+	rankings, err := s.repository.FindDailyRanking(date, models.CategoryTopGainers, repositories.DefaultRankingMaxRank)
 	if err != nil {
 		return fmt.Errorf("failed to find daily rankings:%w", err)
 	}
@@ -117,6 +124,11 @@ func (s *xPostService) PostRanking(date string) error {
 
 // 後者: 個別分析投稿（5件まとめて）
 func (s *xPostService) PostAnalysis(date string) error {
+	date, err := tradingDate(date)
+	if err != nil {
+		return err
+	}
+
 	for rank := 1; rank <= 5; rank++ {
 		if err := s.PostSingleAnalysis(date, rank); err != nil {
 			// エラーログを残しつつ続行
@@ -130,7 +142,12 @@ func (s *xPostService) PostAnalysis(date string) error {
 
 // 個別分析投稿（1件ずつ）
 func (s *xPostService) PostSingleAnalysis(date string, rank int) error {
-	ranking, err := s.repository.FindDailyRankingByDateAndRank(date, rank, "Top Gainers")
+	date, err := tradingDate(date)
+	if err != nil {
+		return err
+	}
+
+	ranking, err := s.repository.FindDailyRankingByDateAndRank(date, rank, models.CategoryTopGainers)
 	if err != nil {
 		return fmt.Errorf("failed to find daily ranking data:%w", err)
 	}
@@ -139,6 +156,14 @@ func (s *xPostService) PostSingleAnalysis(date string, rank int) error {
 	return s.postToX(text)
 }
 
+// tradingDate 投稿対象の取引日（未指定の場合はNYSEの直近の取引日、休場日の場合はその日以前の直近の取引日）
+func tradingDate(date string) (string, error) {
+	if date == "" {
+		return calendar.NYSE.LatestSessionDate(time.Now()), nil
+	}
+	return calendar.NYSE.SnapDate(date)
+}
+
 // X APIを使用しての投稿
 func (s *xPostService) postToX(text string) error {
 	url := "https://api.twitter.com/2/tweets"
@@ -4,14 +4,21 @@ import { useParams, useRouter } from 'next/navigation';
 import Link from 'next/link';
 import Header from '@/components/Header';
 import TimelineItem from '@/components/TimelineItem';
-import { useStockHistory } from '@/hooks/useStocks';
+import PriceChart from '@/components/PriceChart';
+import { useStockHistory, useStockBars, useStockIndicators, useStockValuation } from '@/hooks/useStocks';
 import { ChevronLeft } from 'lucide-react';
 
 export default function StockDetailPage() {
   const params = useParams();
   const router = useRouter();
   const ticker = params.ticker as string;
-  const { data, isLoading } = useStockHistory(ticker);
+  const { data, isLoading, hasNextPage, fetchNextPage, isFetchingNextPage } = useStockHistory(ticker, 5);
+  const { data: bars, isLoading: isBarsLoading } = useStockBars(ticker);
+  const { data: indicators } = useStockIndicators(ticker);
+  const { data: valuation } = useStockValuation(ticker);
+
+  const formatIndicator = (value: number | null | undefined, digits = 2, suffix = '') =>
+    value === null || value === undefined ? '-' : `${value.toFixed(digits)}${suffix}`;
 
   return (
     <div className="relative flex h-auto min-h-screen w-full flex-col">
@@ -64,6 +71,58 @@ export default function StockDetailPage() {
               )}
             </div>
 
+            {/* Price Chart */}
+            <div className="flex flex-col rounded-xl border border-zinc-200 bg-white dark:border-zinc-800 dark:bg-zinc-900">
+              <div className="border-b border-zinc-200 p-4 dark:border-zinc-800 sm:p-6">
+                <h3 className="text-lg font-bold text-zinc-900 dark:text-white">
+                  株価の推移（終値）
+                </h3>
+              </div>
+              <div className="p-4 sm:p-6">
+                {isBarsLoading ? (
+                  <div className="animate-pulse">
+                    <div className="h-56 bg-gray-700 rounded" />
+                  </div>
+                ) : (
+                  <PriceChart bars={bars ?? []} />
+                )}
+                {indicators && (
+                  <dl className="mt-6 grid grid-cols-2 gap-4 sm:grid-cols-4">
+                    {[
+                      ['20日移動平均', formatIndicator(indicators.SMA20)],
+                      ['200日移動平均', formatIndicator(indicators.SMA200)],
+                      ['RSI(14)', formatIndicator(indicators.RSI14, 1)],
+                      ['MACD', formatIndicator(indicators.MACD, 3)],
+                      ['ATR(14)', formatIndicator(indicators.ATR14Percent, 2, '%')],
+                      ['出来高Zスコア', formatIndicator(indicators.VolumeZScore20)],
+                      ['52週高値から', formatIndicator(indicators.DistanceFromHigh52Week, 1, '%')],
+                      ['52週安値から', formatIndicator(indicators.DistanceFromLow52Week, 1, '%')],
+                    ].map(([label, value]) => (
+                      <div key={label}>
+                        <dt className="text-xs text-zinc-500 dark:text-zinc-400">{label}</dt>
+                        <dd className="text-sm font-semibold text-zinc-900 dark:text-white">{value}</dd>
+                      </div>
+                    ))}
+                  </dl>
+                )}
+                {valuation && (
+                  <dl className="mt-6 grid grid-cols-2 gap-4 border-t border-zinc-200 pt-6 dark:border-zinc-800 sm:grid-cols-4">
+                    {[
+                      ['PER', formatIndicator(valuation.Latest.PER, 1, '倍')],
+                      ['PBR', formatIndicator(valuation.Latest.PBR, 2, '倍')],
+                      ['配当利回り', formatIndicator(valuation.Latest.DividendYield, 2, '%')],
+                      ['ROE', formatIndicator(valuation.Latest.ROE, 1, '%')],
+                    ].map(([label, value]) => (
+                      <div key={label}>
+                        <dt className="text-xs text-zinc-500 dark:text-zinc-400">{label}</dt>
+                        <dd className="text-sm font-semibold text-zinc-900 dark:text-white">{value}</dd>
+                      </div>
+                    ))}
+                  </dl>
+                )}
+              </div>
+            </div>
+
             {/* Timeline */}
             <div className="flex flex-col rounded-xl border border-zinc-200 bg-white dark:border-zinc-800 dark:bg-zinc-900">
               <div className="border-b border-zinc-200 p-4 dark:border-zinc-800 sm:p-6">
@@ -88,6 +147,18 @@ export default function StockDetailPage() {
                         isLast={index === data.history.length - 1}
                       />
                     ))}
+                    {hasNextPage && (
+                      <div className="col-span-2 flex justify-center pt-4">
+                        <button
+                          type="button"
+                          onClick={() => fetchNextPage()}
+                          disabled={isFetchingNextPage}
+                          className="flex h-10 cursor-pointer items-center justify-center rounded-lg bg-zinc-100 px-4 text-sm font-medium text-zinc-600 transition-colors hover:bg-zinc-200 disabled:cursor-not-allowed disabled:opacity-50 dark:bg-zinc-800 dark:text-zinc-300 dark:hover:bg-zinc-700"
+                        >
+                          {isFetchingNextPage ? '読み込み中...' : 'さらに読み込む'}
+                        </button>
+                      </div>
+                    )}
                   </div>
                 ) : (
                   <div className="text-center py-8">
@@ -0,0 +1,65 @@
+'use client';
+
+import { StockDailyBar } from '@/types/stock';
+
+interface PriceChartProps {
+  bars: StockDailyBar[];
+}
+
+const WIDTH = 640;
+const HEIGHT = 220;
+const PADDING = 8;
+
+// 終値の推移を折れ線で描画する（外部のチャートライブラリは使わずSVGで描く）
+export default function PriceChart({ bars }: PriceChartProps) {
+  if (bars.length < 2) {
+    return (
+      <div className="text-center py-8">
+        <p className="text-zinc-500 dark:text-zinc-400">株価データがありません</p>
+      </div>
+    );
+  }
+
+  const closes = bars.map((bar) => bar.Close);
+  const min = Math.min(...closes);
+  const max = Math.max(...closes);
+  const range = max - min || 1;
+
+  const points = bars
+    .map((bar, i) => {
+      const x = PADDING + (i / (bars.length - 1)) * (WIDTH - PADDING * 2);
+      const y = PADDING + (1 - (bar.Close - min) / range) * (HEIGHT - PADDING * 2);
+      return `${x.toFixed(1)},${y.toFixed(1)}`;
+    })
+    .join(' ');
+
+  const first = bars[0];
+  const last = bars[bars.length - 1];
+  const isUp = last.Close >= first.Close;
+  const changeRate = ((last.Close - first.Close) / first.Close) * 100;
+
+  return (
+    <div className="flex flex-col gap-3">
+      <div className="flex items-baseline justify-between">
+        <p className="text-2xl font-bold text-zinc-900 dark:text-white">${last.Close.toFixed(2)}</p>
+        <p className={`text-sm font-medium ${isUp ? 'text-green-500' : 'text-red-500'}`}>
+          {isUp ? '+' : ''}
+          {changeRate.toFixed(2)}% ({first.Date} 〜 {last.Date})
+        </p>
+      </div>
+      <svg viewBox={`0 0 ${WIDTH} ${HEIGHT}`} className="h-56 w-full" preserveAspectRatio="none">
+        <polyline
+          points={points}
+          fill="none"
+          strokeWidth={2}
+          vectorEffect="non-scaling-stroke"
+          className={isUp ? 'stroke-green-500' : 'stroke-red-500'}
+        />
+      </svg>
+      <div className="flex justify-between text-xs text-zinc-500 dark:text-zinc-400">
+        <span>安値 ${min.toFixed(2)}</span>
+        <span>高値 ${max.toFixed(2)}</span>
+      </div>
+    </div>
+  );
+}
@@ -1,14 +1,39 @@
 'use client';
 
+import { isAxiosError } from 'axios';
 import { useSyncStocks } from '@/hooks/useStocks';
 import { RefreshCw } from 'lucide-react';
 
+// 入力されたAPIキーはタブを閉じるまでsessionStorageに保持する
+const API_KEY_STORAGE_KEY = 'adminApiKey';
+
 export default function SyncButton() {
   const { mutate: syncStocks, isPending } = useSyncStocks();
 
+  const handleClick = () => {
+    let apiKey = sessionStorage.getItem(API_KEY_STORAGE_KEY);
+    if (!apiKey) {
+      apiKey = window.prompt('管理用APIキーを入力してください');
+      if (!apiKey) {
+        return;
+      }
+      sessionStorage.setItem(API_KEY_STORAGE_KEY, apiKey);
+    }
+
+    syncStocks(apiKey, {
+      onError: (error) => {
+        // キーが無効・権限不足の場合は次回入力し直せるよう破棄する
+        if (isAxiosError(error) && (error.response?.status === 401 || error.response?.status === 403)) {
+          sessionStorage.removeItem(API_KEY_STORAGE_KEY);
+          window.alert('APIキーが無効か、権限が不足しています');
+        }
+      },
+    });
+  };
+
   return (
     <button
-      onClick={() => syncStocks()}
+      onClick={handleClick}
       disabled={isPending}
       className="flex min-w-[84px] max-w-[480px] cursor-pointer items-center justify-center gap-2 overflow-hidden rounded-lg h-10 px-4 bg-primary text-white text-sm font-bold leading-normal tracking-[0.015em] hover:bg-primary/80 transition-colors disabled:opacity-50 disabled:cursor-not-allowed"
     >
@@ -1,12 +1,12 @@
-import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
+import { useQuery, useInfiniteQuery, useMutation, useQueryClient, InfiniteData } from '@tanstack/react-query';
 import api from '@/lib/api';
-import { DailyRanking, StockDisplayData, StockInfo, RankingHistory } from '@/types/stock';
+import { DailyRanking, StockDisplayData, StockInfo, RankingHistory, RankingCategory, BackgroundJob, StockDailyBar, TechnicalIndicators, StockValuationHistory, JapaneseDailyRanking, JapaneseMarketSegment, Page } from '@/types/stock';
 
-export const useLatestStocks = () => {
+export const useLatestStocks = (category: RankingCategory = 'gainers') => {
     return useQuery<DailyRanking[], Error, StockDisplayData[]>({
-        queryKey: ['ranking', 'latest'],
+        queryKey: ['ranking', 'latest', category],
         queryFn: async (): Promise<DailyRanking[]> => {
-            const response = await api.get('/api/stocks/latest');
+            const response = await api.get('/api/stocks/latest', { params: { category } });
             return response.data;
         },
         select: (data: DailyRanking[]): StockDisplayData[] => {
@@ -24,11 +24,11 @@ export const useLatestStocks = () => {
     });
 };
 
-export const useDateStocks = (date: string) => {
+export const useDateStocks = (date: string, category: RankingCategory = 'gainers') => {
     return useQuery<DailyRanking[], Error, StockDisplayData[]>({
-        queryKey: ['ranking', date],
+        queryKey: ['ranking', date, category],
         queryFn: async (): Promise<DailyRanking[]> => {
-            const response = await api.get(`/api/stocks/date?date=${date}`);
+            const response = await api.get('/api/stocks/date', { params: { date, category } });
             return response.data;
         },
         select: (data: DailyRanking[]): StockDisplayData[] => {
@@ -47,14 +47,39 @@ export const useDateStocks = (date: string) => {
     });
 };
 
-export const useStockHistory = (ticker: string) => {
-    return useQuery<DailyRanking[], Error, { stockInfo: StockInfo; history: RankingHistory[] }>({
-        queryKey: ['stocks', ticker],
-        queryFn: async (): Promise<DailyRanking[]> => {
-            const response = await api.get(`/api/stocks/${ticker}`);
+export const useLatestJapaneseStocks = (category: RankingCategory = 'gainers', market: JapaneseMarketSegment = 'all') => {
+    return useQuery<JapaneseDailyRanking[], Error>({
+        queryKey: ['jp-ranking', 'latest', category, market],
+        queryFn: async (): Promise<JapaneseDailyRanking[]> => {
+            const response = await api.get('/api/jp/stocks/latest', { params: { category, market } });
             return response.data;
         },
-        select: (data: DailyRanking[]): { stockInfo: StockInfo; history: RankingHistory[] } => {
+    });
+};
+
+export const useDateJapaneseStocks = (date: string, category: RankingCategory = 'gainers', market: JapaneseMarketSegment = 'all') => {
+    return useQuery<JapaneseDailyRanking[], Error>({
+        queryKey: ['jp-ranking', date, category, market],
+        queryFn: async (): Promise<JapaneseDailyRanking[]> => {
+            const response = await api.get('/api/jp/stocks/date', { params: { date, category, market } });
+            return response.data;
+        },
+        enabled: !!date,
+    });
+};
+
+// 1ページ目以降は fetchNextPage で続きを取得する（maxRank は省略時に全順位）
+export const useStockHistory = (ticker: string, maxRank?: number, limit = 50) => {
+    return useInfiniteQuery<Page<DailyRanking>, Error, { stockInfo: StockInfo; history: RankingHistory[] }, (string | number | undefined)[], string | undefined>({
+        queryKey: ['stocks', ticker, maxRank, limit],
+        queryFn: async ({ pageParam }): Promise<Page<DailyRanking>> => {
+            const response = await api.get(`/api/stocks/${ticker}`, { params: { maxRank, limit, cursor: pageParam } });
+            return response.data;
+        },
+        initialPageParam: undefined,
+        getNextPageParam: (lastPage) => lastPage.NextCursor ?? undefined,
+        select: (pages: InfiniteData<Page<DailyRanking>, string | undefined>): { stockInfo: StockInfo; history: RankingHistory[] } => {
+            const data = pages.pages.flatMap((page) => page.Items);
             if (data.length === 0) {
                 return {
                     stockInfo: {
@@ -91,12 +116,63 @@ export const useStockHistory = (ticker: string) => {
     });
 };
 
+// from / to は省略時にサーバー側で直近180日分になる
+export const useStockBars = (ticker: string, from?: string, to?: string) => {
+    return useQuery<StockDailyBar[], Error>({
+        queryKey: ['stocks', ticker, 'bars', from, to],
+        queryFn: async (): Promise<StockDailyBar[]> => {
+            const response = await api.get(`/api/stocks/${ticker}/bars`, { params: { from, to } });
+            return response.data;
+        },
+        enabled: !!ticker,
+    });
+};
+
+export const useStockIndicators = (ticker: string, date?: string) => {
+    return useQuery<TechnicalIndicators, Error>({
+        queryKey: ['stocks', ticker, 'indicators', date],
+        queryFn: async (): Promise<TechnicalIndicators> => {
+            const response = await api.get(`/api/stocks/${ticker}/indicators`, { params: { date } });
+            return response.data;
+        },
+        enabled: !!ticker,
+        retry: false,
+    });
+};
+
+export const useStockValuation = (ticker: string, from?: string, to?: string) => {
+    return useQuery<StockValuationHistory, Error>({
+        queryKey: ['stocks', ticker, 'valuation', from, to],
+        queryFn: async (): Promise<StockValuationHistory> => {
+            const response = await api.get(`/api/stocks/${ticker}/valuation`, { params: { from, to } });
+            return response.data;
+        },
+        enabled: !!ticker,
+        retry: false,
+    });
+};
+
+const SYNC_JOB_POLL_INTERVAL_MS = 3000;
+
 export const useSyncStocks = () => {
     const queryClient = useQueryClient();
     
     return useMutation({
-        mutationFn: async (): Promise<void> => {
-            await api.post('/api/admin/sync');
+        // 管理系APIはAPIキー（operator以上）が必要
+        // 同期はジョブとして登録されるので、完了（成功・失敗）するまでジョブの状態をポーリングする
+        mutationFn: async (apiKey: string): Promise<void> => {
+            const headers = { Authorization: `Bearer ${apiKey}` };
+            const { data: enqueued } = await api.post<BackgroundJob>('/api/admin/sync', null, { headers });
+
+            let job = enqueued;
+            while (job.Status === 'queued' || job.Status === 'running') {
+                await new Promise((resolve) => setTimeout(resolve, SYNC_JOB_POLL_INTERVAL_MS));
+                const response = await api.get<BackgroundJob>(`/api/admin/jobs/${enqueued.ID}`, { headers });
+                job = response.data;
+            }
+            if (job.Status === 'failed') {
+                throw new Error(job.LastError || 'sync job failed');
+            }
         },
         onSuccess: () => {
             queryClient.invalidateQueries({ queryKey: ['ranking'] });
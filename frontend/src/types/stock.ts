@@ -20,7 +20,82 @@ export interface Stock {
     Name: string;
     Sector: string;
     Industry: string;
-    Ranking: DailyRanking[];
+    Description: string;
+    Website: string;
+    Country: string;
+    FullTimeEmployees: number;
+    Image: string;
+    IpoDate: string;
+    CEO: string;
+}
+
+// APIのエラーレスポンス（全API共通）
+export interface ApiError {
+    code: 'bad_request' | 'unauthorized' | 'forbidden' | 'not_found' | 'method_not_allowed' | 'internal_error';
+    message: string;
+    details?: Record<string, unknown>;
+}
+
+// 米国株の日足（GET /api/stocks/:ticker/bars）
+export interface StockDailyBar {
+    ID: number;
+    StockID: number;
+    Date: string;
+    Open: number;
+    High: number;
+    Low: number;
+    Close: number;
+    Volume: number;
+    Provider: string;
+}
+
+// テクニカル指標（本数が足りず計算できない指標は null）
+export interface TechnicalIndicators {
+    Date: string;
+    Close: number;
+    Bars: number;
+    SMA20: number | null;
+    SMA50: number | null;
+    SMA200: number | null;
+    EMA12: number | null;
+    EMA26: number | null;
+    RSI14: number | null;
+    MACD: number | null;
+    MACDSignal: number | null;
+    MACDHistogram: number | null;
+    BollingerUpper: number | null;
+    BollingerMiddle: number | null;
+    BollingerLower: number | null;
+    BollingerPercentB: number | null;
+    ATR14: number | null;
+    ATR14Percent: number | null;
+    VolumeZScore20: number | null;
+    High52Week: number;
+    Low52Week: number;
+    DistanceFromHigh52Week: number;
+    DistanceFromLow52Week: number;
+}
+
+// バリュエーション（1株あたりの指標が取得できない場合は null）
+export interface StockValuation {
+    ID: number;
+    StockID: number;
+    Date: string;
+    Close: number;
+    EarningsPerShare: number | null;
+    BookValuePerShare: number | null;
+    DividendPerShare: number | null;
+    PER: number | null;
+    PBR: number | null;
+    DividendYield: number | null;
+    ROE: number | null;
+    Provider: string;
+}
+
+export interface StockValuationHistory {
+    Ticker: string;
+    Latest: StockValuation;
+    History: StockValuation[];
 }
 
 // 画面表示用の型定義
@@ -47,4 +122,48 @@ export type RankingHistory = {
     rank: number;
     changeRate: number;
     aiAnalysis: string;
-};
\ No newline at end of file
+};
+
+// カーソルページングのレスポンス（NextCursor を次のリクエストの cursor に渡す）
+export interface Page<T> {
+    Items: T[];
+    NextCursor: string | null;
+    HasMore: boolean;
+    Limit: number;
+}
+
+// /api/stocks/latest・/api/stocks/date の category パラメータ
+export type RankingCategory = 'gainers' | 'losers' | 'active';
+
+// /api/jp/stocks/latest・/api/jp/stocks/date の market パラメータ
+export type JapaneseMarketSegment = 'all' | 'prime' | 'standard' | 'growth';
+
+// 日本株の日次ランキング（全銘柄の日足から計算）
+export interface JapaneseDailyRanking {
+    ID: number;
+    Date: string;
+    Segment: JapaneseMarketSegment;
+    Category: string;
+    Rank: number;
+    Code: string;
+    CompanyName: string;
+    MarketCode: string;
+    MarketCodeName: string;
+    Sector33CodeName: string;
+    Price: number;
+    PreviousClose: number;
+    ChangeAmount: number;
+    ChangeRate: number;
+    Volume: number;
+    TurnoverValue: number;
+}
+
+// 管理系APIのバックグラウンドジョブ（GET /api/admin/jobs/:id）
+export type BackgroundJob = {
+    ID: number;
+    Type: string;
+    Status: 'queued' | 'running' | 'succeeded' | 'failed';
+    Attempts: number;
+    MaxAttempts: number;
+    LastError?: string;
+};